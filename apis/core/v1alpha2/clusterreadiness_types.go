@@ -0,0 +1,110 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterReadinessSpec defines the desired state of ClusterReadiness
+type ClusterReadinessSpec struct {
+	// ReadinessName is the name of the Readiness that is collected from each workload cluster
+	ReadinessName string `json:"readinessName"`
+
+	// ClusterSelector selects the Cluster API Clusters in the namespace of the ClusterReadiness
+	// whose Readiness is collected. If not provided, all the Clusters in the namespace are selected.
+	//+kubebuilder:validation:Optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+
+	// Checks is the set of checks of the Readiness that are aggregated across the clusters.
+	// If not provided, all the checks reported by the clusters are aggregated.
+	//+kubebuilder:validation:Optional
+	Checks []string `json:"checks,omitempty"`
+
+	// ResyncInterval is the interval at which the Readiness is collected from the workload clusters.
+	// Defaults to 60s.
+	//+kubebuilder:validation:Optional
+	ResyncInterval *metav1.Duration `json:"resyncInterval,omitempty"`
+}
+
+// ClusterReadinessStatus defines the observed state of ClusterReadiness
+type ClusterReadinessStatus struct {
+	// ReadyClusters is the number of selected clusters whose Readiness is ready
+	ReadyClusters int32 `json:"readyClusters"`
+
+	// TotalClusters is the number of selected clusters
+	TotalClusters int32 `json:"totalClusters"`
+
+	// Clusters is the Readiness collected from each selected cluster
+	//+kubebuilder:validation:Optional
+	Clusters []WorkloadClusterReadiness `json:"clusters,omitempty"`
+
+	// Checks lists, for each aggregated check, the clusters in which the check is not ready
+	//+kubebuilder:validation:Optional
+	Checks []ClusterCheckStatus `json:"checks,omitempty"`
+}
+
+// WorkloadClusterReadiness is the Readiness collected from a workload cluster
+type WorkloadClusterReadiness struct {
+	// Name is the name of the Cluster
+	Name string `json:"name"`
+
+	// Ready is true if the Readiness of the cluster is ready
+	Ready bool `json:"ready"`
+
+	// Message describes why the Readiness could not be collected from the cluster
+	//+kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+
+	// NotReadyChecks are the aggregated checks that are not ready in the cluster
+	//+kubebuilder:validation:Optional
+	NotReadyChecks []string `json:"notReadyChecks,omitempty"`
+
+	// LastCollectedTime is the time at which the Readiness was last collected from the cluster
+	//+kubebuilder:validation:Optional
+	LastCollectedTime *metav1.Time `json:"lastCollectedTime,omitempty"`
+}
+
+// ClusterCheckStatus is the state of a check across the selected clusters
+type ClusterCheckStatus struct {
+	// Name is the name of the check
+	Name string `json:"name"`
+
+	// ReadyClusters is the number of clusters in which the check is ready
+	ReadyClusters int32 `json:"readyClusters"`
+
+	// NotReadyClusters are the clusters in which the check is not ready or whose Readiness could not be collected
+	//+kubebuilder:validation:Optional
+	NotReadyClusters []string `json:"notReadyClusters,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Readiness",type=string,JSONPath=`.spec.readinessName`
+//+kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.readyClusters`
+//+kubebuilder:printcolumn:name="Total",type=integer,JSONPath=`.status.totalClusters`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterReadiness is the Schema for the clusterreadinesses API.
+// It aggregates the Readiness of the Cluster API workload clusters managed from a management cluster.
+type ClusterReadiness struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterReadinessSpec   `json:"spec,omitempty"`
+	Status ClusterReadinessStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterReadinessList contains a list of ClusterReadiness
+type ClusterReadinessList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterReadiness `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterReadiness{}, &ClusterReadinessList{})
+}
@@ -0,0 +1,212 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ComputeFeatureActivation computes the effective activation of features from their intended activation, taking the
+// dependencies and conflicts between features into account. A feature is activated only if it is intended to be
+// activated, all the features it depends on are activated, and none of the features it conflicts with would be
+// activated. A conflict blocks both features. It returns the activated features, and for each feature that is
+// intended to be activated but is blocked, the reason why it is blocked.
+func ComputeFeatureActivation(features []Feature, intents map[string]bool) (activated map[string]bool, blocked map[string]string) {
+	graph := newFeatureGraph(features)
+
+	// satisfied holds whether a feature is intended to be activated and its dependencies are satisfied, ignoring
+	// conflicts. A feature in a dependency cycle is never satisfied.
+	satisfied := map[string]bool{}
+	reasons := map[string]string{}
+	visiting := map[string]bool{}
+	var satisfy func(name string) bool
+	satisfy = func(name string) bool {
+		if result, ok := satisfied[name]; ok {
+			return result
+		}
+		if visiting[name] {
+			reasons[name] = "feature is part of a dependency cycle"
+			return false
+		}
+		if !intents[name] {
+			return false
+		}
+		visiting[name] = true
+		defer delete(visiting, name)
+
+		result := true
+		for _, dependency := range graph.dependsOn[name] {
+			if _, ok := graph.features[dependency]; !ok {
+				reasons[name] = fmt.Sprintf("feature depends on feature %s, which does not exist", dependency)
+				result = false
+				break
+			}
+			if !satisfy(dependency) {
+				if _, ok := reasons[name]; !ok {
+					reasons[name] = fmt.Sprintf("feature depends on feature %s, which is not activated", dependency)
+				}
+				result = false
+				break
+			}
+		}
+		satisfied[name] = result
+		return result
+	}
+
+	var names []string
+	for name := range graph.features {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		satisfy(name)
+	}
+
+	activated = map[string]bool{}
+	var activate func(name string) bool
+	activate = func(name string) bool {
+		if result, ok := activated[name]; ok {
+			return result
+		}
+		if !satisfied[name] {
+			activated[name] = false
+			return false
+		}
+
+		result := true
+		for _, conflict := range graph.conflicts(name) {
+			if satisfied[conflict] {
+				reasons[name] = fmt.Sprintf("feature conflicts with feature %s, which is also activated", conflict)
+				result = false
+				break
+			}
+		}
+		if result {
+			for _, dependency := range graph.dependsOn[name] {
+				if !activate(dependency) {
+					reasons[name] = fmt.Sprintf("feature depends on feature %s, which is not activated", dependency)
+					result = false
+					break
+				}
+			}
+		}
+		activated[name] = result
+		return result
+	}
+
+	blocked = map[string]string{}
+	for _, name := range names {
+		if !activate(name) && intents[name] {
+			blocked[name] = reasons[name]
+		}
+	}
+	return activated, blocked
+}
+
+// ComputeFeatureIntents returns the intended activation of features: the activation set by the feature reference of
+// the FeatureGate with the highest priority that references them, or the default activation of the stability level of
+// the feature. References that the stability policy does not allow are ignored. If featureGate is not nil, it replaces
+// the FeatureGate with the same name. A feature whose activation is rolled out to a subset of the namespaces is not
+// activated in the whole cluster, so a reference with a rollout does not change its intended activation. Stability
+// level policies are resolved through the FeaturePolicy resources.
+func ComputeFeatureIntents(features []Feature, featureGates []FeatureGate, featureGate *FeatureGate, featurePolicies []FeaturePolicy) map[string]bool {
+	gates := make([]FeatureGate, 0, len(featureGates)+1)
+	for i := range featureGates {
+		if featureGate != nil && featureGates[i].Name == featureGate.Name {
+			continue
+		}
+		gates = append(gates, featureGates[i])
+	}
+	if featureGate != nil {
+		gates = append(gates, *featureGate)
+	}
+
+	intents := map[string]bool{}
+	for i := range features {
+		policy := GetPolicyForStabilityLevel(features[i].Spec.Stability, featurePolicies...)
+		intents[features[i].Name] = policy.DefaultActivation
+
+		_, ref, found := GetWinningFeatureReference(gates, features[i].Name)
+		if !found || policy.DefaultActivation == ref.Activate || ref.Rollout != nil {
+			continue
+		}
+		if policy.Immutable || (policy.VoidsWarranty && !ref.PermanentlyVoidAllSupportGuarantees) {
+			continue
+		}
+		intents[features[i].Name] = ref.Activate
+	}
+	return intents
+}
+
+// FeaturesRelatedTo returns the features that depend on, or conflict with, the named feature, and the features the
+// named feature depends on or conflicts with
+func FeaturesRelatedTo(features []Feature, name string) []string {
+	graph := newFeatureGraph(features)
+	related := map[string]bool{}
+	for _, dependency := range graph.dependsOn[name] {
+		related[dependency] = true
+	}
+	for _, conflict := range graph.conflicts(name) {
+		related[conflict] = true
+	}
+	for other, dependencies := range graph.dependsOn {
+		for _, dependency := range dependencies {
+			if dependency == name {
+				related[other] = true
+			}
+		}
+	}
+
+	var names []string
+	for other := range related {
+		if other != name {
+			names = append(names, other)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// featureGraph holds the dependencies and the conflicts between features
+type featureGraph struct {
+	features      map[string]*Feature
+	dependsOn     map[string][]string
+	conflictsWith map[string]map[string]bool
+}
+
+func newFeatureGraph(features []Feature) *featureGraph {
+	graph := &featureGraph{
+		features:      map[string]*Feature{},
+		dependsOn:     map[string][]string{},
+		conflictsWith: map[string]map[string]bool{},
+	}
+	addConflict := func(a, b string) {
+		if graph.conflictsWith[a] == nil {
+			graph.conflictsWith[a] = map[string]bool{}
+		}
+		graph.conflictsWith[a][b] = true
+	}
+
+	for i := range features {
+		feature := &features[i]
+		graph.features[feature.Name] = feature
+		graph.dependsOn[feature.Name] = feature.Spec.DependsOn
+		for _, conflict := range feature.Spec.ConflictsWith {
+			addConflict(feature.Name, conflict)
+			addConflict(conflict, feature.Name)
+		}
+	}
+	return graph
+}
+
+// conflicts returns the sorted features that conflict with the named feature
+func (g *featureGraph) conflicts(name string) []string {
+	var conflicts []string
+	for conflict := range g.conflictsWith[name] {
+		conflicts = append(conflicts, conflict)
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
@@ -0,0 +1,271 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func testFeature(name string, stability StabilityLevel, dependsOn, conflictsWith []string) Feature {
+	return Feature{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       FeatureSpec{Description: name, Stability: stability, DependsOn: dependsOn, ConflictsWith: conflictsWith},
+	}
+}
+
+func TestComputeFeatureActivation(t *testing.T) {
+	testCases := []struct {
+		description   string
+		features      []Feature
+		intents       map[string]bool
+		wantActivated []string
+		wantBlocked   []string
+	}{
+		{
+			description: "Dependencies are activated transitively",
+			features: []Feature{
+				testFeature("foo", TechnicalPreview, []string{"bar"}, nil),
+				testFeature("bar", TechnicalPreview, []string{"baz"}, nil),
+				testFeature("baz", TechnicalPreview, nil, nil),
+			},
+			intents:       map[string]bool{"foo": true, "bar": true, "baz": true},
+			wantActivated: []string{"foo", "bar", "baz"},
+		},
+		{
+			description: "Features are blocked by a transitive dependency that is not activated",
+			features: []Feature{
+				testFeature("foo", TechnicalPreview, []string{"bar"}, nil),
+				testFeature("bar", TechnicalPreview, []string{"baz"}, nil),
+				testFeature("baz", TechnicalPreview, nil, nil),
+			},
+			intents:     map[string]bool{"foo": true, "bar": true},
+			wantBlocked: []string{"foo", "bar"},
+		},
+		{
+			description: "Features are blocked by a dependency that does not exist",
+			features: []Feature{
+				testFeature("foo", TechnicalPreview, []string{"missing"}, nil),
+				testFeature("bar", TechnicalPreview, nil, nil),
+			},
+			intents:       map[string]bool{"foo": true, "bar": true},
+			wantActivated: []string{"bar"},
+			wantBlocked:   []string{"foo"},
+		},
+		{
+			description: "Conflicting features block each other",
+			features: []Feature{
+				testFeature("foo", TechnicalPreview, nil, []string{"bar"}),
+				testFeature("bar", TechnicalPreview, nil, nil),
+				testFeature("baz", TechnicalPreview, []string{"bar"}, nil),
+			},
+			intents:     map[string]bool{"foo": true, "bar": true, "baz": true},
+			wantBlocked: []string{"foo", "bar", "baz"},
+		},
+		{
+			description: "Conflicting feature that is not activated does not block",
+			features: []Feature{
+				testFeature("foo", TechnicalPreview, nil, []string{"bar"}),
+				testFeature("bar", TechnicalPreview, []string{"baz"}, nil),
+				testFeature("baz", TechnicalPreview, nil, nil),
+			},
+			intents:       map[string]bool{"foo": true, "bar": true},
+			wantActivated: []string{"foo"},
+			wantBlocked:   []string{"bar"},
+		},
+		{
+			description: "Features in a dependency cycle are blocked",
+			features: []Feature{
+				testFeature("foo", TechnicalPreview, []string{"bar"}, nil),
+				testFeature("bar", TechnicalPreview, []string{"foo"}, nil),
+			},
+			intents:     map[string]bool{"foo": true, "bar": true},
+			wantBlocked: []string{"foo", "bar"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			activated, blocked := ComputeFeatureActivation(tc.features, tc.intents)
+
+			var gotActivated []string
+			for name, active := range activated {
+				if active {
+					gotActivated = append(gotActivated, name)
+				}
+			}
+			if diff := sliceDiffIgnoreOrder(gotActivated, tc.wantActivated); diff != "" {
+				t.Errorf("got activated features %v, want %v, diff: %s", gotActivated, tc.wantActivated, diff)
+			}
+
+			gotBlocked := sets.StringKeySet(blocked).List()
+			if diff := sliceDiffIgnoreOrder(gotBlocked, tc.wantBlocked); diff != "" {
+				t.Errorf("got blocked features %v, want %v, diff: %s", gotBlocked, tc.wantBlocked, diff)
+			}
+			for name, reason := range blocked {
+				if reason == "" {
+					t.Errorf("expected a reason for blocked feature %s", name)
+				}
+			}
+		})
+	}
+}
+
+func TestComputeFeaturesBlockedByFeatureGate(t *testing.T) {
+	features := &FeatureList{
+		Items: []Feature{
+			testFeature("foo", TechnicalPreview, []string{"bar"}, nil),
+			testFeature("bar", TechnicalPreview, nil, nil),
+			testFeature("baz", TechnicalPreview, nil, []string{"bar"}),
+			testFeature("qux", Stable, nil, nil),
+		},
+	}
+
+	testCases := []struct {
+		description  string
+		featureGate  *FeatureGate
+		featureGates *FeatureGateList
+		want         []string
+	}{
+		{
+			description: "Activating a feature and its dependency",
+			featureGate: &FeatureGate{
+				ObjectMeta: metav1.ObjectMeta{Name: "fg"},
+				Spec:       FeatureGateSpec{Features: []FeatureReference{{Name: "foo", Activate: true}, {Name: "bar", Activate: true}}},
+			},
+			featureGates: &FeatureGateList{},
+			want:         []string{},
+		},
+		{
+			description: "Activating a feature without its dependency",
+			featureGate: &FeatureGate{
+				ObjectMeta: metav1.ObjectMeta{Name: "fg"},
+				Spec:       FeatureGateSpec{Features: []FeatureReference{{Name: "foo", Activate: true}}},
+			},
+			featureGates: &FeatureGateList{},
+			want:         []string{"foo"},
+		},
+		{
+			description: "Activating a feature whose dependency is activated by another FeatureGate",
+			featureGate: &FeatureGate{
+				ObjectMeta: metav1.ObjectMeta{Name: "fg"},
+				Spec:       FeatureGateSpec{Features: []FeatureReference{{Name: "foo", Activate: true}}},
+			},
+			featureGates: &FeatureGateList{Items: []FeatureGate{{
+				ObjectMeta: metav1.ObjectMeta{Name: "other"},
+				Spec:       FeatureGateSpec{Features: []FeatureReference{{Name: "bar", Activate: true}}},
+			}}},
+			want: []string{},
+		},
+		{
+			description: "Deactivating the dependency of an activated feature",
+			featureGate: &FeatureGate{
+				ObjectMeta: metav1.ObjectMeta{Name: "fg"},
+				Spec:       FeatureGateSpec{Features: []FeatureReference{{Name: "foo", Activate: true}, {Name: "bar", Activate: false}}},
+			},
+			featureGates: &FeatureGateList{Items: []FeatureGate{{
+				ObjectMeta: metav1.ObjectMeta{Name: "fg"},
+				Spec:       FeatureGateSpec{Features: []FeatureReference{{Name: "foo", Activate: true}, {Name: "bar", Activate: true}}},
+			}}},
+			want: []string{"foo"},
+		},
+		{
+			description: "Activating a feature that conflicts with an activated feature",
+			featureGate: &FeatureGate{
+				ObjectMeta: metav1.ObjectMeta{Name: "fg"},
+				Spec:       FeatureGateSpec{Features: []FeatureReference{{Name: "baz", Activate: true}}},
+			},
+			featureGates: &FeatureGateList{Items: []FeatureGate{{
+				ObjectMeta: metav1.ObjectMeta{Name: "other"},
+				Spec:       FeatureGateSpec{Features: []FeatureReference{{Name: "bar", Activate: true}}},
+			}}},
+			want: []string{"bar", "baz"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			blocked := computeFeaturesBlockedByFeatureGate(tc.featureGate, features, tc.featureGates, nil)
+			got := sets.StringKeySet(blocked).List()
+			if diff := sliceDiffIgnoreOrder(got, tc.want); diff != "" {
+				t.Errorf("got blocked features %v, want %v, diff: %s", got, tc.want, diff)
+			}
+		})
+	}
+}
+
+func TestComputeFeatureIntents(t *testing.T) {
+	features := []Feature{
+		testFeature("foo", TechnicalPreview, nil, nil),
+		testFeature("bar", Experimental, nil, nil),
+		testFeature("baz", Stable, nil, nil),
+	}
+	featureGates := []FeatureGate{{
+		ObjectMeta: metav1.ObjectMeta{Name: "fg"},
+		Spec: FeatureGateSpec{Features: []FeatureReference{
+			{Name: "foo", Activate: true},
+			// Ignored, as activating an experimental feature requires voiding the support guarantees
+			{Name: "bar", Activate: true},
+			// Ignored, as stable features are immutable
+			{Name: "baz", Activate: false},
+		}},
+	}}
+
+	got := ComputeFeatureIntents(features, featureGates, nil, nil)
+	want := map[string]bool{"foo": true, "bar": false, "baz": true}
+	if diff := cmp.Diff(got, want, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("got intents %v, want %v, diff: %s", got, want, diff)
+	}
+}
+
+func TestComputeFeatureIntentsWithLayeredFeatureGates(t *testing.T) {
+	features := []Feature{
+		testFeature("foo", TechnicalPreview, nil, nil),
+		testFeature("bar", TechnicalPreview, nil, nil),
+		testFeature("baz", TechnicalPreview, nil, nil),
+	}
+	featureGates := []FeatureGate{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+			Spec: FeatureGateSpec{Features: []FeatureReference{
+				{Name: "foo", Activate: true},
+				{Name: "bar", Activate: true},
+				{Name: "baz", Activate: true},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "environment"},
+			Spec: FeatureGateSpec{Priority: 200, Features: []FeatureReference{
+				{Name: "bar", Activate: true},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "platform"},
+			Spec: FeatureGateSpec{Priority: 100, Features: []FeatureReference{
+				{Name: "foo", Activate: false},
+				{Name: "bar", Activate: false},
+			}},
+		},
+	}
+
+	got := ComputeFeatureIntents(features, featureGates, nil, nil)
+	want := map[string]bool{"foo": false, "bar": true, "baz": true}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("got intents %v, want %v, diff: %s", got, want, diff)
+	}
+
+	// The platform FeatureGate no longer overrides the baseline FeatureGate once it is lowered below it
+	platform := featureGates[2].DeepCopy()
+	platform.Spec.Priority = -1
+	got = ComputeFeatureIntents(features, featureGates, platform, nil)
+	want = map[string]bool{"foo": true, "bar": true, "baz": true}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("got intents %v, want %v, diff: %s", got, want, diff)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// IsFeaturePastRemovalDate returns true if the removal date of the feature is set and is not after now
+func IsFeaturePastRemovalDate(feature *Feature, now time.Time) bool {
+	return feature.Spec.RemovalDate != nil && !now.Before(feature.Spec.RemovalDate.Time)
+}
+
+// FeatureLifecycleWarning returns a warning about the lifecycle of an activated feature, or an empty string if the
+// feature is neither deprecated nor scheduled for removal
+func FeatureLifecycleWarning(feature *Feature, now time.Time) string {
+	if IsFeaturePastRemovalDate(feature, now) {
+		return fmt.Sprintf("feature %s was removed on %s and should be deactivated", feature.Name,
+			feature.Spec.RemovalDate.Format("2006-01-02"))
+	}
+
+	var schedule []string
+	if feature.Spec.PlannedRemovalIn != "" {
+		schedule = append(schedule, fmt.Sprintf("in version %s", feature.Spec.PlannedRemovalIn))
+	}
+	if feature.Spec.RemovalDate != nil {
+		schedule = append(schedule, fmt.Sprintf("on %s", feature.Spec.RemovalDate.Format("2006-01-02")))
+	}
+
+	switch {
+	case len(schedule) > 0:
+		return fmt.Sprintf("feature %s is planned to be removed %s", feature.Name, strings.Join(schedule, " and "))
+	case feature.Spec.Stability == Deprecated:
+		return fmt.Sprintf("feature %s is deprecated and is destined for removal", feature.Name)
+	}
+	return ""
+}
+
+// computeFeaturesActivatedPastRemovalDate computes and returns the features that a FeatureGate resource spec activates
+// after their removal date. References that were already activating the feature in the old object are allowed, so
+// that the FeatureGate can still be updated; oldObject is nil on create.
+func computeFeaturesActivatedPastRemovalDate(spec FeatureGateSpec, oldObject *FeatureGate, features *FeatureList, now time.Time) []string {
+	invalidFeatures := sets.String{}
+	for _, featureRef := range spec.Features {
+		if !featureRef.Activate {
+			continue
+		}
+		if oldObject != nil {
+			if oldRef, found := getFeatureReference(oldObject.Spec, featureRef.Name); found && oldRef.Activate {
+				continue
+			}
+		}
+		feature, found := getFeature(features, featureRef.Name)
+		if found && IsFeaturePastRemovalDate(feature, now) {
+			invalidFeatures.Insert(featureRef.Name)
+		}
+	}
+	return invalidFeatures.List()
+}
+
+// computeFeatureLifecycleWarnings computes and returns the lifecycle warnings of the features that a FeatureGate
+// resource spec activates
+func computeFeatureLifecycleWarnings(spec FeatureGateSpec, features *FeatureList, now time.Time) []string {
+	var warnings []string
+	for _, featureRef := range spec.Features {
+		if !featureRef.Activate {
+			continue
+		}
+		feature, found := getFeature(features, featureRef.Name)
+		if !found {
+			continue
+		}
+		if warning := FeatureLifecycleWarning(feature, now); warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	return warnings
+}
+
+func getFeature(list *FeatureList, featureName string) (*Feature, bool) {
+	for i := range list.Items {
+		if list.Items[i].Name == featureName {
+			return &list.Items[i], true
+		}
+	}
+	return nil, false
+}
+
+func getFeatureReference(spec FeatureGateSpec, featureName string) (FeatureReference, bool) {
+	for _, featureRef := range spec.Features {
+		if featureRef.Name == featureName {
+			return featureRef, true
+		}
+	}
+	return FeatureReference{}, false
+}
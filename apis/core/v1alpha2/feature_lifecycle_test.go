@@ -0,0 +1,90 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFeatureLifecycle(t *testing.T) {
+	now := time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+	past := metav1.NewTime(now.AddDate(0, -1, 0))
+	future := metav1.NewTime(now.AddDate(0, 1, 0))
+
+	features := &FeatureList{
+		Items: []Feature{
+			{ObjectMeta: metav1.ObjectMeta{Name: "removed"}, Spec: FeatureSpec{Stability: Deprecated, RemovalDate: &past}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "scheduled"}, Spec: FeatureSpec{Stability: Deprecated, PlannedRemovalIn: "v1.2.0", RemovalDate: &future}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "deprecated"}, Spec: FeatureSpec{Stability: Deprecated}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "stable"}, Spec: FeatureSpec{Stability: Stable, IntroducedIn: "v1.0.0"}},
+		},
+	}
+
+	testCases := []struct {
+		description     string
+		featureGateSpec FeatureGateSpec
+		oldObject       *FeatureGate
+		wantRemoved     []string
+		wantWarnings    []string
+	}{
+		{
+			description: "Activating features with lifecycle warnings",
+			featureGateSpec: FeatureGateSpec{
+				Features: []FeatureReference{
+					{Name: "scheduled", Activate: true},
+					{Name: "deprecated", Activate: true},
+					{Name: "stable", Activate: true},
+				},
+			},
+			wantRemoved: []string{},
+			wantWarnings: []string{
+				"feature scheduled is planned to be removed in version v1.2.0 and on 2023-07-01",
+				"feature deprecated is deprecated and is destined for removal",
+			},
+		},
+		{
+			description: "Activating a feature past its removal date",
+			featureGateSpec: FeatureGateSpec{
+				Features: []FeatureReference{{Name: "removed", Activate: true}},
+			},
+			wantRemoved:  []string{"removed"},
+			wantWarnings: []string{"feature removed was removed on 2023-05-01 and should be deactivated"},
+		},
+		{
+			description: "Feature past its removal date that was already activated",
+			featureGateSpec: FeatureGateSpec{
+				Features: []FeatureReference{{Name: "removed", Activate: true}},
+			},
+			oldObject: &FeatureGate{
+				Spec: FeatureGateSpec{Features: []FeatureReference{{Name: "removed", Activate: true}}},
+			},
+			wantRemoved:  []string{},
+			wantWarnings: []string{"feature removed was removed on 2023-05-01 and should be deactivated"},
+		},
+		{
+			description: "Deactivating a feature past its removal date",
+			featureGateSpec: FeatureGateSpec{
+				Features: []FeatureReference{{Name: "removed", Activate: false}},
+			},
+			wantRemoved:  []string{},
+			wantWarnings: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			removed := computeFeaturesActivatedPastRemovalDate(tc.featureGateSpec, tc.oldObject, features, now)
+			if diff := sliceDiffIgnoreOrder(removed, tc.wantRemoved); diff != "" {
+				t.Errorf("got removed features %v, want %v, diff: %s", removed, tc.wantRemoved, diff)
+			}
+			warnings := computeFeatureLifecycleWarnings(tc.featureGateSpec, features, now)
+			if diff := sliceDiffIgnoreOrder(warnings, tc.wantWarnings); diff != "" {
+				t.Errorf("got warnings %v, want %v, diff: %s", warnings, tc.wantWarnings, diff)
+			}
+		})
+	}
+}
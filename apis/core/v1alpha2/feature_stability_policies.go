@@ -3,6 +3,8 @@
 
 package v1alpha2
 
+import "sort"
+
 // Policy represents Stability level policy
 type Policy struct {
 	// DefaultActivation is the default activation state of the Feature. When a new Feature resource is added to the
@@ -55,7 +57,50 @@ var StabilityPolicies = map[StabilityLevel]Policy{
 	},
 }
 
-// GetPolicyForStabilityLevel returns policy for stability level
-func GetPolicyForStabilityLevel(stability StabilityLevel) Policy {
-	return StabilityPolicies[stability]
+// GetPolicyForStabilityLevel returns policy for stability level. The built-in policy of the stability level is
+// overridden by the FeaturePolicy resources, applied in the order of their names so that the last one wins.
+// Custom stability levels start from a policy with all the fields set to false.
+func GetPolicyForStabilityLevel(stability StabilityLevel, featurePolicies ...FeaturePolicy) Policy {
+	policy := StabilityPolicies[stability]
+
+	sorted := make([]*FeaturePolicy, len(featurePolicies))
+	for i := range featurePolicies {
+		sorted[i] = &featurePolicies[i]
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	for _, featurePolicy := range sorted {
+		for _, override := range featurePolicy.Spec.Policies {
+			if override.Stability != stability {
+				continue
+			}
+			overrideField(&policy.DefaultActivation, override.DefaultActivation)
+			overrideField(&policy.Immutable, override.Immutable)
+			overrideField(&policy.VoidsWarranty, override.VoidsWarranty)
+			overrideField(&policy.Discoverable, override.Discoverable)
+		}
+	}
+	return policy
+}
+
+// IsStabilityLevelDefined returns true if the stability level is a built-in stability level or has a policy defined in
+// the FeaturePolicy resources
+func IsStabilityLevelDefined(stability StabilityLevel, featurePolicies ...FeaturePolicy) bool {
+	if _, ok := StabilityPolicies[stability]; ok {
+		return true
+	}
+	for i := range featurePolicies {
+		for _, override := range featurePolicies[i].Spec.Policies {
+			if override.Stability == stability {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func overrideField(field, override *bool) {
+	if override != nil {
+		*field = *override
+	}
 }
@@ -0,0 +1,78 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetPolicyForStabilityLevel(t *testing.T) {
+	truePtr, falsePtr := true, false
+	featurePolicies := []FeaturePolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "b-regulated"},
+			Spec: FeaturePolicySpec{Policies: []StabilityLevelPolicy{
+				{Stability: TechnicalPreview, Discoverable: &falsePtr},
+				{Stability: "Beta", Discoverable: &falsePtr},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-custom"},
+			Spec: FeaturePolicySpec{Policies: []StabilityLevelPolicy{
+				{Stability: "Beta", DefaultActivation: &truePtr, Discoverable: &truePtr},
+			}},
+		},
+	}
+
+	testCases := []struct {
+		description     string
+		stability       StabilityLevel
+		featurePolicies []FeaturePolicy
+		want            Policy
+		wantDefined     bool
+	}{
+		{
+			description: "Built-in stability level without FeaturePolicies",
+			stability:   TechnicalPreview,
+			want:        StabilityPolicies[TechnicalPreview],
+			wantDefined: true,
+		},
+		{
+			description:     "Built-in stability level overridden by a FeaturePolicy",
+			stability:       TechnicalPreview,
+			featurePolicies: featurePolicies,
+			want:            Policy{DefaultActivation: false, Immutable: false, VoidsWarranty: false, Discoverable: false},
+			wantDefined:     true,
+		},
+		{
+			description:     "Custom stability level defined by FeaturePolicies applied in name order",
+			stability:       "Beta",
+			featurePolicies: featurePolicies,
+			want:            Policy{DefaultActivation: true, Discoverable: false},
+			wantDefined:     true,
+		},
+		{
+			description:     "Undefined stability level",
+			stability:       "Gamma",
+			featurePolicies: featurePolicies,
+			want:            Policy{},
+			wantDefined:     false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got := GetPolicyForStabilityLevel(tc.stability, tc.featurePolicies...)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("got diff: %s", diff)
+			}
+			if defined := IsStabilityLevelDefined(tc.stability, tc.featurePolicies...); defined != tc.wantDefined {
+				t.Errorf("expected stability level defined to be %t, got %t", tc.wantDefined, defined)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 )
 
 // StabilityLevel indicates stability level of the feature.
+// +kubebuilder:validation:MinLength=1
 type StabilityLevel string
 
 const (
@@ -23,29 +24,127 @@ type FeatureSpec struct {
 	// Description of the feature.
 	Description string `json:"description"`
 	// Stability indicates stability level of the feature.
-	// Stability levels are Work In Progress, Experimental, Technical Preview, Stable and Deprecated.
-	// +kubebuilder:validation:Enum=Work In Progress;Experimental;Technical Preview;Stable;Deprecated
+	// Built-in stability levels are Work In Progress, Experimental, Technical Preview, Stable and Deprecated.
+	// Custom stability levels can be defined by FeaturePolicy resources.
 	// - Work In Progress: Feature is still under development. It is not ready to be used, except by the team working on it. Activating this feature is not recommended under any circumstances.
 	// - Experimental: Feature is not ready, but may be used in pre-production environments. However, if an experimental feature has ever been used in an environment, that environment will not be supported. Activating an experimental feature requires you to permanently, irrevocably void all support guarantees for this environment by setting permanentlyVoidAllSupportGuarantees in feature reference in featuregate spec to true. You will need to recreate the environment to return to a supported state.
 	// - Technical Preview: Feature is not ready, but is not believed to be dangerous. The feature itself is unsupported, but activating a technical preview feature does not affect the support status of the environment.
 	// - Stable: Feature is ready and fully supported
 	// - Deprecated: Feature is destined for removal, usage is discouraged. Deactivate this feature prior to upgrading to a release which has removed it to validate that you are not still using it and to prevent users from introducing new usage of it.
 	Stability StabilityLevel `json:"stability"`
+	// DependsOn lists the features that must be activated for this feature to be activated.
+	// +optional
+	// +listType=set
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// ConflictsWith lists the features that must not be activated together with this feature.
+	// A conflict declared by either of two features applies to both.
+	// +optional
+	// +listType=set
+	ConflictsWith []string `json:"conflictsWith,omitempty"`
+	// Owner is the team or person that owns the feature.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+	// IntroducedIn is the version in which the feature was introduced.
+	// +optional
+	IntroducedIn string `json:"introducedIn,omitempty"`
+	// PlannedGAIn is the version in which the feature is planned to become Stable.
+	// +optional
+	PlannedGAIn string `json:"plannedGAIn,omitempty"`
+	// PlannedRemovalIn is the version in which the feature is planned to be removed.
+	// +optional
+	PlannedRemovalIn string `json:"plannedRemovalIn,omitempty"`
+	// RemovalDate is the date from which the feature is removed. FeatureGates cannot activate the feature from that
+	// date on.
+	// +optional
+	RemovalDate *metav1.Time `json:"removalDate,omitempty"`
+	// Links are links to more information about the feature, such as its documentation or tracking issue.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Links []FeatureLink `json:"links,omitempty"`
 }
 
+// FeatureLink is a link to more information about a feature
+type FeatureLink struct {
+	// Name of the link, such as "docs" or "issue".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+	// URL of the link.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength:=1
+	URL string `json:"url"`
+}
+
+// FeatureActivationReason is the reason for the activation state of a feature
+type FeatureActivationReason string
+
+const (
+	// DefaultActivationReason indicates that the feature is set to the default activation of its stability level, as
+	// no FeatureGate references it.
+	DefaultActivationReason FeatureActivationReason = "Default"
+	// ExplicitActivationReason indicates that the feature is set to the activation specified by a FeatureGate.
+	ExplicitActivationReason FeatureActivationReason = "Explicit"
+	// PolicyBlockedActivationReason indicates that the activation specified by a FeatureGate is not allowed by the
+	// policy of the stability level of the feature, so the feature is set to its default activation.
+	PolicyBlockedActivationReason FeatureActivationReason = "PolicyBlocked"
+	// DependencyBlockedActivationReason indicates that the feature is deactivated because its dependencies are not
+	// activated, or it conflicts with activated features.
+	DependencyBlockedActivationReason FeatureActivationReason = "DependencyBlocked"
+)
+
+// FeatureActivatedCondition is the condition type that reports whether a feature is activated
+const FeatureActivatedCondition = "Activated"
+
 // FeatureStatus defines the observed state of Feature
 type FeatureStatus struct {
 	// Activated is a boolean which indicates whether a feature is activated or not.
 	Activated bool `json:"activated"`
+	// Namespaces lists the namespaces in which the feature is activated, when its activation is rolled out to a subset
+	// of the namespaces.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// FeatureGate is the name of the FeatureGate that controls the feature. It is empty if no FeatureGate references
+	// the feature.
+	// +optional
+	FeatureGate string `json:"featureGate,omitempty"`
+	// FeatureGatePriority is the priority of the FeatureGate that controls the feature, which is the layer that decided
+	// its activation. It is not set if no FeatureGate references the feature.
+	// +optional
+	FeatureGatePriority *int32 `json:"featureGatePriority,omitempty"`
+	// OverriddenFeatureGates lists the FeatureGates that reference the feature, but are overridden by the FeatureGate
+	// with a higher priority that controls it.
+	// +optional
+	// +listType=set
+	OverriddenFeatureGates []string `json:"overriddenFeatureGates,omitempty"`
+	// Reason is the reason for the activation state of the feature.
+	// +optional
+	Reason FeatureActivationReason `json:"reason,omitempty"`
+	// LastTransitionTime is the last time the activation state of the feature changed.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+	// ObservedGeneration is the generation of the feature that was last reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describe the state of the feature.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // Feature is the Schema for the features API
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
 // +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Description",type=string,JSONPath=.spec.description
 // +kubebuilder:printcolumn:name="Stability",type=string,JSONPath=.spec.stability
 // +kubebuilder:printcolumn:name="Activated?",type=string,JSONPath=.status.activated
+// +kubebuilder:printcolumn:name="Owner",type=string,JSONPath=.spec.owner,priority=1
+// +kubebuilder:printcolumn:name="FeatureGate",type=string,JSONPath=.status.featureGate,priority=1
+// +kubebuilder:printcolumn:name="Priority",type=integer,JSONPath=.status.featureGatePriority,priority=1
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=.status.reason,priority=1
 type Feature struct {
 	Status            FeatureStatus `json:"status,omitempty"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -0,0 +1,150 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ComputeActivationChanges returns the changes to the activation of features between the old and the new FeatureGate.
+// oldObject is nil on create. The activation of a feature without a reference is its default activation, taken from
+// defaults, so adding or removing a reference that does not change the activation is not a change.
+func ComputeActivationChanges(oldObject, newObject *FeatureGate, defaults map[string]bool, actor, reason string, now metav1.Time) []FeatureActivationChange {
+	oldRefs := map[string]FeatureReference{}
+	if oldObject != nil {
+		for _, ref := range oldObject.Spec.Features {
+			oldRefs[ref.Name] = ref
+		}
+	}
+	newRefs := map[string]FeatureReference{}
+	for _, ref := range newObject.Spec.Features {
+		newRefs[ref.Name] = ref
+	}
+
+	names := map[string]bool{}
+	for name := range oldRefs {
+		names[name] = true
+	}
+	for name := range newRefs {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var changes []FeatureActivationChange
+	for _, name := range sorted {
+		previous, activate := defaults[name], defaults[name]
+		oldRef, hadRef := oldRefs[name]
+		if hadRef {
+			previous = oldRef.Activate
+		}
+		newRef, hasRef := newRefs[name]
+		if hasRef {
+			activate = newRef.Activate
+		}
+		voidedWarranty := hasRef && newRef.PermanentlyVoidAllSupportGuarantees && !oldRef.PermanentlyVoidAllSupportGuarantees
+
+		if previous == activate && !voidedWarranty {
+			continue
+		}
+		changes = append(changes, FeatureActivationChange{
+			Feature:          name,
+			Actor:            actor,
+			Time:             now,
+			PreviousActivate: previous,
+			Activate:         activate,
+			VoidedWarranty:   voidedWarranty,
+			Reason:           reason,
+		})
+	}
+	return changes
+}
+
+// AppendActivationHistory appends the changes that are not in the history yet, and returns the last
+// ActivationHistoryLimit changes
+func AppendActivationHistory(history, changes []FeatureActivationChange) []FeatureActivationChange {
+	recorded := map[string]bool{}
+	for i := range history {
+		recorded[activationChangeKey(&history[i])] = true
+	}
+	for i := range changes {
+		if key := activationChangeKey(&changes[i]); !recorded[key] {
+			recorded[key] = true
+			history = append(history, changes[i])
+		}
+	}
+	if len(history) > ActivationHistoryLimit {
+		history = history[len(history)-ActivationHistoryLimit:]
+	}
+	return history
+}
+
+// UnrecordedActivationChanges returns the changes that are not in the history
+func UnrecordedActivationChanges(changes, history []FeatureActivationChange) []FeatureActivationChange {
+	recorded := map[string]bool{}
+	for i := range history {
+		recorded[activationChangeKey(&history[i])] = true
+	}
+	var unrecorded []FeatureActivationChange
+	for i := range changes {
+		if !recorded[activationChangeKey(&changes[i])] {
+			unrecorded = append(unrecorded, changes[i])
+		}
+	}
+	return unrecorded
+}
+
+// HasWarrantyVoids returns true if any of the activation changes voids the support warranty
+func HasWarrantyVoids(changes []FeatureActivationChange) bool {
+	for i := range changes {
+		if changes[i].VoidedWarranty {
+			return true
+		}
+	}
+	return false
+}
+
+// activationChangeKey identifies an activation change. Times are compared at the precision they are serialized with.
+func activationChangeKey(change *FeatureActivationChange) string {
+	return fmt.Sprintf("%s/%s/%s/%t/%t", change.Feature, change.Actor, change.Time.UTC().Format(time.RFC3339), change.Activate, change.VoidedWarranty)
+}
+
+// GetPendingActivationChanges returns the activation changes held in the PendingActivationChangesAnnotation of the
+// FeatureGate
+func GetPendingActivationChanges(featureGate *FeatureGate) ([]FeatureActivationChange, error) {
+	value, ok := featureGate.Annotations[PendingActivationChangesAnnotation]
+	if !ok || value == "" {
+		return nil, nil
+	}
+	var changes []FeatureActivationChange
+	if err := json.Unmarshal([]byte(value), &changes); err != nil {
+		return nil, fmt.Errorf("could not decode annotation %s: %w", PendingActivationChangesAnnotation, err)
+	}
+	return changes, nil
+}
+
+// setPendingActivationChanges sets the PendingActivationChangesAnnotation of the FeatureGate to the activation changes
+func setPendingActivationChanges(featureGate *FeatureGate, changes []FeatureActivationChange) error {
+	if len(changes) == 0 {
+		delete(featureGate.Annotations, PendingActivationChangesAnnotation)
+		return nil
+	}
+	value, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+	if featureGate.Annotations == nil {
+		featureGate.Annotations = map[string]string{}
+	}
+	featureGate.Annotations[PendingActivationChangesAnnotation] = string(value)
+	return nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	jsonpatchapply "github.com/evanphx/json-patch"
+	"github.com/google/go-cmp/cmp"
+	"gomodules.xyz/jsonpatch/v2"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestComputeActivationChanges(t *testing.T) {
+	now := metav1.NewTime(time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC))
+	defaults := map[string]bool{"foo": false, "bar": false, "baz": true, "qux": false}
+	oldObject := &FeatureGate{
+		Spec: FeatureGateSpec{Features: []FeatureReference{
+			{Name: "foo", Activate: false},
+			{Name: "bar", Activate: true},
+			{Name: "baz", Activate: false},
+		}},
+	}
+
+	testCases := []struct {
+		description string
+		oldObject   *FeatureGate
+		newObject   *FeatureGate
+		want        []FeatureActivationChange
+	}{
+		{
+			description: "FeatureGate created",
+			newObject: &FeatureGate{Spec: FeatureGateSpec{Features: []FeatureReference{
+				{Name: "foo", Activate: true},
+				{Name: "baz", Activate: true},
+			}}},
+			want: []FeatureActivationChange{
+				{Feature: "foo", Actor: "admin", Time: now, PreviousActivate: false, Activate: true, Reason: "testing"},
+			},
+		},
+		{
+			description: "Features toggled, warranty voided and reference removed",
+			oldObject:   oldObject,
+			newObject: &FeatureGate{Spec: FeatureGateSpec{Features: []FeatureReference{
+				{Name: "foo", Activate: true, PermanentlyVoidAllSupportGuarantees: true},
+				{Name: "bar", Activate: true},
+				{Name: "qux", Activate: false, PermanentlyVoidAllSupportGuarantees: true},
+			}}},
+			want: []FeatureActivationChange{
+				{Feature: "baz", Actor: "admin", Time: now, PreviousActivate: false, Activate: true, Reason: "testing"},
+				{Feature: "foo", Actor: "admin", Time: now, PreviousActivate: false, Activate: true, VoidedWarranty: true, Reason: "testing"},
+				{Feature: "qux", Actor: "admin", Time: now, PreviousActivate: false, Activate: false, VoidedWarranty: true, Reason: "testing"},
+			},
+		},
+		{
+			description: "No changes",
+			oldObject:   oldObject,
+			newObject:   oldObject.DeepCopy(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got := ComputeActivationChanges(tc.oldObject, tc.newObject, defaults, "admin", "testing", now)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("got diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestAppendActivationHistory(t *testing.T) {
+	start := time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+	change := func(i int) FeatureActivationChange {
+		return FeatureActivationChange{
+			Feature:  fmt.Sprintf("feature-%d", i),
+			Actor:    "admin",
+			Time:     metav1.NewTime(start.Add(time.Duration(i) * time.Minute)),
+			Activate: true,
+		}
+	}
+
+	var history []FeatureActivationChange
+	for i := 0; i < ActivationHistoryLimit; i++ {
+		history = AppendActivationHistory(history, []FeatureActivationChange{change(i)})
+	}
+	// Changes that are already in the history are not appended again
+	history = AppendActivationHistory(history, []FeatureActivationChange{change(ActivationHistoryLimit - 1)})
+	if len(history) != ActivationHistoryLimit {
+		t.Fatalf("expected %d changes, got %d", ActivationHistoryLimit, len(history))
+	}
+
+	// The oldest changes are dropped once the limit is reached
+	history = AppendActivationHistory(history, []FeatureActivationChange{change(ActivationHistoryLimit)})
+	if len(history) != ActivationHistoryLimit {
+		t.Fatalf("expected %d changes, got %d", ActivationHistoryLimit, len(history))
+	}
+	if history[0].Feature != "feature-1" || history[len(history)-1].Feature != fmt.Sprintf("feature-%d", ActivationHistoryLimit) {
+		t.Errorf("unexpected history bounds: %s...%s", history[0].Feature, history[len(history)-1].Feature)
+	}
+
+	if unrecorded := UnrecordedActivationChanges([]FeatureActivationChange{change(0), change(1)}, history); len(unrecorded) != 1 || unrecorded[0].Feature != "feature-0" {
+		t.Errorf("expected only feature-0 to be unrecorded, got %v", unrecorded)
+	}
+}
+
+func TestFeatureGateHistoryHandler(t *testing.T) {
+	s, err := getScheme()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl = fake.NewClientBuilder().WithScheme(s).WithObjects(
+		&Feature{ObjectMeta: metav1.ObjectMeta{Name: "foo"}, Spec: FeatureSpec{Stability: TechnicalPreview}},
+	).Build()
+	defer func() { cl = nil }()
+
+	decoder, err := admission.NewDecoder(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := &featureGateHistoryHandler{}
+	if err := handler.InjectDecoder(decoder); err != nil {
+		t.Fatal(err)
+	}
+
+	recorded := FeatureActivationChange{Feature: "foo", Actor: "admin", Time: metav1.NewTime(time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)), Activate: true}
+	oldObject := &FeatureGate{
+		ObjectMeta: metav1.ObjectMeta{Name: "tkg-system"},
+		Spec:       FeatureGateSpec{Features: []FeatureReference{{Name: "foo", Activate: true}}},
+		Status:     FeatureGateStatus{History: []FeatureActivationChange{recorded}},
+	}
+	if err := setPendingActivationChanges(oldObject, []FeatureActivationChange{recorded}); err != nil {
+		t.Fatal(err)
+	}
+
+	newObject := oldObject.DeepCopy()
+	newObject.Spec.Features[0].Activate = false
+	newObject.Annotations[ActivationChangeReasonAnnotation] = "rollback"
+	// Pending changes given in the request are ignored
+	newObject.Annotations[PendingActivationChangesAnnotation] = "[]"
+
+	resp := handler.Handle(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: admissionv1.Update,
+		UserInfo:  authenticationv1.UserInfo{Username: "operator"},
+		Object:    runtime.RawExtension{Raw: mustMarshal(t, newObject)},
+		OldObject: runtime.RawExtension{Raw: mustMarshal(t, oldObject)},
+	}})
+	if !resp.Allowed {
+		t.Fatalf("expected request to be allowed: %v", resp.Result)
+	}
+
+	patched := applyPatches(t, newObject, resp.Patches)
+	if _, ok := patched.Annotations[ActivationChangeReasonAnnotation]; ok {
+		t.Errorf("expected the reason annotation to be removed")
+	}
+	pending, err := GetPendingActivationChanges(patched)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 {
+		t.Fatalf("expected 1 pending change, got %v", pending)
+	}
+	if pending[0].Actor != "operator" || pending[0].Reason != "rollback" || !pending[0].PreviousActivate || pending[0].Activate {
+		t.Errorf("unexpected pending change: %+v", pending[0])
+	}
+	if len(patched.Finalizers) != 0 {
+		t.Errorf("expected no finalizer without voided support warranties, got %v", patched.Finalizers)
+	}
+
+	// Voiding the support warranty protects the FeatureGate from deletion until the void is recorded
+	voiding := patched.DeepCopy()
+	voiding.Spec.Features[0].PermanentlyVoidAllSupportGuarantees = true
+	resp = handler.Handle(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: admissionv1.Update,
+		UserInfo:  authenticationv1.UserInfo{Username: "operator"},
+		Object:    runtime.RawExtension{Raw: mustMarshal(t, voiding)},
+		OldObject: runtime.RawExtension{Raw: mustMarshal(t, patched)},
+	}})
+	if !resp.Allowed {
+		t.Fatalf("expected request to be allowed: %v", resp.Result)
+	}
+	voided := applyPatches(t, voiding, resp.Patches)
+	if len(voided.Finalizers) != 1 || voided.Finalizers[0] != WarrantyVoidsFinalizer {
+		t.Errorf("expected the %s finalizer, got %v", WarrantyVoidsFinalizer, voided.Finalizers)
+	}
+}
+
+func mustMarshal(t *testing.T, obj interface{}) []byte {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func applyPatches(t *testing.T, featureGate *FeatureGate, patches []jsonpatch.JsonPatchOperation) *FeatureGate {
+	patch, err := jsonpatchapply.DecodePatch(mustMarshal(t, patches))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := patch.Apply(mustMarshal(t, featureGate))
+	if err != nil {
+		t.Fatal(err)
+	}
+	patched := &FeatureGate{}
+	if err := json.Unmarshal(raw, patched); err != nil {
+		t.Fatal(err)
+	}
+	return patched
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"sort"
+)
+
+// GetFeatureGatesForFeature returns the FeatureGates that reference the feature, highest priority first. FeatureGates
+// with the same priority are ordered by name.
+func GetFeatureGatesForFeature(featureGates []FeatureGate, featureName string) []*FeatureGate {
+	var gates []*FeatureGate
+	for i := range featureGates {
+		if _, found := getFeatureReference(featureGates[i].Spec, featureName); found {
+			gates = append(gates, &featureGates[i])
+		}
+	}
+	sort.SliceStable(gates, func(i, j int) bool {
+		if gates[i].Spec.Priority != gates[j].Spec.Priority {
+			return gates[i].Spec.Priority > gates[j].Spec.Priority
+		}
+		return gates[i].Name < gates[j].Name
+	})
+	return gates
+}
+
+// GetWinningFeatureGate returns the FeatureGate whose reference decides the activation of the feature, which is the
+// FeatureGate with the highest priority among the FeatureGates that reference it.
+func GetWinningFeatureGate(featureGates []FeatureGate, featureName string) (*FeatureGate, bool) {
+	gates := GetFeatureGatesForFeature(featureGates, featureName)
+	if len(gates) == 0 {
+		return nil, false
+	}
+	return gates[0], true
+}
+
+// GetWinningFeatureReference returns the reference to the feature of the FeatureGate that decides its activation,
+// along with the name of that FeatureGate.
+func GetWinningFeatureReference(featureGates []FeatureGate, featureName string) (string, FeatureReference, bool) {
+	gate, found := GetWinningFeatureGate(featureGates, featureName)
+	if !found {
+		return "", FeatureReference{}, false
+	}
+	ref, _ := getFeatureReference(gate.Spec, featureName)
+	return gate.Name, ref, true
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetFeatureGatesForFeature(t *testing.T) {
+	featureGate := func(name string, priority int32, features ...string) FeatureGate {
+		gate := FeatureGate{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: FeatureGateSpec{Priority: priority}}
+		for _, feature := range features {
+			gate.Spec.Features = append(gate.Spec.Features, FeatureReference{Name: feature})
+		}
+		return gate
+	}
+	featureGates := []FeatureGate{
+		featureGate("baseline", 0, "foo", "bar"),
+		featureGate("environment", 200, "foo"),
+		featureGate("platform-b", 100, "foo"),
+		featureGate("platform-a", 100, "foo", "bar"),
+	}
+
+	testCases := []struct {
+		description string
+		feature     string
+		want        []string
+	}{
+		{
+			description: "FeatureGates are ordered by decreasing priority, then by name",
+			feature:     "foo",
+			want:        []string{"environment", "platform-a", "platform-b", "baseline"},
+		},
+		{
+			description: "Only the FeatureGates that reference the feature are returned",
+			feature:     "bar",
+			want:        []string{"platform-a", "baseline"},
+		},
+		{
+			description: "No FeatureGate references the feature",
+			feature:     "baz",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var got []string
+			for _, gate := range GetFeatureGatesForFeature(featureGates, tc.feature) {
+				got = append(got, gate.Name)
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("got FeatureGates %v, want %v, diff: %s", got, tc.want, diff)
+			}
+
+			gateName, _, found := GetWinningFeatureReference(featureGates, tc.feature)
+			if found != (len(tc.want) > 0) || (found && gateName != tc.want[0]) {
+				t.Errorf("got winning FeatureGate %q, want the first of %v", gateName, tc.want)
+			}
+		})
+	}
+}
@@ -17,6 +17,29 @@ type FeatureReference struct {
 	// PermanentlyVoidAllSupportGuarantees when set to true permanently voids all support guarantees.
 	// Once set to true, cannot be set back to false
 	PermanentlyVoidAllSupportGuarantees bool `json:"permanentlyVoidAllSupportGuarantees,omitempty"`
+	// Rollout restricts the activation of the feature to a subset of the namespaces. If not set, the feature is
+	// activated in the whole cluster. Rollout can only be set to activate a feature that is deactivated by default.
+	// +optional
+	Rollout *FeatureRollout `json:"rollout,omitempty"`
+}
+
+// FeatureRollout selects the namespaces in which a feature is activated. A namespace is selected if it is listed in
+// Namespaces, matches NamespaceSelector, or is among the Percentage of namespaces selected for the feature.
+type FeatureRollout struct {
+	// Namespaces lists the namespaces in which the feature is activated.
+	// +optional
+	// +listType=set
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NamespaceSelector selects the namespaces in which the feature is activated by their labels.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Percentage is the percentage of the namespaces in which the feature is activated. Namespaces are selected by a
+	// hash of their name and of the name of the feature, so the namespaces selected for a lower percentage remain
+	// selected when the percentage increases.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percentage *int32 `json:"percentage,omitempty"`
 }
 
 // FeatureGateSpec defines the desired state of FeatureGate
@@ -26,6 +49,13 @@ type FeatureGateSpec struct {
 	// +listType=map
 	// +listMapKey=name
 	Features []FeatureReference `json:"features,omitempty"`
+	// Priority is the priority of the FeatureGate among the FeatureGates that reference the same feature. The reference
+	// of the FeatureGate with the highest priority decides the activation of the feature and overrides the references
+	// of the other FeatureGates. This allows FeatureGates to be layered, such as a vendor baseline FeatureGate, a
+	// platform team FeatureGate that overrides it, and a FeatureGate per environment that overrides both.
+	// FeatureGates that reference the same feature cannot have the same priority.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
 }
 
 // FeatureGateStatus defines the observed state of FeatureGate
@@ -34,6 +64,44 @@ type FeatureGateStatus struct {
 	// +listType=map
 	// +listMapKey=name
 	FeatureReferenceResults []FeatureReferenceResult `json:"featureReferenceResults"`
+	// History is the append-only history of the changes to the activation of the features in the FeatureGate spec,
+	// oldest first. Only the last ActivationHistoryLimit changes are kept.
+	// +optional
+	History []FeatureActivationChange `json:"history,omitempty"`
+}
+
+const (
+	// ActivationChangeReasonAnnotation is the annotation in which clients give the reason for the changes they make
+	// to the activation of features in a FeatureGate. The FeatureGate webhook removes it once it is recorded.
+	ActivationChangeReasonAnnotation = "core.tanzu.vmware.com/activation-change-reason"
+	// PendingActivationChangesAnnotation holds the activation changes recorded by the FeatureGate webhook that are
+	// yet to be added to the FeatureGate status history.
+	PendingActivationChangesAnnotation = "core.tanzu.vmware.com/pending-activation-changes"
+	// WarrantyVoidsFinalizer is set by the FeatureGate webhook on FeatureGates with pending activation changes that
+	// void the support warranty, so that they are not deleted before the voids are recorded in the SupportStatus.
+	WarrantyVoidsFinalizer = "core.tanzu.vmware.com/warranty-voids"
+	// ActivationHistoryLimit is the maximum number of activation changes kept in the FeatureGate status history.
+	ActivationHistoryLimit = 50
+)
+
+// FeatureActivationChange is a change to the activation of a feature in a FeatureGate
+type FeatureActivationChange struct {
+	// Feature is the name of the feature.
+	Feature string `json:"feature"`
+	// Actor is the user that made the change.
+	Actor string `json:"actor"`
+	// Time is the time at which the change was made.
+	Time metav1.Time `json:"time"`
+	// PreviousActivate is the intended activation of the feature before the change.
+	PreviousActivate bool `json:"previousActivate"`
+	// Activate is the intended activation of the feature after the change.
+	Activate bool `json:"activate"`
+	// VoidedWarranty is true if the change permanently voided all support guarantees for the environment.
+	// +optional
+	VoidedWarranty bool `json:"voidedWarranty,omitempty"`
+	// Reason is the reason given for the change.
+	// +optional
+	Reason string `json:"reason,omitempty"`
 }
 
 // FeatureReferenceStatus represents the status of the feature reference in the FeatureGate spec
@@ -42,6 +110,10 @@ type FeatureReferenceStatus string
 const (
 	AppliedReferenceStatus FeatureReferenceStatus = "Applied"
 	InvalidReferenceStatus FeatureReferenceStatus = "Invalid"
+	BlockedReferenceStatus FeatureReferenceStatus = "Blocked"
+	// OverriddenReferenceStatus indicates that the reference is overridden by the reference of a FeatureGate with a
+	// higher priority.
+	OverriddenReferenceStatus FeatureReferenceStatus = "Overridden"
 )
 
 // FeatureReferenceResult represents the result of FeatureReference.
@@ -52,9 +124,11 @@ type FeatureReferenceResult struct {
 	Name string `json:"name"`
 	// Status represents the outcome of the feature reference operation specified in the FeatureGate spec
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=Applied;Invalid
+	// +kubebuilder:validation:Enum=Applied;Invalid;Blocked;Overridden
 	// - Applied: represents feature toggle has been successfully applied.
 	// - Invalid: represents that the intended state of the feature is invalid.
+	// - Blocked: represents that the feature could not be activated because of its dependencies or conflicts.
+	// - Overridden: represents that the feature toggle is overridden by a FeatureGate with a higher priority.
 	Status FeatureReferenceStatus `json:"status"`
 	// Message represents the reason for status
 	// +optional
@@ -65,6 +139,7 @@ type FeatureReferenceResult struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
 // +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Priority",type=integer,JSONPath=.spec.priority
 
 // FeatureGate is the Schema for the featuregates API
 type FeatureGate struct {
@@ -5,10 +5,15 @@ package v1alpha2
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"reflect"
+	"time"
 
+	admissionv1 "k8s.io/api/admission/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -16,8 +21,10 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 // log is for logging in this package.
@@ -66,9 +73,140 @@ func (r *FeatureGate) SetupWebhookWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(r).
-		Complete()
+	// The FeatureGate validating webhook is registered with a handler that adds admission warnings about the lifecycle
+	// of the activated features, which webhook.Validator cannot return.
+	mgr.GetWebhookServer().Register("/validate-core-tanzu-vmware-com-v1alpha2-featuregate", &webhook.Admission{
+		Handler: &featureGateValidatingHandler{validatingHandler: admission.ValidatingWebhookFor(r).Handler},
+	})
+	// The FeatureGate mutating webhook records the activation changes with the user that made them, which is only
+	// known at admission.
+	mgr.GetWebhookServer().Register("/mutate-core-tanzu-vmware-com-v1alpha2-featuregate", &webhook.Admission{
+		Handler: &featureGateHistoryHandler{},
+	})
+	return nil
+}
+
+//+kubebuilder:webhook:verbs=create;update,path=/mutate-core-tanzu-vmware-com-v1alpha2-featuregate,mutating=true,failurePolicy=fail,groups=core.tanzu.vmware.com,resources=featuregates,versions=v1alpha2,name=mfeaturegate.kb.io
+
+// featureGateHistoryHandler records the changes to the activation of features in the PendingActivationChangesAnnotation
+// of FeatureGates, from which the Feature controller adds them to the FeatureGate status history. FeatureGates with
+// pending changes that void the support warranty get the WarrantyVoidsFinalizer.
+type featureGateHistoryHandler struct {
+	decoder *admission.Decoder
+}
+
+var _ admission.DecoderInjector = &featureGateHistoryHandler{}
+
+// InjectDecoder injects the decoder into the handler.
+func (h *featureGateHistoryHandler) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
+
+// Handle records the activation changes made by the request. The pending changes are always computed from the stored
+// FeatureGate, so that they cannot be altered by the request.
+func (h *featureGateHistoryHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	featureGate := &FeatureGate{}
+	if err := h.decoder.Decode(req, featureGate); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	var oldObject *FeatureGate
+	var pending []FeatureActivationChange
+	if req.Operation == admissionv1.Update {
+		oldObject = &FeatureGate{}
+		if err := h.decoder.DecodeRaw(req.OldObject, oldObject); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		oldPending, err := GetPendingActivationChanges(oldObject)
+		if err != nil {
+			featuregatelog.Error(err, "dropping pending activation changes", "name", featureGate.Name)
+		}
+		pending = UnrecordedActivationChanges(oldPending, oldObject.Status.History)
+	}
+
+	c, err := featureGate.getClient()
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	defaults, err := defaultActivations(ctx, c)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	reason := featureGate.Annotations[ActivationChangeReasonAnnotation]
+	delete(featureGate.Annotations, ActivationChangeReasonAnnotation)
+	changes := ComputeActivationChanges(oldObject, featureGate, defaults, req.UserInfo.Username, reason, metav1.Now())
+	pending = AppendActivationHistory(pending, changes)
+	if err := setPendingActivationChanges(featureGate, pending); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if HasWarrantyVoids(pending) {
+		controllerutil.AddFinalizer(featureGate, WarrantyVoidsFinalizer)
+	}
+
+	marshaled, err := json.Marshal(featureGate)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+// defaultActivations returns the default activation of the features in the cluster
+func defaultActivations(ctx context.Context, c client.Client) (map[string]bool, error) {
+	features := &FeatureList{}
+	if err := c.List(ctx, features); err != nil {
+		return nil, err
+	}
+	featurePolicies := &FeaturePolicyList{}
+	if err := c.List(ctx, featurePolicies); err != nil {
+		return nil, err
+	}
+
+	defaults := map[string]bool{}
+	for i := range features.Items {
+		defaults[features.Items[i].Name] = GetPolicyForStabilityLevel(features.Items[i].Spec.Stability, featurePolicies.Items...).DefaultActivation
+	}
+	return defaults, nil
+}
+
+// featureGateValidatingHandler validates FeatureGates and warns about the lifecycle of the features they activate
+type featureGateValidatingHandler struct {
+	validatingHandler admission.Handler
+	decoder           *admission.Decoder
+}
+
+var _ admission.DecoderInjector = &featureGateValidatingHandler{}
+
+// InjectDecoder injects the decoder into the handler and the validating handler it wraps.
+func (h *featureGateValidatingHandler) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	_, err := admission.InjectDecoderInto(d, h.validatingHandler)
+	return err
+}
+
+// Handle validates the FeatureGate and, if it is allowed, adds the lifecycle warnings of the features it activates.
+func (h *featureGateValidatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	resp := h.validatingHandler.Handle(ctx, req)
+	if !resp.Allowed || req.Operation == admissionv1.Delete {
+		return resp
+	}
+
+	featureGate := &FeatureGate{}
+	if err := h.decoder.Decode(req, featureGate); err != nil {
+		return resp
+	}
+	c, err := featureGate.getClient()
+	if err != nil {
+		featuregatelog.Error(err, "could not compute feature lifecycle warnings", "name", featureGate.Name)
+		return resp
+	}
+	features := &FeatureList{}
+	if err := c.List(ctx, features); err != nil {
+		featuregatelog.Error(err, "could not compute feature lifecycle warnings", "name", featureGate.Name)
+		return resp
+	}
+	return resp.WithWarnings(computeFeatureLifecycleWarnings(featureGate.Spec, features, time.Now())...)
 }
 
 //+kubebuilder:webhook:verbs=create;update,path=/validate-core-tanzu-vmware-com-v1alpha2-featuregate,mutating=false,failurePolicy=fail,groups=core.tanzu.vmware.com,resources=featuregates,versions=v1alpha2,name=vfeaturegate.kb.io
@@ -90,6 +228,9 @@ func (r *FeatureGate) ValidateCreate() error {
 	allErrors = append(allErrors, r.validateFeatureExists(ctx, c)...)
 	allErrors = append(allErrors, r.validateConflictingFeaturesInFeatureGate(ctx, c)...)
 	allErrors = append(allErrors, r.validateFeatureForStabilityPolicyViolation(ctx, c)...)
+	allErrors = append(allErrors, r.validateFeatureDependencies(ctx, c)...)
+	allErrors = append(allErrors, r.validateFeatureRollouts(ctx, c)...)
+	allErrors = append(allErrors, r.validateFeatureLifecycle(ctx, c, nil)...)
 	if len(allErrors) == 0 {
 		return nil
 	}
@@ -121,6 +262,9 @@ func (r *FeatureGate) ValidateUpdate(old runtime.Object) error {
 	allErrors = append(allErrors, r.validateConflictingFeaturesInFeatureGate(ctx, c)...)
 	allErrors = append(allErrors, r.validateWarrantyVoidOverride(oldObj)...)
 	allErrors = append(allErrors, r.validateFeatureForStabilityPolicyViolation(ctx, c)...)
+	allErrors = append(allErrors, r.validateFeatureDependencies(ctx, c)...)
+	allErrors = append(allErrors, r.validateFeatureRollouts(ctx, c)...)
+	allErrors = append(allErrors, r.validateFeatureLifecycle(ctx, c, oldObj)...)
 
 	if len(allErrors) == 0 {
 		return nil
@@ -145,8 +289,21 @@ func (r *FeatureGate) validateFeatureForStabilityPolicyViolation(ctx context.Con
 		return allErrors
 	}
 
-	featuresThatVoidWarranty := computeFeaturesThatVoidSupportWarranty(r.Spec, features)
-	immutableFeatures := computeImmutableFeatures(r.Spec, features)
+	featurePolicies := &FeaturePolicyList{}
+	if err := c.List(ctx, featurePolicies); err != nil {
+		allErrors = append(allErrors, field.InternalError(field.NewPath("spec").Child("features"), err))
+		return allErrors
+	}
+
+	undefinedFeatures := computeFeaturesWithUndefinedStabilityLevel(r.Spec, features, featurePolicies.Items)
+	featuresThatVoidWarranty := computeFeaturesThatVoidSupportWarranty(r.Spec, features, featurePolicies.Items)
+	immutableFeatures := computeImmutableFeatures(r.Spec, features, featurePolicies.Items)
+
+	if len(undefinedFeatures) > 0 {
+		allErrors = append(allErrors, field.Invalid(field.NewPath("spec").Child("features"),
+			r.Spec.Features, fmt.Sprintf("cannot toggle features %v as their stability level is not defined by any "+
+				"FeaturePolicy", undefinedFeatures)))
+	}
 
 	if len(featuresThatVoidWarranty) > 0 {
 		allErrors = append(allErrors, field.Invalid(field.NewPath("spec").Child("features"),
@@ -164,9 +321,22 @@ func (r *FeatureGate) validateFeatureForStabilityPolicyViolation(ctx context.Con
 	return allErrors
 }
 
+// computeFeaturesWithUndefinedStabilityLevel computes and returns features referenced in a FeatureGate resource spec
+// whose stability level is neither built-in nor defined by a FeaturePolicy resource
+func computeFeaturesWithUndefinedStabilityLevel(spec FeatureGateSpec, features *FeatureList, featurePolicies []FeaturePolicy) []string {
+	invalidFeatures := sets.String{}
+	for _, featureRef := range spec.Features {
+		stabilityLevel, found := getFeatureStabilityLevel(features, featureRef.Name)
+		if found && !IsStabilityLevelDefined(stabilityLevel, featurePolicies...) {
+			invalidFeatures.Insert(featureRef.Name)
+		}
+	}
+	return invalidFeatures.List()
+}
+
 // computeFeaturesThatVoidSupportWarranty computes and returns features that voids the support warranty in a FeatureGate
 // resource spec
-func computeFeaturesThatVoidSupportWarranty(spec FeatureGateSpec, features *FeatureList) []string {
+func computeFeaturesThatVoidSupportWarranty(spec FeatureGateSpec, features *FeatureList, featurePolicies []FeaturePolicy) []string {
 	invalidFeatures := sets.String{}
 	for _, featureRef := range spec.Features {
 		stabilityLevel, found := getFeatureStabilityLevel(features, featureRef.Name)
@@ -174,7 +344,7 @@ func computeFeaturesThatVoidSupportWarranty(spec FeatureGateSpec, features *Feat
 			// Feature doesn't exist and is validated in validateFeatureExistence method
 			continue
 		}
-		policy := GetPolicyForStabilityLevel(stabilityLevel)
+		policy := GetPolicyForStabilityLevel(stabilityLevel, featurePolicies...)
 		// checks for invalid features that voids warranty of the environment when activating it, ie if the intent for
 		// the feature is different from default feature state and the stability policy for the feature says it voids
 		// warranty, then that feature is considered as invalid.
@@ -187,7 +357,7 @@ func computeFeaturesThatVoidSupportWarranty(spec FeatureGateSpec, features *Feat
 }
 
 // computeImmutableFeatures computes and returns features that are immutable in a FeatureGate resource spec
-func computeImmutableFeatures(spec FeatureGateSpec, features *FeatureList) []string {
+func computeImmutableFeatures(spec FeatureGateSpec, features *FeatureList, featurePolicies []FeaturePolicy) []string {
 	invalidFeatures := sets.String{}
 	for _, featureRef := range spec.Features {
 		stabilityLevel, found := getFeatureStabilityLevel(features, featureRef.Name)
@@ -195,7 +365,7 @@ func computeImmutableFeatures(spec FeatureGateSpec, features *FeatureList) []str
 			// Feature doesn't exist and is validated in validateFeatureExistence method
 			continue
 		}
-		policy := GetPolicyForStabilityLevel(stabilityLevel)
+		policy := GetPolicyForStabilityLevel(stabilityLevel, featurePolicies...)
 		if policy.Immutable && policy.DefaultActivation != featureRef.Activate {
 			invalidFeatures.Insert(featureRef.Name)
 		}
@@ -203,6 +373,130 @@ func computeImmutableFeatures(spec FeatureGateSpec, features *FeatureList) []str
 	return invalidFeatures.List()
 }
 
+// validateFeatureDependencies validates that the features activated in a FeatureGate resource have their dependencies
+// activated and do not conflict with activated features, and that the FeatureGate does not block features that were
+// activated
+func (r *FeatureGate) validateFeatureDependencies(ctx context.Context, c client.Client) field.ErrorList {
+	var allErrors field.ErrorList
+
+	features := &FeatureList{}
+	if err := c.List(ctx, features); err != nil {
+		allErrors = append(allErrors, field.InternalError(field.NewPath("spec").Child("features"), err))
+		return allErrors
+	}
+
+	featureGates := &FeatureGateList{}
+	if err := c.List(ctx, featureGates); err != nil {
+		allErrors = append(allErrors, field.InternalError(field.NewPath("spec").Child("features"), err))
+		return allErrors
+	}
+
+	featurePolicies := &FeaturePolicyList{}
+	if err := c.List(ctx, featurePolicies); err != nil {
+		allErrors = append(allErrors, field.InternalError(field.NewPath("spec").Child("features"), err))
+		return allErrors
+	}
+
+	blocked := computeFeaturesBlockedByFeatureGate(r, features, featureGates, featurePolicies.Items)
+	for _, name := range sets.StringKeySet(blocked).List() {
+		allErrors = append(allErrors, field.Invalid(field.NewPath("spec").Child("features"),
+			r.Spec.Features, fmt.Sprintf("feature %s cannot be activated: %s", name, blocked[name])))
+	}
+	return allErrors
+}
+
+// computeFeaturesBlockedByFeatureGate computes and returns the features that the FeatureGate resource intends to
+// activate but are blocked, and the features that were activated but are blocked by the FeatureGate resource, with the
+// reasons why they are blocked. The FeatureGate list holds the stored version of the FeatureGate resource, if any.
+func computeFeaturesBlockedByFeatureGate(featureGate *FeatureGate, features *FeatureList, featureGates *FeatureGateList, featurePolicies []FeaturePolicy) map[string]string {
+	_, blockedBefore := ComputeFeatureActivation(features.Items, ComputeFeatureIntents(features.Items, featureGates.Items, nil, featurePolicies))
+	_, blockedAfter := ComputeFeatureActivation(features.Items, ComputeFeatureIntents(features.Items, featureGates.Items, featureGate, featurePolicies))
+
+	activatedInSpec := sets.String{}
+	blocked := map[string]string{}
+	for _, featureRef := range featureGate.Spec.Features {
+		if !featureRef.Activate {
+			continue
+		}
+		activatedInSpec.Insert(featureRef.Name)
+
+		// A feature that is rolled out to a subset of the namespaces is checked as if it was activated
+		if featureRef.Rollout != nil {
+			intents := ComputeFeatureIntents(features.Items, featureGates.Items, featureGate, featurePolicies)
+			intents[featureRef.Name] = true
+			if _, rolloutBlocked := ComputeFeatureActivation(features.Items, intents); rolloutBlocked[featureRef.Name] != "" {
+				blocked[featureRef.Name] = rolloutBlocked[featureRef.Name]
+			}
+		}
+	}
+
+	for name, reason := range blockedAfter {
+		if _, ok := blockedBefore[name]; !ok || activatedInSpec.Has(name) {
+			blocked[name] = reason
+		}
+	}
+	return blocked
+}
+
+// validateFeatureRollouts validates that rollouts in a FeatureGate resource only activate features that are
+// deactivated by default, and that their namespace selectors are valid
+func (r *FeatureGate) validateFeatureRollouts(ctx context.Context, c client.Client) field.ErrorList {
+	var allErrors field.ErrorList
+
+	features := &FeatureList{}
+	if err := c.List(ctx, features); err != nil {
+		allErrors = append(allErrors, field.InternalError(field.NewPath("spec").Child("features"), err))
+		return allErrors
+	}
+
+	featurePolicies := &FeaturePolicyList{}
+	if err := c.List(ctx, featurePolicies); err != nil {
+		allErrors = append(allErrors, field.InternalError(field.NewPath("spec").Child("features"), err))
+		return allErrors
+	}
+
+	for i, featureRef := range r.Spec.Features {
+		if featureRef.Rollout == nil {
+			continue
+		}
+		path := field.NewPath("spec").Child("features").Index(i).Child("rollout")
+
+		if !featureRef.Activate {
+			allErrors = append(allErrors, field.Invalid(path, featureRef.Name, "rollout can only be set to activate a feature"))
+			continue
+		}
+		if stabilityLevel, found := getFeatureStabilityLevel(features, featureRef.Name); found &&
+			GetPolicyForStabilityLevel(stabilityLevel, featurePolicies.Items...).DefaultActivation {
+			allErrors = append(allErrors, field.Invalid(path, featureRef.Name,
+				fmt.Sprintf("rollout cannot be set for feature %s, which is activated by default", featureRef.Name)))
+		}
+		if featureRef.Rollout.NamespaceSelector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(featureRef.Rollout.NamespaceSelector); err != nil {
+				allErrors = append(allErrors, field.Invalid(path.Child("namespaceSelector"), featureRef.Rollout.NamespaceSelector, err.Error()))
+			}
+		}
+	}
+	return allErrors
+}
+
+// validateFeatureLifecycle validates that a FeatureGate resource does not activate features after their removal date
+func (r *FeatureGate) validateFeatureLifecycle(ctx context.Context, c client.Client, oldObject *FeatureGate) field.ErrorList {
+	var allErrors field.ErrorList
+
+	features := &FeatureList{}
+	if err := c.List(ctx, features); err != nil {
+		allErrors = append(allErrors, field.InternalError(field.NewPath("spec").Child("features"), err))
+		return allErrors
+	}
+
+	removedFeatures := computeFeaturesActivatedPastRemovalDate(r.Spec, oldObject, features, time.Now())
+	if len(removedFeatures) > 0 {
+		allErrors = append(allErrors, field.Invalid(field.NewPath("spec").Child("features"),
+			r.Spec.Features, fmt.Sprintf("cannot activate features %v as they are past their removal date", removedFeatures)))
+	}
+	return allErrors
+}
+
 // validateWarrantyVoidOverride determines if permanentlyVoidAllSupportGuarantees field for a feature in FeatureGate
 // resource is set to false after setting it to true initially
 func (r *FeatureGate) validateWarrantyVoidOverride(oldObject *FeatureGate) field.ErrorList {
@@ -272,8 +566,8 @@ func computeFeaturesThatDoNotExist(spec FeatureGateSpec, features *FeatureList)
 	return invalidFeatures.List()
 }
 
-// validateConflictingFeaturesInFeatureGate validates that the features in FeatureGate resource does not conflict
-// with features gated by other FeatureGate resources.
+// validateConflictingFeaturesInFeatureGate validates that the features in FeatureGate resource are not gated by other
+// FeatureGate resources with the same priority, as none of their references would take precedence.
 func (r *FeatureGate) validateConflictingFeaturesInFeatureGate(ctx context.Context, c client.Client) field.ErrorList {
 	var allErrors field.ErrorList
 
@@ -287,13 +581,13 @@ func (r *FeatureGate) validateConflictingFeaturesInFeatureGate(ctx context.Conte
 
 	if len(conflicts) > 0 {
 		allErrors = append(allErrors, field.Invalid(field.NewPath("spec").Child("features"),
-			r.Spec.Features, fmt.Sprintf("features %v cannot be gated by multiple featuregates", conflicts)))
+			r.Spec.Features, fmt.Sprintf("features %v cannot be gated by multiple featuregates with the same priority %d", conflicts, r.Spec.Priority)))
 	}
 	return allErrors
 }
 
 // computeConflictingFeatures computes and returns features in FeatureGate resource that conflict with features in
-// other FeatureGate resources
+// other FeatureGate resources with the same priority
 func computeConflictingFeatures(featureGate *FeatureGate, featureGates *FeatureGateList) []string {
 	allFeaturesInSpec := sets.String{}
 
@@ -301,12 +595,12 @@ func computeConflictingFeatures(featureGate *FeatureGate, featureGates *FeatureG
 		allFeaturesInSpec.Insert(feature.Name)
 	}
 
-	// Gather all the gated features in the cluster
+	// Gather all the features gated with the same priority in the cluster
 	allGatedFeaturesInCluster := sets.String{}
 	for i := range featureGates.Items {
 		fg := featureGates.Items[i]
 		// Skip comparing the object to itself during updates.
-		if fg.Name == featureGate.Name {
+		if fg.Name == featureGate.Name || fg.Spec.Priority != featureGate.Spec.Priority {
 			continue
 		}
 		for _, feat := range fg.Spec.Features {
@@ -314,7 +608,8 @@ func computeConflictingFeatures(featureGate *FeatureGate, featureGates *FeatureG
 		}
 	}
 
-	// Intersection gives us the features that are already being gated by other FeatureGate resources
+	// Intersection gives us the features that are already being gated by other FeatureGate resources with the same
+	// priority
 	conflicts := allGatedFeaturesInCluster.Intersection(allFeaturesInSpec)
 	return conflicts.List()
 }
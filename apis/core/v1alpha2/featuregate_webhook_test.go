@@ -4,12 +4,14 @@
 package v1alpha2
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestComputeFeaturesThatVoidSupportWarranty(t *testing.T) {
@@ -73,7 +75,54 @@ func TestComputeFeaturesThatVoidSupportWarranty(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
-			got := computeFeaturesThatVoidSupportWarranty(tc.featureGateSpec, tc.featureList)
+			got := computeFeaturesThatVoidSupportWarranty(tc.featureGateSpec, tc.featureList, nil)
+			if diff := sliceDiffIgnoreOrder(got, tc.want); diff != "" {
+				t.Errorf("got invalid features %v, want %v, diff: %s", got, tc.want, diff)
+			}
+		})
+	}
+}
+
+func TestComputeFeaturesWithUndefinedStabilityLevel(t *testing.T) {
+	featureList := &FeatureList{
+		Items: []Feature{
+			{ObjectMeta: metav1.ObjectMeta{Name: "foo"}, Spec: FeatureSpec{Description: "foo", Stability: "Beta"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "bar"}, Spec: FeatureSpec{Description: "bar", Stability: "Gamma"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "baz"}, Spec: FeatureSpec{Description: "baz", Stability: "Technical Preview"}},
+		},
+	}
+	featurePolicies := []FeaturePolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+			Spec:       FeaturePolicySpec{Policies: []StabilityLevelPolicy{{Stability: "Beta"}}},
+		},
+	}
+	featureGateSpec := FeatureGateSpec{
+		Features: []FeatureReference{
+			{Name: "foo", Activate: true},
+			{Name: "bar", Activate: true},
+			{Name: "baz", Activate: true},
+		},
+	}
+
+	testCases := []struct {
+		description     string
+		featurePolicies []FeaturePolicy
+		want            []string
+	}{
+		{
+			description:     "Custom stability levels defined by a FeaturePolicy",
+			featurePolicies: featurePolicies,
+			want:            []string{"bar"},
+		},
+		{
+			description: "No FeaturePolicies",
+			want:        []string{"foo", "bar"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got := computeFeaturesWithUndefinedStabilityLevel(featureGateSpec, featureList, tc.featurePolicies)
 			if diff := sliceDiffIgnoreOrder(got, tc.want); diff != "" {
 				t.Errorf("got invalid features %v, want %v, diff: %s", got, tc.want, diff)
 			}
@@ -141,7 +190,7 @@ func TestComputeImmutableFeatures(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
-			got := computeImmutableFeatures(tc.featureGateSpec, tc.featureList)
+			got := computeImmutableFeatures(tc.featureGateSpec, tc.featureList, nil)
 			if diff := sliceDiffIgnoreOrder(got, tc.want); diff != "" {
 				t.Errorf("got invalid features %v, want %v, diff: %s", got, tc.want, diff)
 			}
@@ -303,6 +352,47 @@ func TestComputeConflictingFeatures(t *testing.T) {
 			},
 			want: []string{},
 		},
+		{
+			description: "Features gated by featuregates with different priorities do not conflict",
+			featureGate: &FeatureGate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-featuregate",
+				},
+				Spec: FeatureGateSpec{
+					Priority: 100,
+					Features: []FeatureReference{
+						{Name: "foo", Activate: false},
+						{Name: "bar", Activate: true},
+					},
+				},
+			},
+			featureGateList: &FeatureGateList{
+				Items: []FeatureGate{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "baseline-featuregate",
+						},
+						Spec: FeatureGateSpec{
+							Features: []FeatureReference{
+								{Name: "foo", Activate: true},
+							},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "platform-featuregate",
+						},
+						Spec: FeatureGateSpec{
+							Priority: 100,
+							Features: []FeatureReference{
+								{Name: "bar", Activate: false},
+							},
+						},
+					},
+				},
+			},
+			want: []string{"bar"},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
@@ -389,3 +479,69 @@ func TestComputeFeaturesThatDoNotExist(t *testing.T) {
 func sliceDiffIgnoreOrder(a, b []string) string {
 	return cmp.Diff(a, b, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(x, y string) bool { return x < y }))
 }
+
+func TestValidateFeatureRollouts(t *testing.T) {
+	s, err := getScheme()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(s).WithObjects(
+		&Feature{ObjectMeta: metav1.ObjectMeta{Name: "alpha"}, Spec: FeatureSpec{Stability: Experimental}},
+		&Feature{ObjectMeta: metav1.ObjectMeta{Name: "stable"}, Spec: FeatureSpec{Stability: Stable}},
+	).Build()
+
+	percentage := int32(20)
+	testCases := []struct {
+		description string
+		featureRef  FeatureReference
+		wantErrors  int
+	}{
+		{
+			description: "Rollout to namespaces",
+			featureRef:  FeatureReference{Name: "alpha", Activate: true, Rollout: &FeatureRollout{Namespaces: []string{"ns1"}}},
+			wantErrors:  0,
+		},
+		{
+			description: "Rollout to a percentage of namespaces",
+			featureRef:  FeatureReference{Name: "alpha", Activate: true, Rollout: &FeatureRollout{Percentage: &percentage}},
+			wantErrors:  0,
+		},
+		{
+			description: "Rollout that deactivates a feature",
+			featureRef:  FeatureReference{Name: "alpha", Activate: false, Rollout: &FeatureRollout{Namespaces: []string{"ns1"}}},
+			wantErrors:  1,
+		},
+		{
+			description: "Rollout of a feature activated by default",
+			featureRef:  FeatureReference{Name: "stable", Activate: true, Rollout: &FeatureRollout{Namespaces: []string{"ns1"}}},
+			wantErrors:  1,
+		},
+		{
+			description: "Rollout with an invalid namespace selector",
+			featureRef: FeatureReference{Name: "alpha", Activate: true, Rollout: &FeatureRollout{
+				NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "env", Operator: "Unknown", Values: []string{"dev"}},
+				}},
+			}},
+			wantErrors: 1,
+		},
+		{
+			description: "No rollout",
+			featureRef:  FeatureReference{Name: "stable", Activate: false},
+			wantErrors:  0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			featureGate := &FeatureGate{
+				ObjectMeta: metav1.ObjectMeta{Name: "tkg-system"},
+				Spec:       FeatureGateSpec{Features: []FeatureReference{tc.featureRef}},
+			}
+			if got := featureGate.validateFeatureRollouts(context.Background(), k8sClient); len(got) != tc.wantErrors {
+				t.Errorf("expected %d errors, got %d: %v", tc.wantErrors, len(got), got)
+			}
+		})
+	}
+}
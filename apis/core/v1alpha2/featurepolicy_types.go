@@ -0,0 +1,63 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StabilityLevelPolicy overrides the policy of a stability level. Fields that are not set keep the value of the
+// built-in policy of the stability level, or false for custom stability levels.
+type StabilityLevelPolicy struct {
+	// Stability is the stability level the policy applies to. It is either one of the built-in stability levels or a
+	// custom stability level.
+	// +kubebuilder:validation:Required
+	Stability StabilityLevel `json:"stability"`
+	// DefaultActivation is the default activation state of the features with the stability level.
+	// +optional
+	DefaultActivation *bool `json:"defaultActivation,omitempty"`
+	// Immutable indicates that the activation state of the features with the stability level cannot be toggled.
+	// +optional
+	Immutable *bool `json:"immutable,omitempty"`
+	// VoidsWarranty indicates that activating the features with the stability level voids the warranty of the
+	// environment.
+	// +optional
+	VoidsWarranty *bool `json:"voidsWarranty,omitempty"`
+	// Discoverable indicates that the features with the stability level are discoverable.
+	// +optional
+	Discoverable *bool `json:"discoverable,omitempty"`
+}
+
+// FeaturePolicySpec defines the desired state of FeaturePolicy
+type FeaturePolicySpec struct {
+	// Policies is the list of stability level policies.
+	// +optional
+	// +listType=map
+	// +listMapKey=stability
+	Policies []StabilityLevelPolicy `json:"policies,omitempty"`
+}
+
+// FeaturePolicy is the Schema for the featurepolicies API.
+// It overrides the built-in stability level policies and defines the policies of custom stability levels.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
+type FeaturePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              FeaturePolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FeaturePolicyList contains a list of FeaturePolicy
+type FeaturePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FeaturePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FeaturePolicy{}, &FeaturePolicyList{})
+}
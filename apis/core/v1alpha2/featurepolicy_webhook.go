@@ -0,0 +1,81 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var featurepolicylog = logf.Log.WithName("featurepolicy-resource").WithValues("apigroup", "core")
+
+// SetupWebhookWithManager adds the webhook to the manager.
+func (r *FeaturePolicy) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:verbs=create;update,path=/validate-core-tanzu-vmware-com-v1alpha2-featurepolicy,mutating=false,failurePolicy=fail,groups=core.tanzu.vmware.com,resources=featurepolicies,versions=v1alpha2,name=vfeaturepolicy.kb.io
+
+var _ webhook.Validator = &FeaturePolicy{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *FeaturePolicy) ValidateCreate() error {
+	featurepolicylog.Info("validate create", "name", r.Name)
+	return r.validatePolicies()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *FeaturePolicy) ValidateUpdate(old runtime.Object) error {
+	featurepolicylog.Info("validate update", "name", r.Name)
+	return r.validatePolicies()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *FeaturePolicy) ValidateDelete() error {
+	return nil
+}
+
+// validatePolicies rejects policies that relax the safeguards of the built-in stability levels. Stable features cannot
+// be made mutable, and the features of stability levels that void the support warranty can neither be made to keep it
+// nor be activated by default, which would let them be activated without recording a voided warranty. Custom
+// stability levels and policies that tighten the built-in ones are allowed.
+func (r *FeaturePolicy) validatePolicies() error {
+	var allErrors field.ErrorList
+	policiesPath := field.NewPath("spec").Child("policies")
+
+	for i, override := range r.Spec.Policies {
+		builtin, ok := StabilityPolicies[override.Stability]
+		if !ok {
+			continue
+		}
+		path := policiesPath.Index(i)
+
+		if builtin.Immutable && override.Immutable != nil && !*override.Immutable {
+			allErrors = append(allErrors, field.Forbidden(path.Child("immutable"),
+				fmt.Sprintf("features with the %s stability level cannot be made mutable", override.Stability)))
+		}
+		if builtin.VoidsWarranty && override.VoidsWarranty != nil && !*override.VoidsWarranty {
+			allErrors = append(allErrors, field.Forbidden(path.Child("voidsWarranty"),
+				fmt.Sprintf("activating features with the %s stability level must void the support warranty", override.Stability)))
+		}
+		if builtin.VoidsWarranty && override.DefaultActivation != nil && *override.DefaultActivation != builtin.DefaultActivation {
+			allErrors = append(allErrors, field.Forbidden(path.Child("defaultActivation"),
+				fmt.Sprintf("the default activation of features with the %s stability level cannot be changed, since it decides whether activating them voids the support warranty", override.Stability)))
+		}
+	}
+
+	if len(allErrors) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("FeaturePolicy").GroupKind(), r.Name, allErrors)
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateFeaturePolicy(t *testing.T) {
+	yes, no := true, false
+
+	testCases := []struct {
+		description string
+		policies    []StabilityLevelPolicy
+		wantErr     bool
+	}{
+		{
+			description: "Hide technical preview features and define a custom stability level",
+			policies: []StabilityLevelPolicy{
+				{Stability: TechnicalPreview, Discoverable: &no},
+				{Stability: "Beta", VoidsWarranty: &no, Immutable: &no, DefaultActivation: &yes},
+			},
+		},
+		{
+			description: "Tighten the policy of a built-in stability level",
+			policies:    []StabilityLevelPolicy{{Stability: TechnicalPreview, VoidsWarranty: &yes, Immutable: &yes}},
+		},
+		{
+			description: "Keep the built-in values",
+			policies:    []StabilityLevelPolicy{{Stability: Experimental, VoidsWarranty: &yes, DefaultActivation: &no}, {Stability: Stable, Immutable: &yes}},
+		},
+		{
+			description: "Experimental features cannot keep the support warranty",
+			policies:    []StabilityLevelPolicy{{Stability: Experimental, VoidsWarranty: &no}},
+			wantErr:     true,
+		},
+		{
+			description: "Work in progress features cannot be activated by default",
+			policies:    []StabilityLevelPolicy{{Stability: WorkInProgress, DefaultActivation: &yes}},
+			wantErr:     true,
+		},
+		{
+			description: "Stable features cannot be made mutable",
+			policies:    []StabilityLevelPolicy{{Stability: Stable, Immutable: &no}},
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			policy := &FeaturePolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy"}, Spec: FeaturePolicySpec{Policies: tc.policies}}
+
+			if err := policy.ValidateCreate(); (err != nil) != tc.wantErr {
+				t.Errorf("create: got error %v, want error %t", err, tc.wantErr)
+			}
+			if err := policy.ValidateUpdate(&FeaturePolicy{}); (err != nil) != tc.wantErr {
+				t.Errorf("update: got error %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
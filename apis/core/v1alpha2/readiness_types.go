@@ -36,6 +36,67 @@ type Check struct {
 
 	// Category is the category of the check. Examples of categories are availability and security.
 	Category string `json:"category"`
+
+	// CheckRefs contains the names of the checks in the same Readiness that a composite check depends on.
+	// A composite check is ready only when all the referenced checks are ready.
+	// This field is ignored for basic checks.
+	//+kubebuilder:validation:Optional
+	CheckRefs []string `json:"checkRefs,omitempty"`
+
+	// ProviderSelector selects the ReadinessProviders that can satisfy a basic check by their labels.
+	// If not provided, any ReadinessProvider that references the check name satisfies the check.
+	// Readiness resources that define a check with the same name but a different definition must scope their providers
+	// with a selector; otherwise the check name is ambiguous and the Readiness is rejected.
+	//+kubebuilder:validation:Optional
+	ProviderSelector *metav1.LabelSelector `json:"providerSelector,omitempty"`
+
+	// Aggregation is the policy that determines how many providers must be active for a basic check to be ready.
+	// If not provided, the check is ready as soon as any of its providers is active.
+	// This field is ignored for composite checks.
+	//+kubebuilder:validation:Optional
+	Aggregation *AggregationPolicy `json:"aggregation,omitempty"`
+
+	// ProgressDeadlineSeconds is the time the check is given to become ready.
+	// The deadline starts when the check stops being ready, or when the check is first evaluated.
+	// A check that is not ready after its deadline is reported in the status with deadlineExceeded set.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=1
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+}
+
+// AggregationPolicyType is the type of the aggregation policy of a check
+type AggregationPolicyType string
+
+const (
+	// AnyAggregationPolicy requires at least one active provider
+	AnyAggregationPolicy = AggregationPolicyType("any")
+
+	// AllAggregationPolicy requires all the providers to be active, and at least one provider
+	AllAggregationPolicy = AggregationPolicyType("all")
+
+	// MinCountAggregationPolicy requires at least MinCount active providers
+	MinCountAggregationPolicy = AggregationPolicyType("minCount")
+
+	// WeightedAggregationPolicy requires the sum of the weights of the active providers to reach Threshold
+	WeightedAggregationPolicy = AggregationPolicyType("weighted")
+)
+
+// AggregationPolicy defines how the states of the providers of a check are combined
+type AggregationPolicy struct {
+	// Type is the type of the aggregation policy
+	// +kubebuilder:validation:Enum=any;all;minCount;weighted
+	// +kubebuilder:default=any
+	Type AggregationPolicyType `json:"type"`
+
+	// MinCount is the minimum number of active providers required by the minCount policy
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=1
+	MinCount *int32 `json:"minCount,omitempty"`
+
+	// Threshold is the minimum sum of the weights of the active providers required by the weighted policy
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=1
+	Threshold *int32 `json:"threshold,omitempty"`
 }
 
 // ReadinessStatus defines the observed state of Readiness
@@ -46,6 +107,32 @@ type ReadinessStatus struct {
 	// Ready is the flag that denotes if the defined readiness is ready.
 	// The readiness is marked ready if all the checks are satisfied.
 	Ready bool `json:"ready"`
+
+	// Conditions contains the latest observations of the readiness state.
+	// The Ready condition mirrors the Ready flag and records when it last changed.
+	//+kubebuilder:validation:Optional
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ReadyConditionType is the type of the condition that reports whether a resource is ready
+const ReadyConditionType = "Ready"
+
+// MaxCheckHistory is the maximum number of transitions kept in the history of a check
+const MaxCheckHistory = 10
+
+// CheckTransition records a change of the ready state of a check
+type CheckTransition struct {
+	// Ready is the state of the check after the transition
+	Ready bool `json:"ready"`
+
+	// Time is the time of the transition
+	Time metav1.Time `json:"time"`
+
+	// Message provides information about the check at the time of the transition
+	//+kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
 }
 
 type CheckStatus struct {
@@ -57,6 +144,27 @@ type CheckStatus struct {
 
 	// Providers is the list of providers available for the given check
 	Providers []Provider `json:"providers"`
+
+	// BlockingChecks is the list of checks referenced by a composite check that are not ready yet
+	//+kubebuilder:validation:Optional
+	BlockingChecks []string `json:"blockingChecks,omitempty"`
+
+	// Message provides information about the check evaluation
+	//+kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+
+	// DeadlineExceeded is the boolean flag indicating if the check did not become ready within its progress deadline
+	//+kubebuilder:validation:Optional
+	DeadlineExceeded bool `json:"deadlineExceeded,omitempty"`
+
+	// LastTransitionTime is the last time the ready state of the check changed
+	//+kubebuilder:validation:Optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// History is the list of the most recent transitions of the check, oldest first.
+	// At most MaxCheckHistory transitions are kept.
+	//+kubebuilder:validation:Optional
+	History []CheckTransition `json:"history,omitempty"`
 }
 
 type Provider struct {
@@ -65,6 +173,14 @@ type Provider struct {
 
 	// IsActive is the boolean flag indicating if the provider is active
 	IsActive bool `json:"isActive"`
+
+	// Weight is the weight of the provider, reported for checks that use the weighted aggregation policy
+	//+kubebuilder:validation:Optional
+	Weight *int32 `json:"weight,omitempty"`
+
+	// Counted is the boolean flag indicating if the provider counted towards the result of the check
+	//+kubebuilder:validation:Optional
+	Counted bool `json:"counted,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,251 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var readinesslog = logf.Log.WithName("readiness-resource").WithValues("apigroup", "core")
+
+// SetupWebhookWithManager adds the webhook to the manager.
+func (r *Readiness) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	s, err := getScheme()
+	if err != nil {
+		return err
+	}
+
+	kubeClient, err = client.New(mgr.GetConfig(), client.Options{Scheme: s})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+var _ webhook.Validator = &Readiness{}
+
+// Get a cached client.
+func (r *Readiness) getClient() (client.Client, error) {
+	if kubeClient != nil && !reflect.ValueOf(kubeClient).IsNil() {
+		return kubeClient, nil
+	}
+
+	s, err := getScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	return client.New(cfg, client.Options{Scheme: s})
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *Readiness) ValidateCreate() error {
+	readinesslog.Info("validate create", "name", r.Name)
+	ctx := context.Background()
+
+	c, err := r.getClient()
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	return r.validateObject(ctx, c)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *Readiness) ValidateUpdate(_ runtime.Object) error {
+	readinesslog.Info("validate update", "name", r.Name)
+	ctx := context.Background()
+
+	c, err := r.getClient()
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	return r.validateObject(ctx, c)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *Readiness) ValidateDelete() error {
+	readinesslog.Info("validate delete", "name", r.Name)
+	return nil
+}
+
+func (r *Readiness) validateObject(ctx context.Context, k8sClient client.Client) error {
+	var allErrors field.ErrorList
+	checksPath := field.NewPath("spec").Child("checks")
+
+	for i, check := range r.Spec.Checks {
+		if check.ProviderSelector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(check.ProviderSelector); err != nil {
+				allErrors = append(allErrors, field.Invalid(checksPath.Index(i).Child("providerSelector"), check.ProviderSelector, err.Error()))
+			}
+		}
+		if check.Aggregation != nil {
+			allErrors = append(allErrors, validateAggregationPolicy(check.Aggregation, checksPath.Index(i).Child("aggregation"))...)
+		}
+	}
+
+	readinessList := &ReadinessList{}
+	if err := k8sClient.List(ctx, readinessList); err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	allErrors = append(allErrors, r.validateCheckCollisions(readinessList.Items)...)
+
+	if len(allErrors) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(GroupVersion.WithKind("Readiness").GroupKind(), r.Name, allErrors)
+}
+
+// validateCheckCollisions rejects checks whose names are ambiguous across Readiness resources.
+// A check name is ambiguous when another Readiness defines a check with the same name, unless both checks scope their
+// providers with selectors that no set of labels can match at the same time. Otherwise, a provider could satisfy both
+// checks.
+func (r *Readiness) validateCheckCollisions(others []Readiness) field.ErrorList {
+	var allErrors field.ErrorList
+	checksPath := field.NewPath("spec").Child("checks")
+
+	for i := range r.Spec.Checks {
+		check := &r.Spec.Checks[i]
+		for j := range others {
+			if others[j].Name == r.Name {
+				continue
+			}
+
+			for k := range others[j].Spec.Checks {
+				other := &others[j].Spec.Checks[k]
+				if other.Name != check.Name || labelSelectorsAreDisjoint(check.ProviderSelector, other.ProviderSelector) {
+					continue
+				}
+
+				allErrors = append(allErrors, field.Invalid(checksPath.Index(i).Child("name"), check.Name,
+					fmt.Sprintf("check is also defined in Readiness %s; set providerSelector on both checks so that no provider matches both", others[j].Name)))
+			}
+		}
+	}
+
+	return allErrors
+}
+
+// validateAggregationPolicy checks that the parameters required by the policy type are set
+func validateAggregationPolicy(policy *AggregationPolicy, fldPath *field.Path) field.ErrorList {
+	var allErrors field.ErrorList
+
+	switch policy.Type {
+	case MinCountAggregationPolicy:
+		if policy.MinCount == nil {
+			allErrors = append(allErrors, field.Required(fldPath.Child("minCount"), "minCount is required by the minCount policy"))
+		}
+	case WeightedAggregationPolicy:
+		if policy.Threshold == nil {
+			allErrors = append(allErrors, field.Required(fldPath.Child("threshold"), "threshold is required by the weighted policy"))
+		}
+	}
+
+	return allErrors
+}
+
+// labelRequirement is what a label selector requires of a single label key
+type labelRequirement struct {
+	// values are the values the label may have, nil if any value is allowed
+	values sets.String
+	// excluded are the values the label may not have
+	excluded sets.String
+	exists   bool
+	absent   bool
+}
+
+// labelSelectorsAreDisjoint returns true if no set of labels can match both selectors. A nil selector matches no
+// labels in this context, since it does not scope the check, so it is never disjoint from another selector.
+func labelSelectorsAreDisjoint(a, b *metav1.LabelSelector) bool {
+	if a == nil || b == nil {
+		return false
+	}
+
+	requirementsA, requirementsB := labelRequirements(a), labelRequirements(b)
+	for key, reqA := range requirementsA {
+		reqB, ok := requirementsB[key]
+		if !ok {
+			continue
+		}
+		if labelRequirementsConflict(reqA, reqB) || labelRequirementsConflict(reqB, reqA) {
+			return true
+		}
+	}
+	return false
+}
+
+// labelRequirements returns the requirements of the selector for each label key
+func labelRequirements(selector *metav1.LabelSelector) map[string]*labelRequirement {
+	requirements := make(map[string]*labelRequirement)
+	get := func(key string) *labelRequirement {
+		if _, ok := requirements[key]; !ok {
+			requirements[key] = &labelRequirement{excluded: sets.NewString()}
+		}
+		return requirements[key]
+	}
+	allow := func(req *labelRequirement, values ...string) {
+		if req.values == nil {
+			req.values = sets.NewString(values...)
+			return
+		}
+		req.values = req.values.Intersection(sets.NewString(values...))
+	}
+
+	for key, value := range selector.MatchLabels {
+		allow(get(key), value)
+	}
+	for _, expr := range selector.MatchExpressions {
+		req := get(expr.Key)
+		switch expr.Operator {
+		case metav1.LabelSelectorOpIn:
+			allow(req, expr.Values...)
+		case metav1.LabelSelectorOpNotIn:
+			req.excluded.Insert(expr.Values...)
+		case metav1.LabelSelectorOpExists:
+			req.exists = true
+		case metav1.LabelSelectorOpDoesNotExist:
+			req.absent = true
+		}
+	}
+	return requirements
+}
+
+// labelRequirementsConflict returns true if a label value required by a is never allowed by b
+func labelRequirementsConflict(a, b *labelRequirement) bool {
+	if (a.values != nil || a.exists) && b.absent {
+		return true
+	}
+	if a.values == nil {
+		return false
+	}
+	allowed := a.values.Difference(b.excluded)
+	if b.values != nil {
+		allowed = allowed.Intersection(b.values)
+	}
+	return allowed.Len() == 0
+}
@@ -0,0 +1,178 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateCheckCollisions(t *testing.T) {
+	teamA := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+	teamB := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}}
+
+	readinessWithCheck := func(name string, check Check) Readiness {
+		return Readiness{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       ReadinessSpec{Checks: []Check{check}},
+		}
+	}
+
+	testCases := []struct {
+		description string
+		readiness   Readiness
+		others      []Readiness
+		wantErrors  int
+	}{
+		{
+			description: "Check name not used by other readiness resources",
+			readiness:   readinessWithCheck("foo", Check{Name: "check1", Type: BasicReadinessCheck}),
+			others:      []Readiness{readinessWithCheck("bar", Check{Name: "check2", Type: BasicReadinessCheck})},
+			wantErrors:  0,
+		},
+		{
+			description: "Identical checks without provider selectors",
+			readiness:   readinessWithCheck("foo", Check{Name: "check1", Type: BasicReadinessCheck, Category: "x"}),
+			others:      []Readiness{readinessWithCheck("bar", Check{Name: "check1", Type: BasicReadinessCheck, Category: "x"})},
+			wantErrors:  1,
+		},
+		{
+			description: "Checks with identical provider selectors",
+			readiness:   readinessWithCheck("foo", Check{Name: "check1", Type: BasicReadinessCheck, ProviderSelector: teamA}),
+			others:      []Readiness{readinessWithCheck("bar", Check{Name: "check1", Type: BasicReadinessCheck, ProviderSelector: teamA})},
+			wantErrors:  1,
+		},
+		{
+			description: "Checks with overlapping provider selectors",
+			readiness:   readinessWithCheck("foo", Check{Name: "check1", Type: BasicReadinessCheck, ProviderSelector: teamA}),
+			others: []Readiness{readinessWithCheck("bar", Check{Name: "check1", Type: BasicReadinessCheck,
+				ProviderSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "gold"}}})},
+			wantErrors: 1,
+		},
+		{
+			description: "Update of the same readiness resource",
+			readiness:   readinessWithCheck("foo", Check{Name: "check1", Type: BasicReadinessCheck, Category: "y"}),
+			others:      []Readiness{readinessWithCheck("foo", Check{Name: "check1", Type: BasicReadinessCheck, Category: "x"})},
+			wantErrors:  0,
+		},
+		{
+			description: "Different definitions without provider selectors",
+			readiness:   readinessWithCheck("foo", Check{Name: "check1", Type: BasicReadinessCheck, Category: "y"}),
+			others:      []Readiness{readinessWithCheck("bar", Check{Name: "check1", Type: BasicReadinessCheck, Category: "x"})},
+			wantErrors:  1,
+		},
+		{
+			description: "Different definitions where only one check has a provider selector",
+			readiness:   readinessWithCheck("foo", Check{Name: "check1", Type: BasicReadinessCheck, ProviderSelector: teamA}),
+			others:      []Readiness{readinessWithCheck("bar", Check{Name: "check1", Type: BasicReadinessCheck})},
+			wantErrors:  1,
+		},
+		{
+			description: "Different definitions scoped with provider selectors",
+			readiness:   readinessWithCheck("foo", Check{Name: "check1", Type: BasicReadinessCheck, ProviderSelector: teamA}),
+			others:      []Readiness{readinessWithCheck("bar", Check{Name: "check1", Type: BasicReadinessCheck, ProviderSelector: teamB})},
+			wantErrors:  0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := tc.readiness.validateCheckCollisions(tc.others); len(got) != tc.wantErrors {
+				t.Errorf("got %d errors, want %d: %v", len(got), tc.wantErrors, got)
+			}
+		})
+	}
+}
+
+func TestLabelSelectorsAreDisjoint(t *testing.T) {
+	testCases := []struct {
+		description string
+		a, b        *metav1.LabelSelector
+		want        bool
+	}{
+		{
+			description: "Nil selector",
+			a:           &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			want:        false,
+		},
+		{
+			description: "Different values of the same label",
+			a:           &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			b:           &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}},
+			want:        true,
+		},
+		{
+			description: "Identical selectors",
+			a:           &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			b:           &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			want:        false,
+		},
+		{
+			description: "Different labels",
+			a:           &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			b:           &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "gold"}},
+			want:        false,
+		},
+		{
+			description: "Overlapping In expressions",
+			a: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"a", "b"}}}},
+			b: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"b", "c"}}}},
+			want: false,
+		},
+		{
+			description: "Value excluded by NotIn",
+			a:           &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			b: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"a"}}}},
+			want: true,
+		},
+		{
+			description: "Label required by one selector and absent in the other",
+			a: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: metav1.LabelSelectorOpExists}}},
+			b: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: metav1.LabelSelectorOpDoesNotExist}}},
+			want: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := labelSelectorsAreDisjoint(tc.a, tc.b); got != tc.want {
+				t.Errorf("got %t, want %t", got, tc.want)
+			}
+			if got := labelSelectorsAreDisjoint(tc.b, tc.a); got != tc.want {
+				t.Errorf("got %t with swapped selectors, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateAggregationPolicy(t *testing.T) {
+	two := int32(2)
+
+	testCases := []struct {
+		description string
+		policy      *AggregationPolicy
+		wantErrors  int
+	}{
+		{description: "Any policy", policy: &AggregationPolicy{Type: AnyAggregationPolicy}, wantErrors: 0},
+		{description: "MinCount policy with minCount", policy: &AggregationPolicy{Type: MinCountAggregationPolicy, MinCount: &two}, wantErrors: 0},
+		{description: "MinCount policy without minCount", policy: &AggregationPolicy{Type: MinCountAggregationPolicy}, wantErrors: 1},
+		{description: "Weighted policy with threshold", policy: &AggregationPolicy{Type: WeightedAggregationPolicy, Threshold: &two}, wantErrors: 0},
+		{description: "Weighted policy without threshold", policy: &AggregationPolicy{Type: WeightedAggregationPolicy}, wantErrors: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := validateAggregationPolicy(tc.policy, field.NewPath("aggregation")); len(got) != tc.wantErrors {
+				t.Errorf("got %d errors, want %d: %v", len(got), tc.wantErrors, got)
+			}
+		})
+	}
+}
@@ -35,11 +35,49 @@ const (
 	ConditionInProgressState = ReadinessConditionState("inprogress")
 )
 
+// ReadinessProviderConditionType is the type of a readiness provider condition.
+// It matches the JSON field name of the condition type in ReadinessProviderCondition.
+type ReadinessProviderConditionType string
+
+const (
+	// ResourceExistenceConditionType is the type of ResourceExistenceCondition
+	ResourceExistenceConditionType = ReadinessProviderConditionType("resourceExistenceCondition")
+
+	// ResourceStatusConditionType is the type of ResourceStatusCondition
+	ResourceStatusConditionType = ReadinessProviderConditionType("resourceStatusCondition")
+
+	// FieldComparisonConditionType is the type of FieldComparisonCondition
+	FieldComparisonConditionType = ReadinessProviderConditionType("fieldComparisonCondition")
+
+	// RolloutConditionType is the type of RolloutCondition
+	RolloutConditionType = ReadinessProviderConditionType("rolloutCondition")
+
+	// ServiceProbeConditionType is the type of ServiceProbeCondition
+	ServiceProbeConditionType = ReadinessProviderConditionType("serviceProbeCondition")
+
+	// CapabilityConditionType is the type of CapabilityCondition
+	CapabilityConditionType = ReadinessProviderConditionType("capabilityCondition")
+
+	// QueryConditionType is the type of QueryCondition
+	QueryConditionType = ReadinessProviderConditionType("queryCondition")
+)
+
 // ReadinessProviderSpec defines the desired state of ReadinessProvider
 type ReadinessProviderSpec struct {
 	// CheckRefs contains names of the checks that the current provider satisfies
 	CheckRefs []string `json:"checkRefs"`
 
+	// ReadinessSelector selects the Readiness resources whose checks the provider may satisfy by their labels.
+	// If not provided, the provider may satisfy the referenced checks of any Readiness.
+	//+kubebuilder:validation:Optional
+	ReadinessSelector *metav1.LabelSelector `json:"readinessSelector,omitempty"`
+
+	// Weight is the weight of the provider in checks that use the weighted aggregation policy.
+	// Providers with a weight of zero never count towards weighted checks. Defaults to 1.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=0
+	Weight *int32 `json:"weight,omitempty"`
+
 	// Conditions is the set of checks that must be evaluated to true to mark the provider as ready
 	Conditions []ReadinessProviderCondition `json:"conditions"`
 
@@ -50,6 +88,14 @@ type ReadinessProviderSpec struct {
 	// which may not have appropriate RBAC for evaluating conditions.
 	//+kubebuilder:validation:Optional
 	ServiceAccountRef *ServiceAccountRef `json:"serviceAccountRef"`
+
+	// ResyncInterval is the interval at which the conditions are re-evaluated after the provider succeeds.
+	// Changes to the resources referenced by the conditions trigger an evaluation immediately,
+	// so the resync only matters for state that cannot be watched, like service probes.
+	// While the provider is not successful, evaluations are retried with an exponential backoff capped at this interval.
+	// Defaults to 60s.
+	//+kubebuilder:validation:Optional
+	ResyncInterval *metav1.Duration `json:"resyncInterval,omitempty"`
 }
 
 type ServiceAccountRef struct {
@@ -69,6 +115,70 @@ type ReadinessProviderCondition struct {
 	// ResourceExistenceCondition is the condition that checks for the presence of a certain resource in the cluster
 	//+kubebuilder:validation:Optional
 	ResourceExistenceCondition *ResourceExistenceCondition `json:"resourceExistenceCondition"`
+
+	// ResourceStatusCondition is the condition that checks for a status condition of a certain resource in the cluster
+	//+kubebuilder:validation:Optional
+	ResourceStatusCondition *ResourceStatusCondition `json:"resourceStatusCondition,omitempty"`
+
+	// FieldComparisonCondition is the condition that compares a field of a certain resource in the cluster with a value
+	//+kubebuilder:validation:Optional
+	FieldComparisonCondition *FieldComparisonCondition `json:"fieldComparisonCondition,omitempty"`
+
+	// RolloutCondition is the condition that checks if the rollout of a Deployment or StatefulSet is complete
+	//+kubebuilder:validation:Optional
+	RolloutCondition *RolloutCondition `json:"rolloutCondition,omitempty"`
+
+	// ServiceProbeCondition is the condition that probes a Service in the cluster over HTTP or TCP
+	//+kubebuilder:validation:Optional
+	ServiceProbeCondition *ServiceProbeCondition `json:"serviceProbeCondition,omitempty"`
+
+	// CapabilityCondition is the condition that checks the results of a Capability
+	//+kubebuilder:validation:Optional
+	CapabilityCondition *CapabilityCondition `json:"capabilityCondition,omitempty"`
+
+	// QueryCondition is the condition that runs the given capability query and succeeds when all its results are found.
+	// It uses the same schema as the queries of a Capability, so GVR, object and partial schema queries can be defined inline.
+	//+kubebuilder:validation:Optional
+	QueryCondition *Query `json:"queryCondition,omitempty"`
+
+	// ProgressDeadlineSeconds is the time the condition is given to succeed.
+	// Until the deadline, a condition that does not succeed is reported as inprogress;
+	// after the deadline, it is reported as failure with the ProgressDeadlineExceeded reason.
+	// The deadline starts when the condition stops succeeding, or when it is first evaluated.
+	// If not provided, the condition reports the state returned by its evaluation.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=1
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+}
+
+// ProgressDeadlineExceededReason is the reason reported by conditions and checks that did not succeed within their deadline
+const ProgressDeadlineExceededReason = "ProgressDeadlineExceeded"
+
+// DefinedTypes returns the types of all the conditions that are defined in the ReadinessProviderCondition
+func (c *ReadinessProviderCondition) DefinedTypes() []ReadinessProviderConditionType {
+	var types []ReadinessProviderConditionType
+	if c.ResourceExistenceCondition != nil {
+		types = append(types, ResourceExistenceConditionType)
+	}
+	if c.ResourceStatusCondition != nil {
+		types = append(types, ResourceStatusConditionType)
+	}
+	if c.FieldComparisonCondition != nil {
+		types = append(types, FieldComparisonConditionType)
+	}
+	if c.RolloutCondition != nil {
+		types = append(types, RolloutConditionType)
+	}
+	if c.ServiceProbeCondition != nil {
+		types = append(types, ServiceProbeConditionType)
+	}
+	if c.CapabilityCondition != nil {
+		types = append(types, CapabilityConditionType)
+	}
+	if c.QueryCondition != nil {
+		types = append(types, QueryConditionType)
+	}
+	return types
 }
 
 // ResourceExistenceCondition is a type of readiness provider condition that checks for existence of given resource
@@ -89,6 +199,148 @@ type ResourceExistenceCondition struct {
 	Name      string  `json:"name"`
 }
 
+// ResourceReference identifies a single resource in the cluster
+type ResourceReference struct {
+	// APIVersion is the API version of the resource that is being checked.
+	// This should be provided in <group>/<version> format.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind is the API kind of the resource that is being checked
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the resource that is being checked; if the Namespace is nil,
+	// the resource is assumed to be cluster scoped. Empty string for the namespace will throw error.
+	//+kubebuilder:validation:Optional
+	Namespace *string `json:"namespace"`
+
+	// Name is the name of the resource that is being checked
+	Name string `json:"name"`
+}
+
+// ResourceStatusCondition is a type of readiness provider condition that checks
+// for a condition in the status of the given resource
+type ResourceStatusCondition struct {
+	ResourceReference `json:",inline"`
+
+	// ConditionType is the type of the status condition that is being checked
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:default=Ready
+	ConditionType string `json:"conditionType"`
+
+	// Status is the expected status of the status condition
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Enum=True;False;Unknown
+	//+kubebuilder:default=True
+	Status metav1.ConditionStatus `json:"status"`
+}
+
+// FieldComparisonOperator is the operator used to compare a field with a value
+type FieldComparisonOperator string
+
+const (
+	// FieldEqualsOperator succeeds when the field is equal to the value
+	FieldEqualsOperator = FieldComparisonOperator("Equals")
+
+	// FieldNotEqualsOperator succeeds when the field exists and is not equal to the value
+	FieldNotEqualsOperator = FieldComparisonOperator("NotEquals")
+
+	// FieldExistsOperator succeeds when the field exists
+	FieldExistsOperator = FieldComparisonOperator("Exists")
+)
+
+// FieldComparisonCondition is a type of readiness provider condition that compares
+// a field of the given resource with a value
+type FieldComparisonCondition struct {
+	ResourceReference `json:",inline"`
+
+	// JSONPath is the JSONPath expression that selects the field to compare, e.g. {.status.phase}
+	// More info: https://kubernetes.io/docs/reference/kubectl/jsonpath/
+	JSONPath string `json:"jsonPath"`
+
+	// Operator is the operator used for the comparison
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Enum=Equals;NotEquals;Exists
+	//+kubebuilder:default=Equals
+	Operator FieldComparisonOperator `json:"operator"`
+
+	// Value is the value that the field is compared with. It is ignored by the Exists operator.
+	//+kubebuilder:validation:Optional
+	Value string `json:"value"`
+}
+
+// RolloutCondition is a type of readiness provider condition that checks if the rollout
+// of the given Deployment or StatefulSet is complete
+type RolloutCondition struct {
+	// Kind is the kind of the workload
+	//+kubebuilder:validation:Enum=Deployment;StatefulSet
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the workload
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the workload
+	Name string `json:"name"`
+}
+
+// ServiceProbeProtocol is the protocol used for probing a service
+type ServiceProbeProtocol string
+
+const (
+	// TCPServiceProbe succeeds when a TCP connection can be established
+	TCPServiceProbe = ServiceProbeProtocol("TCP")
+
+	// HTTPServiceProbe succeeds when an HTTP GET request returns a status code in the range [200, 400)
+	HTTPServiceProbe = ServiceProbeProtocol("HTTP")
+
+	// HTTPSServiceProbe succeeds when an HTTPS GET request returns a status code in the range [200, 400).
+	// The certificate presented by the service is not verified.
+	HTTPSServiceProbe = ServiceProbeProtocol("HTTPS")
+)
+
+// ServiceProbeCondition is a type of readiness provider condition that probes the given Service in the cluster
+type ServiceProbeCondition struct {
+	// Namespace is the namespace of the Service
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the Service
+	Name string `json:"name"`
+
+	// Port is the port of the Service that is probed
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Protocol is the protocol used for the probe
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Enum=TCP;HTTP;HTTPS
+	//+kubebuilder:default=TCP
+	Protocol ServiceProbeProtocol `json:"protocol"`
+
+	// Path is the HTTP path that is requested. It is ignored by TCP probes.
+	//+kubebuilder:validation:Optional
+	Path string `json:"path"`
+
+	// TimeoutSeconds is the number of seconds after which the probe times out
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:default=5
+	TimeoutSeconds int32 `json:"timeoutSeconds"`
+}
+
+// CapabilityCondition is a type of readiness provider condition that checks the results of the given Capability
+type CapabilityCondition struct {
+	// Namespace is the namespace of the Capability
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the Capability
+	Name string `json:"name"`
+
+	// QueryName is the name of the query in the Capability whose results are checked.
+	// If not provided, the results of all the queries are checked.
+	//+kubebuilder:validation:Optional
+	QueryName string `json:"queryName,omitempty"`
+}
+
 // ReadinessProviderStatus defines the observed state of ReadinessProvider
 type ReadinessProviderStatus struct {
 	// State is the computed state of the provider. The state will be success if all the conditions pass;
@@ -101,6 +353,14 @@ type ReadinessProviderStatus struct {
 
 	// Conditions is the set of ReadinessConditions that are being evaluated
 	Conditions []ReadinessConditionStatus `json:"conditions"`
+
+	// StatusConditions contains the latest observations of the provider state.
+	// The Ready condition is true when the state is success, false when the state is failure and unknown otherwise.
+	// These are kept apart from Conditions, which holds the results of the ReadinessConditions.
+	//+kubebuilder:validation:Optional
+	//+listType=map
+	//+listMapKey=type
+	StatusConditions []metav1.Condition `json:"statusConditions,omitempty"`
 }
 
 type ReadinessConditionStatus struct {
@@ -113,6 +373,14 @@ type ReadinessConditionStatus struct {
 
 	// Message is the field that provides information about the condition evaluation
 	Message string `json:"message"`
+
+	// Reason is a programmatic identifier of the cause of the state, like ProgressDeadlineExceeded
+	//+kubebuilder:validation:Optional
+	Reason string `json:"reason,omitempty"`
+
+	// LastTransitionTime is the last time the state of the condition changed
+	//+kubebuilder:validation:Optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
 }
 
 //+kubebuilder:object:root=true
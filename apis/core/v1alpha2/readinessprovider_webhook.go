@@ -8,9 +8,14 @@ import (
 	"fmt"
 	"reflect"
 
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -125,14 +130,34 @@ func (r *ReadinessProvider) validateObject(ctx context.Context, k8sClient client
 		}
 	}
 
+	if r.Spec.ReadinessSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(r.Spec.ReadinessSelector); err != nil {
+			allErrors = append(allErrors, field.Invalid(specPath.Child("readinessSelector"), r.Spec.ReadinessSelector, err.Error()))
+		}
+	}
+
+	// The service account is only impersonated for access reviews once it is known to be valid
+	var reviewedServiceAccount *ServiceAccountRef
+	if saRef != nil && len(allErrors) == 0 {
+		reviewedServiceAccount = saRef
+	}
+
 	// Validate conditions
-	for _, condition := range r.Spec.Conditions {
-		if condition.ResourceExistenceCondition == nil {
+	for i := range r.Spec.Conditions {
+		condition := &r.Spec.Conditions[i]
+		if len(condition.DefinedTypes()) != 1 {
 			allErrors = append(
 				allErrors,
 				field.Invalid(
 					specPath.Child("conditions"),
 					r.Spec.Conditions, fmt.Sprintf("Expected condition %s to have exactly one type defined", condition.Name)))
+			continue
+		}
+
+		targets, errs := conditionTargets(specPath.Child("conditions").Index(i), condition)
+		allErrors = append(allErrors, errs...)
+		for _, target := range targets {
+			allErrors = append(allErrors, validateConditionTarget(ctx, k8sClient, target, reviewedServiceAccount)...)
 		}
 	}
 
@@ -142,3 +167,168 @@ func (r *ReadinessProvider) validateObject(ctx context.Context, k8sClient client
 
 	return apierrors.NewInvalid(GroupVersion.WithKind("ReadinessProvider").GroupKind(), r.Name, allErrors)
 }
+
+// conditionTarget is a resource that is read when a condition is evaluated
+type conditionTarget struct {
+	path      *field.Path
+	gvk       schema.GroupVersionKind
+	namespace *string
+	name      string
+}
+
+// conditionTargets returns the resources read by the condition
+func conditionTargets(path *field.Path, condition *ReadinessProviderCondition) ([]conditionTarget, field.ErrorList) {
+	switch {
+	case condition.ResourceExistenceCondition != nil:
+		c := condition.ResourceExistenceCondition
+		return referenceTarget(path.Child("resourceExistenceCondition"), c.APIVersion, c.Kind, c.Namespace, c.Name)
+	case condition.ResourceStatusCondition != nil:
+		c := condition.ResourceStatusCondition
+		return referenceTarget(path.Child("resourceStatusCondition"), c.APIVersion, c.Kind, c.Namespace, c.Name)
+	case condition.FieldComparisonCondition != nil:
+		c := condition.FieldComparisonCondition
+		return referenceTarget(path.Child("fieldComparisonCondition"), c.APIVersion, c.Kind, c.Namespace, c.Name)
+	case condition.RolloutCondition != nil:
+		c := condition.RolloutCondition
+		return namespacedTarget(path.Child("rolloutCondition"), appsv1.SchemeGroupVersion.WithKind(c.Kind), c.Namespace, c.Name), nil
+	case condition.ServiceProbeCondition != nil:
+		c := condition.ServiceProbeCondition
+		return namespacedTarget(path.Child("serviceProbeCondition"), corev1.SchemeGroupVersion.WithKind("Service"), c.Namespace, c.Name), nil
+	case condition.CapabilityCondition != nil:
+		c := condition.CapabilityCondition
+		return namespacedTarget(path.Child("capabilityCondition"), GroupVersion.WithKind("Capability"), c.Namespace, c.Name), nil
+	case condition.QueryCondition != nil:
+		targets, allErrors := queryObjectTargets(path.Child("queryCondition"), condition.QueryCondition)
+		return targets, append(allErrors, validateQueryNames(path.Child("queryCondition"), condition.QueryCondition)...)
+	}
+	return nil, nil
+}
+
+// queryObjectTargets returns the objects read by the object queries of a query condition. GVR and partial schema
+// queries only read discovery information, which every service account can read.
+func queryObjectTargets(path *field.Path, query *Query) ([]conditionTarget, field.ErrorList) {
+	var targets []conditionTarget
+	var allErrors field.ErrorList
+
+	for i := range query.Objects {
+		ref := &query.Objects[i].ObjectReference
+		refPath := path.Child("objects").Index(i).Child("objectReference")
+
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			allErrors = append(allErrors, field.Invalid(refPath.Child("apiVersion"), ref.APIVersion, err.Error()))
+			continue
+		}
+		if ref.Kind == "" {
+			allErrors = append(allErrors, field.Required(refPath.Child("kind"), "missing required field"))
+			continue
+		}
+		targets = append(targets, namespacedTarget(refPath, gv.WithKind(ref.Kind), ref.Namespace, ref.Name)...)
+	}
+	return targets, allErrors
+}
+
+// validateQueryNames checks that the names of the queries are unique across the query types,
+// since the results of the queries are reported by name
+func validateQueryNames(path *field.Path, query *Query) field.ErrorList {
+	var allErrors field.ErrorList
+	names := make(map[string]struct{})
+	check := func(path *field.Path, name string) {
+		if _, ok := names[name]; ok {
+			allErrors = append(allErrors, field.Duplicate(path.Child("name"), name))
+		}
+		names[name] = struct{}{}
+	}
+
+	for i := range query.GroupVersionResources {
+		check(path.Child("groupVersionResources").Index(i), query.GroupVersionResources[i].Name)
+	}
+	for i := range query.Objects {
+		check(path.Child("objects").Index(i), query.Objects[i].Name)
+	}
+	for i := range query.PartialSchemas {
+		check(path.Child("partialSchemas").Index(i), query.PartialSchemas[i].Name)
+	}
+	if len(names) == 0 {
+		allErrors = append(allErrors, field.Required(path, "at least one query must be defined"))
+	}
+	return allErrors
+}
+
+// referenceTarget returns the target of a condition that references a resource of any kind
+func referenceTarget(path *field.Path, apiVersion, kind string, namespace *string, name string) ([]conditionTarget, field.ErrorList) {
+	var allErrors field.ErrorList
+
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		allErrors = append(allErrors, field.Invalid(path.Child("apiVersion"), apiVersion, err.Error()))
+	}
+	if kind == "" {
+		allErrors = append(allErrors, field.Required(path.Child("kind"), "missing required field"))
+	}
+	if namespace != nil && *namespace == "" {
+		allErrors = append(allErrors, field.Invalid(path.Child("namespace"), *namespace, "namespace must be omitted for cluster scoped resources"))
+	}
+	if len(allErrors) != 0 {
+		return nil, allErrors
+	}
+
+	return []conditionTarget{{path: path, gvk: gv.WithKind(kind), namespace: namespace, name: name}}, nil
+}
+
+// namespacedTarget returns the target of a condition that references a resource of a fixed kind
+func namespacedTarget(path *field.Path, gvk schema.GroupVersionKind, namespace, name string) []conditionTarget {
+	target := conditionTarget{path: path, gvk: gvk, name: name}
+	if namespace != "" {
+		target.namespace = &namespace
+	}
+	return []conditionTarget{target}
+}
+
+// validateConditionTarget checks that the kind of the target is served by the cluster, that the namespace
+// matches the scope of the kind and, if a service account is given, that it is allowed to get the target
+func validateConditionTarget(ctx context.Context, k8sClient client.Client, target conditionTarget, saRef *ServiceAccountRef) field.ErrorList {
+	mapping, err := k8sClient.RESTMapper().RESTMapping(target.gvk.GroupKind(), target.gvk.Version)
+	if err != nil {
+		return field.ErrorList{field.Invalid(target.path.Child("kind"), target.gvk.Kind, fmt.Sprintf("unable to resolve %s: %s", target.gvk, err.Error()))}
+	}
+
+	namespaced := mapping.Scope.Name() == meta.RESTScopeNameNamespace
+	if namespaced && target.namespace == nil {
+		return field.ErrorList{field.Required(target.path.Child("namespace"), fmt.Sprintf("%s is namespaced", target.gvk.Kind))}
+	}
+	if !namespaced && target.namespace != nil {
+		return field.ErrorList{field.Invalid(target.path.Child("namespace"), *target.namespace, fmt.Sprintf("%s is cluster scoped", target.gvk.Kind))}
+	}
+
+	if saRef == nil {
+		return nil
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   fmt.Sprintf("system:serviceaccount:%s:%s", saRef.Namespace, saRef.Name),
+			Groups: []string{"system:serviceaccounts", "system:serviceaccounts:" + saRef.Namespace, "system:authenticated"},
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Verb:     "get",
+				Group:    mapping.Resource.Group,
+				Version:  mapping.Resource.Version,
+				Resource: mapping.Resource.Resource,
+				Name:     target.name,
+			},
+		},
+	}
+	if target.namespace != nil {
+		review.Spec.ResourceAttributes.Namespace = *target.namespace
+	}
+
+	readinessproviderlog.Info("checking if service account can get the condition target", "source", saRef, "resource", mapping.Resource, "name", target.name)
+	if err := k8sClient.Create(ctx, review); err != nil {
+		return field.ErrorList{field.InternalError(target.path, err)}
+	}
+	if !review.Status.Allowed {
+		return field.ErrorList{field.Forbidden(target.path,
+			fmt.Sprintf("service account %s/%s is not allowed to get %s %s", saRef.Namespace, saRef.Name, mapping.Resource.String(), target.name))}
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// accessReviewClient answers SubjectAccessReviews with the configured set of allowed resources
+type accessReviewClient struct {
+	client.Client
+	allowed map[string]bool
+}
+
+func (c *accessReviewClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if review, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+		review.Status.Allowed = c.allowed[review.Spec.User+" "+review.Spec.ResourceAttributes.Resource]
+		return nil
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func TestValidateConditionTargets(t *testing.T) {
+	s, err := getScheme()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Service"), meta.RESTScopeNamespace)
+	mapper.Add(appsv1.SchemeGroupVersion.WithKind("Deployment"), meta.RESTScopeNamespace)
+
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "reader", Namespace: "default"}}
+	k8sClient := &accessReviewClient{
+		Client:  fake.NewClientBuilder().WithScheme(s).WithRESTMapper(mapper).WithObjects(sa).Build(),
+		allowed: map[string]bool{"system:serviceaccount:default:reader configmaps": true},
+	}
+
+	defaultNamespace := "default"
+	emptyNamespace := ""
+	configMap := func(namespace *string) ReadinessProviderCondition {
+		return ReadinessProviderCondition{
+			Name:                       "cond1",
+			ResourceExistenceCondition: &ResourceExistenceCondition{APIVersion: "v1", Kind: "ConfigMap", Namespace: namespace, Name: "cm"},
+		}
+	}
+	reader := &ServiceAccountRef{Namespace: "default", Name: "reader"}
+
+	testCases := []struct {
+		description    string
+		condition      ReadinessProviderCondition
+		serviceAccount *ServiceAccountRef
+		wantErrors     int
+	}{
+		{
+			description: "Namespaced resource with a namespace",
+			condition:   configMap(&defaultNamespace),
+			wantErrors:  0,
+		},
+		{
+			description: "Namespaced resource without a namespace",
+			condition:   configMap(nil),
+			wantErrors:  1,
+		},
+		{
+			description: "Empty namespace",
+			condition:   configMap(&emptyNamespace),
+			wantErrors:  1,
+		},
+		{
+			description: "Cluster scoped resource with a namespace",
+			condition: ReadinessProviderCondition{
+				Name:                       "cond1",
+				ResourceExistenceCondition: &ResourceExistenceCondition{APIVersion: "v1", Kind: "Namespace", Namespace: &defaultNamespace, Name: "default"},
+			},
+			wantErrors: 1,
+		},
+		{
+			description: "Kind that does not resolve",
+			condition: ReadinessProviderCondition{
+				Name:                       "cond1",
+				ResourceExistenceCondition: &ResourceExistenceCondition{APIVersion: "example.com/v1", Kind: "Widget", Name: "w"},
+			},
+			wantErrors: 1,
+		},
+		{
+			description: "Invalid API version",
+			condition: ReadinessProviderCondition{
+				Name: "cond1",
+				ResourceStatusCondition: &ResourceStatusCondition{
+					ResourceReference: ResourceReference{APIVersion: "a/b/c", Kind: "ConfigMap", Namespace: &defaultNamespace, Name: "cm"},
+				},
+			},
+			wantErrors: 1,
+		},
+		{
+			description: "Rollout without a namespace",
+			condition: ReadinessProviderCondition{
+				Name:             "cond1",
+				RolloutCondition: &RolloutCondition{Kind: "Deployment", Name: "app"},
+			},
+			wantErrors: 1,
+		},
+		{
+			description: "Query with unique names",
+			condition: ReadinessProviderCondition{
+				Name: "cond1",
+				QueryCondition: &Query{
+					Name:                  "query",
+					GroupVersionResources: []QueryGVR{{Name: "deployments", Group: "apps", Versions: []string{"v1"}, Resource: "deployments"}},
+					PartialSchemas:        []QueryPartialSchema{{Name: "schema", PartialSchema: "schema"}},
+				},
+			},
+			wantErrors: 0,
+		},
+		{
+			description: "Query with duplicate names",
+			condition: ReadinessProviderCondition{
+				Name: "cond1",
+				QueryCondition: &Query{
+					Name:                  "query",
+					GroupVersionResources: []QueryGVR{{Name: "q", Group: "apps", Versions: []string{"v1"}, Resource: "deployments"}},
+					PartialSchemas:        []QueryPartialSchema{{Name: "q", PartialSchema: "schema"}},
+				},
+			},
+			wantErrors: 1,
+		},
+		{
+			description: "Query without queries",
+			condition: ReadinessProviderCondition{
+				Name:           "cond1",
+				QueryCondition: &Query{Name: "query"},
+			},
+			wantErrors: 1,
+		},
+		{
+			description: "Query object without a kind",
+			condition: ReadinessProviderCondition{
+				Name: "cond1",
+				QueryCondition: &Query{
+					Name:    "query",
+					Objects: []QueryObject{{Name: "cm", ObjectReference: corev1.ObjectReference{APIVersion: "v1", Namespace: "default", Name: "cm"}}},
+				},
+			},
+			wantErrors: 1,
+		},
+		{
+			description: "Service account allowed to get the query objects",
+			condition: ReadinessProviderCondition{
+				Name: "cond1",
+				QueryCondition: &Query{
+					Name:    "query",
+					Objects: []QueryObject{{Name: "cm", ObjectReference: corev1.ObjectReference{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "cm"}}},
+				},
+			},
+			serviceAccount: reader,
+			wantErrors:     0,
+		},
+		{
+			description: "Service account not allowed to get a query object",
+			condition: ReadinessProviderCondition{
+				Name: "cond1",
+				QueryCondition: &Query{
+					Name: "query",
+					Objects: []QueryObject{
+						{Name: "cm", ObjectReference: corev1.ObjectReference{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "cm"}},
+						{Name: "app", ObjectReference: corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "app"}},
+					},
+				},
+			},
+			serviceAccount: reader,
+			wantErrors:     1,
+		},
+		{
+			description:    "Service account allowed to get the target",
+			condition:      configMap(&defaultNamespace),
+			serviceAccount: reader,
+			wantErrors:     0,
+		},
+		{
+			description: "Service account not allowed to get the target",
+			condition: ReadinessProviderCondition{
+				Name:                  "cond1",
+				ServiceProbeCondition: &ServiceProbeCondition{Namespace: "default", Name: "svc", Port: 80},
+			},
+			serviceAccount: reader,
+			wantErrors:     1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			provider := &ReadinessProvider{
+				ObjectMeta: metav1.ObjectMeta{Name: "provider"},
+				Spec: ReadinessProviderSpec{
+					Conditions:        []ReadinessProviderCondition{tc.condition},
+					ServiceAccountRef: tc.serviceAccount,
+				},
+			}
+
+			err := provider.validateObject(context.Background(), k8sClient)
+			gotErrors := 0
+			if err != nil {
+				statusErr, ok := err.(*apierrors.StatusError)
+				if !ok {
+					t.Fatalf("expected a status error, got %v", err)
+				}
+				gotErrors = len(statusErr.ErrStatus.Details.Causes)
+			}
+			if gotErrors != tc.wantErrors {
+				t.Errorf("expected %d errors, got %d: %v", tc.wantErrors, gotErrors, err)
+			}
+		})
+	}
+}
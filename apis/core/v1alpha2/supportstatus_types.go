@@ -0,0 +1,57 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SupportStatusName is the name of the SupportStatus resource of the environment
+const SupportStatusName = "tanzu-support-status"
+
+// WarrantyVoidRecord records a feature whose activation permanently voided all support guarantees for the environment.
+type WarrantyVoidRecord struct {
+	// Feature is the name of the feature that voided the support warranty.
+	Feature string `json:"feature"`
+	// FeatureGate is the name of the FeatureGate that voided the support warranty.
+	FeatureGate string `json:"featureGate"`
+	// Actor is the user that voided the support warranty.
+	// +optional
+	Actor string `json:"actor,omitempty"`
+	// Time is when the support warranty was voided.
+	Time metav1.Time `json:"time"`
+}
+
+// SupportStatusSpec defines the support status of the environment
+type SupportStatusSpec struct {
+	// WarrantyVoids lists the features that permanently voided all support guarantees for the environment. Records
+	// can only be appended.
+	// +optional
+	WarrantyVoids []WarrantyVoidRecord `json:"warrantyVoids,omitempty"`
+}
+
+// SupportStatus is the Schema for the supportstatuses API.
+// It durably records whether the support guarantees of the environment were voided. It is written by the ActivationHistory
+// controller from the persisted FeatureGates and cannot be deleted.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
+type SupportStatus struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              SupportStatusSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SupportStatusList contains a list of SupportStatus
+type SupportStatusList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SupportStatus `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SupportStatus{}, &SupportStatusList{})
+}
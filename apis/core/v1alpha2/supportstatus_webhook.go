@@ -0,0 +1,77 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"fmt"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var supportstatuslog = logf.Log.WithName("supportstatus-resource").WithValues("apigroup", "core")
+
+// SetupWebhookWithManager adds the webhook to the manager.
+func (r *SupportStatus) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:verbs=create;update;delete,path=/validate-core-tanzu-vmware-com-v1alpha2-supportstatus,mutating=false,failurePolicy=fail,groups=core.tanzu.vmware.com,resources=supportstatuses,versions=v1alpha2,name=vsupportstatus.kb.io
+
+var _ webhook.Validator = &SupportStatus{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *SupportStatus) ValidateCreate() error {
+	supportstatuslog.Info("validate create", "name", r.Name)
+
+	if r.Name != SupportStatusName {
+		return apierrors.NewInvalid(GroupVersion.WithKind("SupportStatus").GroupKind(), r.Name, field.ErrorList{
+			field.Invalid(field.NewPath("metadata").Child("name"), r.Name, fmt.Sprintf("the SupportStatus of the environment must be named %s", SupportStatusName)),
+		})
+	}
+	return nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *SupportStatus) ValidateUpdate(old runtime.Object) error {
+	supportstatuslog.Info("validate update", "name", r.Name)
+
+	oldObj, ok := old.(*SupportStatus)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected SupportStatus object, but got object of type %T", old))
+	}
+	if oldObj == nil {
+		return nil
+	}
+
+	if !isWarrantyVoidsAppendOnly(oldObj.Spec.WarrantyVoids, r.Spec.WarrantyVoids) {
+		return apierrors.NewInvalid(GroupVersion.WithKind("SupportStatus").GroupKind(), r.Name, field.ErrorList{
+			field.Forbidden(field.NewPath("spec").Child("warrantyVoids"), "records of voided support warranties can only be appended"),
+		})
+	}
+	return nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *SupportStatus) ValidateDelete() error {
+	supportstatuslog.Info("validate delete", "name", r.Name)
+	return apierrors.NewForbidden(GroupVersion.WithResource("supportstatuses").GroupResource(), r.Name,
+		fmt.Errorf("the SupportStatus of the environment cannot be deleted"))
+}
+
+// isWarrantyVoidsAppendOnly returns true if the new records keep all the old records, in order
+func isWarrantyVoidsAppendOnly(oldRecords, newRecords []WarrantyVoidRecord) bool {
+	if len(newRecords) < len(oldRecords) {
+		return false
+	}
+	return apiequality.Semantic.DeepEqual(oldRecords, newRecords[:len(oldRecords)])
+}
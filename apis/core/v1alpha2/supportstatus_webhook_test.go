@@ -0,0 +1,64 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateSupportStatus(t *testing.T) {
+	now := metav1.NewTime(time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC))
+	foo := WarrantyVoidRecord{Feature: "foo", FeatureGate: "tkg-system", Actor: "admin", Time: now}
+	bar := WarrantyVoidRecord{Feature: "bar", FeatureGate: "tkg-system", Actor: "admin", Time: now}
+	supportStatus := func(name string, records ...WarrantyVoidRecord) *SupportStatus {
+		return &SupportStatus{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: SupportStatusSpec{WarrantyVoids: records}}
+	}
+
+	if err := supportStatus(SupportStatusName).ValidateCreate(); err != nil {
+		t.Errorf("expected create to be allowed, got: %v", err)
+	}
+	if err := supportStatus("other").ValidateCreate(); err == nil {
+		t.Errorf("expected create of a SupportStatus not named %s to be denied", SupportStatusName)
+	}
+	if err := supportStatus(SupportStatusName, foo).ValidateDelete(); err == nil {
+		t.Errorf("expected delete to be denied")
+	}
+
+	testCases := []struct {
+		description string
+		oldObject   *SupportStatus
+		newObject   *SupportStatus
+		wantErr     bool
+	}{
+		{
+			description: "Record appended",
+			oldObject:   supportStatus(SupportStatusName, foo),
+			newObject:   supportStatus(SupportStatusName, foo, bar),
+		},
+		{
+			description: "Record removed",
+			oldObject:   supportStatus(SupportStatusName, foo, bar),
+			newObject:   supportStatus(SupportStatusName, bar),
+			wantErr:     true,
+		},
+		{
+			description: "Record changed",
+			oldObject:   supportStatus(SupportStatusName, foo),
+			newObject:   supportStatus(SupportStatusName, WarrantyVoidRecord{Feature: "foo", FeatureGate: "tkg-system", Actor: "someone-else", Time: now}),
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.newObject.ValidateUpdate(tc.oldObject)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("got error %v, want error: %t", err, tc.wantErr)
+			}
+		})
+	}
+}
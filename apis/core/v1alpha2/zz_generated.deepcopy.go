@@ -9,9 +9,35 @@
 package v1alpha2
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AggregationPolicy) DeepCopyInto(out *AggregationPolicy) {
+	*out = *in
+	if in.MinCount != nil {
+		in, out := &in.MinCount, &out.MinCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AggregationPolicy.
+func (in *AggregationPolicy) DeepCopy() *AggregationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AggregationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Capability) DeepCopyInto(out *Capability) {
 	*out = *in
@@ -39,6 +65,21 @@ func (in *Capability) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapabilityCondition) DeepCopyInto(out *CapabilityCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapabilityCondition.
+func (in *CapabilityCondition) DeepCopy() *CapabilityCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(CapabilityCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CapabilityList) DeepCopyInto(out *CapabilityList) {
 	*out = *in
@@ -118,6 +159,26 @@ func (in *CapabilityStatus) DeepCopy() *CapabilityStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Check) DeepCopyInto(out *Check) {
 	*out = *in
+	if in.CheckRefs != nil {
+		in, out := &in.CheckRefs, &out.CheckRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProviderSelector != nil {
+		in, out := &in.ProviderSelector, &out.ProviderSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Aggregation != nil {
+		in, out := &in.Aggregation, &out.Aggregation
+		*out = new(AggregationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Check.
@@ -136,8 +197,26 @@ func (in *CheckStatus) DeepCopyInto(out *CheckStatus) {
 	if in.Providers != nil {
 		in, out := &in.Providers, &out.Providers
 		*out = make([]Provider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockingChecks != nil {
+		in, out := &in.BlockingChecks, &out.BlockingChecks
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]CheckTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckStatus.
@@ -150,12 +229,166 @@ func (in *CheckStatus) DeepCopy() *CheckStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckTransition) DeepCopyInto(out *CheckTransition) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckTransition.
+func (in *CheckTransition) DeepCopy() *CheckTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCheckStatus) DeepCopyInto(out *ClusterCheckStatus) {
+	*out = *in
+	if in.NotReadyClusters != nil {
+		in, out := &in.NotReadyClusters, &out.NotReadyClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCheckStatus.
+func (in *ClusterCheckStatus) DeepCopy() *ClusterCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReadiness) DeepCopyInto(out *ClusterReadiness) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReadiness.
+func (in *ClusterReadiness) DeepCopy() *ClusterReadiness {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReadiness)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterReadiness) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReadinessList) DeepCopyInto(out *ClusterReadinessList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterReadiness, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReadinessList.
+func (in *ClusterReadinessList) DeepCopy() *ClusterReadinessList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReadinessList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterReadinessList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReadinessSpec) DeepCopyInto(out *ClusterReadinessSpec) {
+	*out = *in
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResyncInterval != nil {
+		in, out := &in.ResyncInterval, &out.ResyncInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReadinessSpec.
+func (in *ClusterReadinessSpec) DeepCopy() *ClusterReadinessSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReadinessSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReadinessStatus) DeepCopyInto(out *ClusterReadinessStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]WorkloadClusterReadiness, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]ClusterCheckStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReadinessStatus.
+func (in *ClusterReadinessStatus) DeepCopy() *ClusterReadinessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReadinessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Feature) DeepCopyInto(out *Feature) {
 	*out = *in
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.TypeMeta = in.TypeMeta
 }
 
@@ -177,6 +410,22 @@ func (in *Feature) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureActivationChange) DeepCopyInto(out *FeatureActivationChange) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureActivationChange.
+func (in *FeatureActivationChange) DeepCopy() *FeatureActivationChange {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureActivationChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureGate) DeepCopyInto(out *FeatureGate) {
 	*out = *in
@@ -242,7 +491,9 @@ func (in *FeatureGateSpec) DeepCopyInto(out *FeatureGateSpec) {
 	if in.Features != nil {
 		in, out := &in.Features, &out.Features
 		*out = make([]FeatureReference, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -264,6 +515,13 @@ func (in *FeatureGateStatus) DeepCopyInto(out *FeatureGateStatus) {
 		*out = make([]FeatureReferenceResult, len(*in))
 		copy(*out, *in)
 	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]FeatureActivationChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureGateStatus.
@@ -276,6 +534,21 @@ func (in *FeatureGateStatus) DeepCopy() *FeatureGateStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureLink) DeepCopyInto(out *FeatureLink) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureLink.
+func (in *FeatureLink) DeepCopy() *FeatureLink {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureLink)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureList) DeepCopyInto(out *FeatureList) {
 	*out = *in
@@ -308,9 +581,94 @@ func (in *FeatureList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeaturePolicy) DeepCopyInto(out *FeaturePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeaturePolicy.
+func (in *FeaturePolicy) DeepCopy() *FeaturePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FeaturePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FeaturePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeaturePolicyList) DeepCopyInto(out *FeaturePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FeaturePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeaturePolicyList.
+func (in *FeaturePolicyList) DeepCopy() *FeaturePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(FeaturePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FeaturePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeaturePolicySpec) DeepCopyInto(out *FeaturePolicySpec) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]StabilityLevelPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeaturePolicySpec.
+func (in *FeaturePolicySpec) DeepCopy() *FeaturePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FeaturePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureReference) DeepCopyInto(out *FeatureReference) {
 	*out = *in
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(FeatureRollout)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureReference.
@@ -338,9 +696,58 @@ func (in *FeatureReferenceResult) DeepCopy() *FeatureReferenceResult {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureRollout) DeepCopyInto(out *FeatureRollout) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureRollout.
+func (in *FeatureRollout) DeepCopy() *FeatureRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureSpec) DeepCopyInto(out *FeatureSpec) {
 	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConflictsWith != nil {
+		in, out := &in.ConflictsWith, &out.ConflictsWith
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemovalDate != nil {
+		in, out := &in.RemovalDate, &out.RemovalDate
+		*out = (*in).DeepCopy()
+	}
+	if in.Links != nil {
+		in, out := &in.Links, &out.Links
+		*out = make([]FeatureLink, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureSpec.
@@ -356,6 +763,32 @@ func (in *FeatureSpec) DeepCopy() *FeatureSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureStatus) DeepCopyInto(out *FeatureStatus) {
 	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FeatureGatePriority != nil {
+		in, out := &in.FeatureGatePriority, &out.FeatureGatePriority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.OverriddenFeatureGates != nil {
+		in, out := &in.OverriddenFeatureGates, &out.OverriddenFeatureGates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureStatus.
@@ -368,6 +801,22 @@ func (in *FeatureStatus) DeepCopy() *FeatureStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldComparisonCondition) DeepCopyInto(out *FieldComparisonCondition) {
+	*out = *in
+	in.ResourceReference.DeepCopyInto(&out.ResourceReference)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldComparisonCondition.
+func (in *FieldComparisonCondition) DeepCopy() *FieldComparisonCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldComparisonCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Policy) DeepCopyInto(out *Policy) {
 	*out = *in
@@ -386,6 +835,11 @@ func (in *Policy) DeepCopy() *Policy {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Provider) DeepCopyInto(out *Provider) {
 	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Provider.
@@ -542,6 +996,10 @@ func (in *Readiness) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReadinessConditionStatus) DeepCopyInto(out *ReadinessConditionStatus) {
 	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessConditionStatus.
@@ -621,6 +1079,41 @@ func (in *ReadinessProviderCondition) DeepCopyInto(out *ReadinessProviderConditi
 		*out = new(ResourceExistenceCondition)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ResourceStatusCondition != nil {
+		in, out := &in.ResourceStatusCondition, &out.ResourceStatusCondition
+		*out = new(ResourceStatusCondition)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FieldComparisonCondition != nil {
+		in, out := &in.FieldComparisonCondition, &out.FieldComparisonCondition
+		*out = new(FieldComparisonCondition)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RolloutCondition != nil {
+		in, out := &in.RolloutCondition, &out.RolloutCondition
+		*out = new(RolloutCondition)
+		**out = **in
+	}
+	if in.ServiceProbeCondition != nil {
+		in, out := &in.ServiceProbeCondition, &out.ServiceProbeCondition
+		*out = new(ServiceProbeCondition)
+		**out = **in
+	}
+	if in.CapabilityCondition != nil {
+		in, out := &in.CapabilityCondition, &out.CapabilityCondition
+		*out = new(CapabilityCondition)
+		**out = **in
+	}
+	if in.QueryCondition != nil {
+		in, out := &in.QueryCondition, &out.QueryCondition
+		*out = new(Query)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessProviderCondition.
@@ -673,6 +1166,16 @@ func (in *ReadinessProviderSpec) DeepCopyInto(out *ReadinessProviderSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReadinessSelector != nil {
+		in, out := &in.ReadinessSelector, &out.ReadinessSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]ReadinessProviderCondition, len(*in))
@@ -685,6 +1188,11 @@ func (in *ReadinessProviderSpec) DeepCopyInto(out *ReadinessProviderSpec) {
 		*out = new(ServiceAccountRef)
 		**out = **in
 	}
+	if in.ResyncInterval != nil {
+		in, out := &in.ResyncInterval, &out.ResyncInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessProviderSpec.
@@ -703,7 +1211,16 @@ func (in *ReadinessProviderStatus) DeepCopyInto(out *ReadinessProviderStatus) {
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]ReadinessConditionStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StatusConditions != nil {
+		in, out := &in.StatusConditions, &out.StatusConditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -723,7 +1240,9 @@ func (in *ReadinessSpec) DeepCopyInto(out *ReadinessSpec) {
 	if in.Checks != nil {
 		in, out := &in.Checks, &out.Checks
 		*out = make([]Check, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -747,6 +1266,13 @@ func (in *ReadinessStatus) DeepCopyInto(out *ReadinessStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessStatus.
@@ -779,6 +1305,42 @@ func (in *ResourceExistenceCondition) DeepCopy() *ResourceExistenceCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceReference) DeepCopyInto(out *ResourceReference) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceReference.
+func (in *ResourceReference) DeepCopy() *ResourceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceStatusCondition) DeepCopyInto(out *ResourceStatusCondition) {
+	*out = *in
+	in.ResourceReference.DeepCopyInto(&out.ResourceReference)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceStatusCondition.
+func (in *ResourceStatusCondition) DeepCopy() *ResourceStatusCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceStatusCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Result) DeepCopyInto(out *Result) {
 	*out = *in
@@ -809,6 +1371,21 @@ func (in *Result) DeepCopy() *Result {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutCondition) DeepCopyInto(out *RolloutCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutCondition.
+func (in *RolloutCondition) DeepCopy() *RolloutCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceAccountRef) DeepCopyInto(out *ServiceAccountRef) {
 	*out = *in
@@ -823,3 +1400,173 @@ func (in *ServiceAccountRef) DeepCopy() *ServiceAccountRef {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceProbeCondition) DeepCopyInto(out *ServiceProbeCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceProbeCondition.
+func (in *ServiceProbeCondition) DeepCopy() *ServiceProbeCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceProbeCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StabilityLevelPolicy) DeepCopyInto(out *StabilityLevelPolicy) {
+	*out = *in
+	if in.DefaultActivation != nil {
+		in, out := &in.DefaultActivation, &out.DefaultActivation
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Immutable != nil {
+		in, out := &in.Immutable, &out.Immutable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VoidsWarranty != nil {
+		in, out := &in.VoidsWarranty, &out.VoidsWarranty
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Discoverable != nil {
+		in, out := &in.Discoverable, &out.Discoverable
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StabilityLevelPolicy.
+func (in *StabilityLevelPolicy) DeepCopy() *StabilityLevelPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(StabilityLevelPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SupportStatus) DeepCopyInto(out *SupportStatus) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupportStatus.
+func (in *SupportStatus) DeepCopy() *SupportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SupportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SupportStatus) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SupportStatusList) DeepCopyInto(out *SupportStatusList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SupportStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupportStatusList.
+func (in *SupportStatusList) DeepCopy() *SupportStatusList {
+	if in == nil {
+		return nil
+	}
+	out := new(SupportStatusList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SupportStatusList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SupportStatusSpec) DeepCopyInto(out *SupportStatusSpec) {
+	*out = *in
+	if in.WarrantyVoids != nil {
+		in, out := &in.WarrantyVoids, &out.WarrantyVoids
+		*out = make([]WarrantyVoidRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupportStatusSpec.
+func (in *SupportStatusSpec) DeepCopy() *SupportStatusSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SupportStatusSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarrantyVoidRecord) DeepCopyInto(out *WarrantyVoidRecord) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarrantyVoidRecord.
+func (in *WarrantyVoidRecord) DeepCopy() *WarrantyVoidRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(WarrantyVoidRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadClusterReadiness) DeepCopyInto(out *WorkloadClusterReadiness) {
+	*out = *in
+	if in.NotReadyChecks != nil {
+		in, out := &in.NotReadyChecks, &out.NotReadyChecks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastCollectedTime != nil {
+		in, out := &in.LastCollectedTime, &out.LastCollectedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadClusterReadiness.
+func (in *WorkloadClusterReadiness) DeepCopy() *WorkloadClusterReadiness {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadClusterReadiness)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -124,3 +124,22 @@ func QueryTargetsToCapability(queryTargets []QueryTarget) (*corev1alpha2.Capabil
 
 	return capability, nil
 }
+
+// QueryToQueryTargets is a helper function to generate the slice of QueryTarget
+// that evaluates a Capability v1alpha2 query.
+func QueryToQueryTargets(query *corev1alpha2.Query) []QueryTarget {
+	var queryTargets []QueryTarget
+	for i := range query.GroupVersionResources {
+		q := &query.GroupVersionResources[i]
+		queryTargets = append(queryTargets, Group(q.Name, q.Group).WithVersions(q.Versions...).WithResource(q.Resource))
+	}
+	for i := range query.Objects {
+		q := &query.Objects[i]
+		queryTargets = append(queryTargets, Object(q.Name, &q.ObjectReference).WithAnnotations(q.WithAnnotations).WithoutAnnotations(q.WithoutAnnotations))
+	}
+	for i := range query.PartialSchemas {
+		q := &query.PartialSchemas[i]
+		queryTargets = append(queryTargets, Schema(q.Name, q.PartialSchema))
+	}
+	return queryTargets
+}
@@ -256,3 +256,42 @@ func TestQueryTargetsToCapability(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryToQueryTargets(t *testing.T) {
+	koi := corev1.ObjectReference{
+		Kind:       "Carp",
+		Name:       "koi",
+		Namespace:  "koi-pond",
+		APIVersion: testapigroup.SchemeGroupVersion.String(),
+	}
+	query := &corev1alpha2.Query{
+		Name: "pondQuery",
+		GroupVersionResources: []corev1alpha2.QueryGVR{
+			{Name: "gvr", Group: testapigroup.SchemeGroupVersion.Group, Versions: []string{testapigroup.SchemeGroupVersion.Version}, Resource: "carps"},
+		},
+		Objects: []corev1alpha2.QueryObject{
+			{Name: "object", ObjectReference: koi, WithAnnotations: map[string]string{"cluster.x-k8s.io/provider": "infrastructure-fake"}},
+		},
+		PartialSchemas: []corev1alpha2.QueryPartialSchema{
+			{Name: "schema", PartialSchema: "partial schema"},
+		},
+	}
+
+	queryTargets := QueryToQueryTargets(query)
+	if len(queryTargets) != 3 {
+		t.Fatalf("expected 3 query targets, got %d", len(queryTargets))
+	}
+
+	gvr, ok := queryTargets[0].(*QueryGVR)
+	if !ok || gvr.Name() != "gvr" || gvr.resource.String != "carps" {
+		t.Errorf("unexpected GVR query target: %#v", queryTargets[0])
+	}
+	object, ok := queryTargets[1].(*QueryObject)
+	if !ok || object.Name() != "object" || !reflect.DeepEqual(*object.object, koi) || !reflect.DeepEqual(object.annotationsMap(true), query.Objects[0].WithAnnotations) {
+		t.Errorf("unexpected object query target: %#v", queryTargets[1])
+	}
+	schema, ok := queryTargets[2].(*QueryPartialSchema)
+	if !ok || schema.Name() != "schema" || schema.schema != "partial schema" {
+		t.Errorf("unexpected partial schema query target: %#v", queryTargets[2])
+	}
+}
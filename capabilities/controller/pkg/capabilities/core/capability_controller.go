@@ -80,48 +80,27 @@ func (r *CapabilityReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 // queryGVRs executes GVR queries and returns results.
 func (r *CapabilityReconciler) queryGVRs(log logr.Logger, clusterQueryClient *discovery.ClusterQueryClient, queries []corev1alpha2.QueryGVR) []corev1alpha2.QueryResult {
-	return r.executeQueries(log.WithValues("queryType", "GVR"), clusterQueryClient, func() map[string]discovery.QueryTarget {
-		queryTargets := make(map[string]discovery.QueryTarget)
-		for i := range queries {
-			q := queries[i]
-			query := discovery.Group(q.Name, q.Group).WithVersions(q.Versions...).WithResource(q.Resource)
-			queryTargets[q.Name] = query
-		}
-		return queryTargets
-	})
+	return r.executeQueries(log.WithValues("queryType", "GVR"), clusterQueryClient,
+		discovery.QueryToQueryTargets(&corev1alpha2.Query{GroupVersionResources: queries}))
 }
 
 // queryObjects executes Object queries and returns results.
 func (r *CapabilityReconciler) queryObjects(log logr.Logger, clusterQueryClient *discovery.ClusterQueryClient, queries []corev1alpha2.QueryObject) []corev1alpha2.QueryResult {
-	return r.executeQueries(log.WithValues("queryType", "Object"), clusterQueryClient, func() map[string]discovery.QueryTarget {
-		queryTargets := make(map[string]discovery.QueryTarget)
-		for i := range queries {
-			q := queries[i]
-			query := discovery.Object(q.Name, &q.ObjectReference).WithAnnotations(q.WithAnnotations).WithoutAnnotations(q.WithoutAnnotations)
-			queryTargets[q.Name] = query
-		}
-		return queryTargets
-	})
+	return r.executeQueries(log.WithValues("queryType", "Object"), clusterQueryClient,
+		discovery.QueryToQueryTargets(&corev1alpha2.Query{Objects: queries}))
 }
 
 // queryPartialSchemas executes PartialSchema queries and returns results.
 func (r *CapabilityReconciler) queryPartialSchemas(log logr.Logger, clusterQueryClient *discovery.ClusterQueryClient, queries []corev1alpha2.QueryPartialSchema) []corev1alpha2.QueryResult {
-	return r.executeQueries(log.WithValues("queryType", "PartialSchema"), clusterQueryClient, func() map[string]discovery.QueryTarget {
-		queryTargets := make(map[string]discovery.QueryTarget)
-		for i := range queries {
-			q := queries[i]
-			query := discovery.Schema(q.Name, q.PartialSchema)
-			queryTargets[q.Name] = query
-		}
-		return queryTargets
-	})
+	return r.executeQueries(log.WithValues("queryType", "PartialSchema"), clusterQueryClient,
+		discovery.QueryToQueryTargets(&corev1alpha2.Query{PartialSchemas: queries}))
 }
 
 // executeQueries executes queries using the discovery client and stores results.
-func (r *CapabilityReconciler) executeQueries(log logr.Logger, clusterQueryClient *discovery.ClusterQueryClient, specToQueryTargetFn func() map[string]discovery.QueryTarget) []corev1alpha2.QueryResult {
+func (r *CapabilityReconciler) executeQueries(log logr.Logger, clusterQueryClient *discovery.ClusterQueryClient, queryTargets []discovery.QueryTarget) []corev1alpha2.QueryResult {
 	var results []corev1alpha2.QueryResult
-	queryTargetsMap := specToQueryTargetFn()
-	for name, queryTarget := range queryTargetsMap {
+	for _, queryTarget := range queryTargets {
+		name := queryTarget.Name()
 		result := corev1alpha2.QueryResult{Name: name}
 		c := clusterQueryClient.Query(queryTarget)
 		found, err := c.Execute()
@@ -137,7 +116,7 @@ func (r *CapabilityReconciler) executeQueries(log logr.Logger, clusterQueryClien
 		}
 		results = append(results, result)
 	}
-	log.Info("Executed queries", "num", len(queryTargetsMap))
+	log.Info("Executed queries", "num", len(queryTargets))
 	return results
 }
 
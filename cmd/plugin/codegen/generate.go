@@ -11,6 +11,7 @@ import (
 	"sigs.k8s.io/controller-tools/pkg/markers"
 
 	"github.com/vmware-tanzu/tanzu-framework/cmd/plugin/codegen/generators/feature"
+	"github.com/vmware-tanzu/tanzu-framework/cmd/plugin/codegen/generators/featureflags"
 )
 
 var (
@@ -24,7 +25,8 @@ var (
 
 	// allGenerators maintains the list of all known generators
 	allGenerators = map[string]genall.Generator{
-		"feature": feature.Generator{},
+		"feature":      feature.Generator{},
+		"featureflags": featureflags.Generator{},
 	}
 
 	// allOutputRules defines the list of all known output rules
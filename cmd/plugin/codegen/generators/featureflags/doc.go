@@ -0,0 +1,5 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package featureflags provides feature flags generator
+package featureflags
@@ -0,0 +1,16 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featureflags
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestFeatureFlagsGeneration(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Feature Flags Generation Suite")
+}
@@ -0,0 +1,156 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featureflags
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+
+	"sigs.k8s.io/controller-tools/pkg/genall"
+	"sigs.k8s.io/controller-tools/pkg/loader"
+	"sigs.k8s.io/controller-tools/pkg/markers"
+
+	"github.com/vmware-tanzu/tanzu-framework/cmd/plugin/codegen/generators/feature"
+)
+
+const (
+	outputFile        = "zz_generated.featureflags.go"
+	featureflagsPkg   = "github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featureflags"
+	featureFlagSuffix = "Feature"
+)
+
+// Generator is feature flags generator that generates typed feature handles from feature markers
+type Generator struct{}
+
+// Generate generates the typed feature handles of each package with feature markers.
+func (g Generator) Generate(ctx *genall.GenerationContext) error {
+	for _, root := range ctx.Roots {
+		code, err := generateFeatureFlags(ctx, root)
+		if err != nil {
+			root.AddError(err)
+			continue
+		}
+		if code == nil {
+			continue
+		}
+		writeOut(ctx, root, code)
+	}
+	return nil
+}
+
+// RegisterMarkers registers all markers needed by this Generator
+func (Generator) RegisterMarkers(reg *markers.Registry) error {
+	return feature.Generator{}.RegisterMarkers(reg)
+}
+
+// generateFeatureFlags returns the formatted source declaring a typed handle for each feature of the package, or nil
+// if the package has no feature markers
+func generateFeatureFlags(ctx *genall.GenerationContext, root *loader.Package) ([]byte, error) {
+	rules := map[string]feature.Rule{}
+	if err := markers.EachType(ctx.Collector, root, func(info *markers.TypeInfo) {
+		for _, markerValue := range info.Markers[feature.RuleDefinition.Name] {
+			rule := markerValue.(feature.Rule)
+			rules[rule.Name] = rule
+		}
+	}); err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	identifiers, err := handleNames(names)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by tanzu codegen. DO NOT EDIT.\n\npackage %s\n\n", root.Name)
+	fmt.Fprintf(&out, "import %q\n\n", featureflagsPkg)
+	out.WriteString("const (\n")
+	for _, name := range names {
+		rule := rules[name]
+		identifier := identifiers[name]
+		if rule.Description != "" {
+			fmt.Fprintf(&out, "// %s is the handle of the %s feature: %s\n", identifier, name, rule.Description)
+		} else {
+			fmt.Fprintf(&out, "// %s is the handle of the %s feature\n", identifier, name)
+		}
+		fmt.Fprintf(&out, "%s featureflags.Feature = %q\n", identifier, name)
+	}
+	out.WriteString(")\n")
+
+	return format.Source(out.Bytes())
+}
+
+// handleNames returns the Go identifier of the handle of each feature. Features whose names only differ in their
+// separators or in the case of their first letters, such as foo-bar and foo_bar, would get the same handle, so they
+// are reported as an error.
+func handleNames(featureNames []string) (map[string]string, error) {
+	identifiers := map[string]string{}
+	features := map[string][]string{}
+	for _, name := range featureNames {
+		identifier := handleName(name)
+		identifiers[name] = identifier
+		features[identifier] = append(features[identifier], name)
+	}
+
+	var collisions []string
+	for identifier, names := range features {
+		if len(names) > 1 {
+			sort.Strings(names)
+			collisions = append(collisions, fmt.Sprintf("features %s would all be handled by %s", strings.Join(names, ", "), identifier))
+		}
+	}
+	if len(collisions) > 0 {
+		sort.Strings(collisions)
+		return nil, fmt.Errorf("feature handle name collisions: %s", strings.Join(collisions, "; "))
+	}
+	return identifiers, nil
+}
+
+// handleName returns the Go identifier of the handle of a feature, such as CloudEventListenerFeature for
+// cloud-event-listener
+func handleName(featureName string) string {
+	parts := strings.FieldsFunc(featureName, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var name strings.Builder
+	for _, part := range parts {
+		name.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	identifier := name.String() + featureFlagSuffix
+	if identifier[0] >= '0' && identifier[0] <= '9' {
+		identifier = featureFlagSuffix + identifier
+	}
+	return identifier
+}
+
+// writeOut writes the generated source to the package directory
+func writeOut(ctx *genall.GenerationContext, root *loader.Package, code []byte) {
+	file, err := ctx.Open(root, outputFile)
+	if err != nil {
+		root.AddError(err)
+		return
+	}
+	defer file.Close()
+	n, err := file.Write(code)
+	if err != nil {
+		root.AddError(err)
+		return
+	}
+	if n < len(code) {
+		root.AddError(io.ErrShortWrite)
+	}
+}
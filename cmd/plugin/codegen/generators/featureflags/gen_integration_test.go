@@ -0,0 +1,68 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featureflags
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-tools/pkg/genall"
+	"sigs.k8s.io/controller-tools/pkg/loader"
+	"sigs.k8s.io/controller-tools/pkg/markers"
+
+	"github.com/vmware-tanzu/tanzu-framework/cmd/plugin/codegen/generators/feature"
+)
+
+var _ = Describe("Feature handles generated by the Feature Flags Generator", func() {
+	generate := func(path string) []byte {
+		cwd, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chdir("../feature/fakeData")).To(Succeed())
+		defer func() { Expect(os.Chdir(cwd)).To(Succeed()) }()
+
+		By("loading the roots")
+		pkgs, err := loader.LoadRoots(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pkgs).To(HaveLen(1))
+
+		By("registering Feature rule marker")
+		reg := &markers.Registry{}
+		Expect(reg.Register(feature.RuleDefinition)).To(Succeed())
+
+		By("generating the feature handles")
+		ctx := &genall.GenerationContext{
+			Collector: &markers.Collector{Registry: reg},
+			Roots:     pkgs,
+		}
+		code, err := generateFeatureFlags(ctx, pkgs[0])
+		Expect(err).NotTo(HaveOccurred())
+		return code
+	}
+
+	It("should generate a handle for each feature", func() {
+		expected, err := os.ReadFile("./testdata/zz_generated.featureflags.go.golden")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(generate("./cronjob_types.go"))).To(Equal(string(expected)))
+	})
+
+	It("should not generate any handle", func() {
+		Expect(generate("./memcached_types.go")).To(BeNil())
+	})
+
+	It("should name handles after the features", func() {
+		Expect(handleName("cloud-event-listener")).To(Equal("CloudEventListenerFeature"))
+		Expect(handleName("2fa")).To(Equal("Feature2faFeature"))
+	})
+
+	It("should report features that would get the same handle", func() {
+		identifiers, err := handleNames([]string{"foo-bar", "foo_bar", "fooBar", "baz"})
+		Expect(err).To(MatchError("feature handle name collisions: features foo-bar, fooBar, foo_bar would all be handled by FooBarFeature"))
+		Expect(identifiers).To(BeNil())
+
+		identifiers, err = handleNames([]string{"foo-bar", "baz"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(identifiers).To(Equal(map[string]string{"foo-bar": "FooBarFeature", "baz": "BazFeature"}))
+	})
+})
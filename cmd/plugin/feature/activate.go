@@ -17,6 +17,7 @@ import (
 
 var (
 	userAllowsVoidingWarranty bool
+	activationChangeReason    string
 )
 
 // FeatureActivateCmd is for activating Features
@@ -32,6 +33,7 @@ var FeatureActivateCmd = &cobra.Command{
 
 func init() {
 	FeatureActivateCmd.Flags().BoolVar(&userAllowsVoidingWarranty, "permanentlyVoidAllSupportGuarantees", false, "Allow for the permanent voiding of all support guarantees for this environment. For some features, e.g. experimental features, if a user sets the activation status to one that does not match the default activation, all support guarantees for this environment will be permanently voided.")
+	FeatureActivateCmd.Flags().StringVar(&activationChangeReason, "reason", "", "Reason for activating the Feature, recorded in the activation history")
 }
 
 func featureActivate(cmd *cobra.Command, args []string) error {
@@ -50,7 +52,7 @@ func featureActivate(cmd *cobra.Command, args []string) error {
 		userAllows = &userAllowsVoidingWarranty
 	}
 
-	gateName, err := activateFeature(ctx, fgClient, featureName, userAllows)
+	gateName, err := activateFeature(ctx, fgClient, featureName, userAllows, activationChangeReason)
 	if err != nil {
 		return fmt.Errorf("could not activate Feature %s: %w", featureName, err)
 	}
@@ -59,7 +61,7 @@ func featureActivate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func activateFeature(ctx context.Context, fgClient *featuregateclient.FeatureGateClient, featureName string, userAllows *bool) (string, error) {
+func activateFeature(ctx context.Context, fgClient *featuregateclient.FeatureGateClient, featureName string, userAllows *bool, reason string) (string, error) {
 	feature, err := fgClient.GetFeature(ctx, featureName)
 	if err != nil {
 		return "", fmt.Errorf("could not get Feature %s: %w", featureName, err)
@@ -70,10 +72,15 @@ func activateFeature(ctx context.Context, fgClient *featuregateclient.FeatureGat
 		return "", fmt.Errorf("could not get FeatureGate List: %w", err)
 	}
 
+	policies, err := fgClient.GetFeaturePolicyList(ctx)
+	if err != nil {
+		return "", fmt.Errorf("could not get FeaturePolicy List: %w", err)
+	}
+
 	gateName, featRef := featuregateclient.FeatureRefFromGateList(gates, featureName)
 
 	var proceedWithVoidingWarranty bool
-	if willWarrantyBeVoided(featRef, feature) {
+	if willWarrantyBeVoided(featRef, feature, policies.Items) {
 		// The warranty will be voided with the request, so check that user allows it.
 		proceedWithVoidingWarranty, err = userGivesPermissionToVoidWarranty(feature, userAllows)
 		if err != nil {
@@ -81,7 +88,7 @@ func activateFeature(ctx context.Context, fgClient *featuregateclient.FeatureGat
 		}
 	}
 
-	err = fgClient.ActivateFeature(ctx, featureName, proceedWithVoidingWarranty)
+	err = fgClient.ActivateFeature(ctx, featureName, proceedWithVoidingWarranty, featuregateclient.WithReason(reason))
 	if err != nil {
 		return gateName, fmt.Errorf("could not activate Feature %s gated by FeatureGate %s: %w", featureName, gateName, err)
 	}
@@ -114,9 +121,9 @@ func displayActivationWarnings(feature *corev1alpha2.Feature) {
 //
 // If all of the above are true, then the warranty will be voided and the function returns true.
 // Otherwise, if any are false, the warranty will not be voided and the function returns false.
-func willWarrantyBeVoided(ref corev1alpha2.FeatureReference, feature *corev1alpha2.Feature) bool {
+func willWarrantyBeVoided(ref corev1alpha2.FeatureReference, feature *corev1alpha2.Feature, policies []corev1alpha2.FeaturePolicy) bool {
 	stability := feature.Spec.Stability
-	policy := corev1alpha2.GetPolicyForStabilityLevel(stability)
+	policy := corev1alpha2.GetPolicyForStabilityLevel(stability, policies...)
 	return policy.VoidsWarranty && !ref.PermanentlyVoidAllSupportGuarantees && !ref.Activate && !policy.DefaultActivation
 }
 
@@ -116,7 +116,7 @@ func TestActivateFeature(t *testing.T) {
 			if tc.userAllows.wasSet {
 				userAllows = &tc.userAllows.setTo
 			}
-			_, err := activateFeature(context.Background(), fgClient, tc.featureName, userAllows)
+			_, err := activateFeature(context.Background(), fgClient, tc.featureName, userAllows, "")
 
 			if !errors.Is(err, tc.wantErr) {
 				t.Fatalf("got error: %v, want: %v", err, tc.wantErr)
@@ -0,0 +1,218 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+var (
+	applyFile      string
+	applyDryRun    bool
+	applyAssumeYes bool
+)
+
+// FeatureApplyCmd is for applying a feature configuration to the cluster
+var FeatureApplyCmd = &cobra.Command{
+	Use:   "apply -f <file>",
+	Short: "Apply a feature configuration to the cluster",
+	Args:  cobra.NoArgs,
+	Example: `
+	# Show the changes to the FeatureGates of the cluster, and apply them after confirmation
+	tanzu feature apply -f features.yaml
+
+	# Only show the changes to the FeatureGates of the cluster
+	tanzu feature apply -f features.yaml --dry-run`,
+	RunE: featureApply,
+}
+
+func init() {
+	FeatureApplyCmd.Flags().StringVarP(&applyFile, "file", "f", "", "File holding the feature configuration to apply")
+	FeatureApplyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "Only show the changes to the FeatureGates, without applying them")
+	FeatureApplyCmd.Flags().BoolVarP(&applyAssumeYes, "yes", "y", false, "Apply the changes without asking for confirmation")
+	FeatureApplyCmd.Flags().BoolVar(&userAllowsVoidingWarranty, "permanentlyVoidAllSupportGuarantees", false, "Allow for the permanent voiding of all support guarantees for this environment. For some features, e.g. experimental features, if a user sets the activation status to one that does not match the default activation, all support guarantees for this environment will be permanently voided.")
+	FeatureApplyCmd.Flags().StringVar(&activationChangeReason, "reason", "", "Reason for applying the feature configuration, recorded in the activation history")
+	_ = FeatureApplyCmd.MarkFlagRequired("file")
+}
+
+func featureApply(cmd *cobra.Command, _ []string) error {
+	config, err := readFeatureConfiguration(applyFile)
+	if err != nil {
+		return err
+	}
+
+	fgClient, err := featuregateclient.NewFeatureGateClient()
+	if err != nil {
+		return fmt.Errorf("could not get FeatureGateClient: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	var userAllows *bool
+	if cmd.Flags().Changed("permanentlyVoidAllSupportGuarantees") {
+		userAllows = &userAllowsVoidingWarranty
+	}
+
+	return applyFeatureConfiguration(ctx, cmd, fgClient, config, userAllows, activationChangeReason)
+}
+
+// readFeatureConfiguration reads and parses the feature configuration in the file.
+func readFeatureConfiguration(path string) (*featuregateclient.FeatureConfiguration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read feature configuration file %s: %w", path, err)
+	}
+
+	config, err := featuregateclient.ParseFeatureConfiguration(data)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse feature configuration file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// applyFeatureConfiguration shows the plan for applying the feature configuration, and applies it once the user has
+// confirmed the changes and allowed any voiding of support warranties.
+func applyFeatureConfiguration(ctx context.Context, cmd *cobra.Command, fgClient *featuregateclient.FeatureGateClient, config *featuregateclient.FeatureConfiguration, userAllows *bool, reason string) error {
+	plan, err := fgClient.PlanFeatureConfiguration(ctx, config)
+	if err != nil {
+		return fmt.Errorf("could not plan feature configuration: %w", err)
+	}
+
+	if !plan.HasChanges() {
+		cmd.Println("The FeatureGates of the cluster already match the feature configuration. No changes to apply.")
+		return nil
+	}
+
+	printFeatureConfigurationPlan(cmd, plan)
+
+	if len(plan.Violations) > 0 {
+		return fmt.Errorf("could not apply feature configuration, it violates the policies of the cluster: %w", featuregateclient.ErrTypeForbidden)
+	}
+
+	if applyDryRun {
+		return nil
+	}
+
+	proceed := applyAssumeYes
+	var proceedWithVoidingWarranty bool
+	if len(plan.VoidedWarranties) > 0 {
+		proceedWithVoidingWarranty, err = userGivesPermissionToVoidWarrantyForPlan(plan, userAllows)
+		if err != nil {
+			return fmt.Errorf("could not get user permission to void warranty: %w", err)
+		}
+		if !proceedWithVoidingWarranty {
+			return fmt.Errorf("could not apply feature configuration, voiding the support warranty was not allowed: %w", featuregateclient.ErrTypeForbidden)
+		}
+		// The user already agreed to the changes when allowing the warranty to be voided interactively.
+		proceed = proceed || userAllows == nil
+	}
+
+	if !proceed {
+		cmd.Print("Would you like to apply these changes [y/N]?")
+		proceed, err = userAllowsByInteractiveCLI(nil)
+		if err != nil {
+			return fmt.Errorf("could not get user confirmation: %w", err)
+		}
+		if !proceed {
+			cmd.Println("No changes applied.")
+			return nil
+		}
+	}
+
+	if err := fgClient.ApplyFeatureConfiguration(ctx, plan, proceedWithVoidingWarranty, reason); err != nil {
+		return fmt.Errorf("could not apply feature configuration: %w", err)
+	}
+
+	cmd.Println("Feature configuration applied.")
+	return nil
+}
+
+func userGivesPermissionToVoidWarrantyForPlan(plan *featuregateclient.FeatureConfigurationPlan, userAllowsByFlag *bool) (bool, error) {
+	if userAllowsByFlag == nil {
+		fmt.Printf("Warning: applying the feature configuration will irrevocably void all support guarantees for this environment because of Features %s. You will need to recreate the environment to return to a supported state.\nWould you like to continue [y/N]?", strings.Join(plan.VoidedWarranties, ", "))
+		return userAllowsByInteractiveCLI(nil)
+	}
+	return *userAllowsByFlag, nil
+}
+
+// printFeatureConfigurationPlan shows the changes to the FeatureGates of the plan and their consequences.
+func printFeatureConfigurationPlan(cmd *cobra.Command, plan *featuregateclient.FeatureConfigurationPlan) {
+	printFeatureGateChanges(cmd, plan.FeatureGates)
+
+	if len(plan.Activations) > 0 {
+		cmd.Println("Features whose activation will change:")
+		printActivationDifferences(cmd, plan.Activations)
+	}
+
+	if len(plan.VoidedWarranties) > 0 {
+		cmd.Printf("Features that will permanently void all support guarantees for this environment: %s\n", strings.Join(plan.VoidedWarranties, ", "))
+	}
+
+	if len(plan.Violations) > 0 {
+		cmd.Println("Violations:")
+		for _, violation := range plan.Violations {
+			cmd.Printf("  - %s\n", violation)
+		}
+	}
+}
+
+func printFeatureGateChanges(cmd *cobra.Command, changes []featuregateclient.FeatureGateChange) {
+	for _, change := range changes {
+		switch change.Type {
+		case featuregateclient.ChangeTypeCreate:
+			cmd.Printf("FeatureGate %s will be created with priority %d:\n", change.Name, change.Priority)
+		default:
+			cmd.Printf("FeatureGate %s will be updated:\n", change.Name)
+			if change.PreviousPriority != change.Priority {
+				cmd.Printf("  priority: %d -> %d\n", change.PreviousPriority, change.Priority)
+			}
+		}
+		for _, ref := range change.References {
+			switch ref.Type {
+			case featuregateclient.ChangeTypeCreate:
+				cmd.Printf("  + %s: %s\n", ref.Feature, describeFeatureReference(ref.Desired))
+			case featuregateclient.ChangeTypeUpdate:
+				cmd.Printf("  ~ %s: %s -> %s\n", ref.Feature, describeFeatureReference(ref.Previous), describeFeatureReference(ref.Desired))
+			case featuregateclient.ChangeTypeRemove:
+				cmd.Printf("  - %s: %s\n", ref.Feature, describeFeatureReference(ref.Previous))
+			}
+		}
+	}
+}
+
+func printActivationDifferences(cmd *cobra.Command, differences []featuregateclient.FeatureActivationDifference) {
+	for _, difference := range differences {
+		cmd.Printf("  %s: %s -> %s\n", difference.Feature, describeActivation(difference.Activated), describeActivation(difference.Desired))
+	}
+}
+
+func describeFeatureReference(ref *corev1alpha2.FeatureReference) string {
+	if ref == nil {
+		return ""
+	}
+	description := fmt.Sprintf("activate=%t", ref.Activate)
+	if ref.PermanentlyVoidAllSupportGuarantees {
+		description += ", permanentlyVoidAllSupportGuarantees=true"
+	}
+	if ref.Rollout != nil {
+		description += ", rollout"
+	}
+	return description
+}
+
+func describeActivation(activated bool) string {
+	if activated {
+		return "activated"
+	}
+	return "deactivated"
+}
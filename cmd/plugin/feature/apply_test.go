@@ -0,0 +1,189 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/kubernetes/scheme"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/fake"
+)
+
+func newTestFeatureGateClient(t *testing.T) *featuregateclient.FeatureGateClient {
+	objs, _, _ := fake.GetTestObjects()
+	if err := corev1alpha2.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+	fgClient, err := featuregateclient.NewFeatureGateClient(featuregateclient.WithClient(cl))
+	if err != nil {
+		t.Fatalf("unable to get FeatureGate client: %v", err)
+	}
+	return fgClient
+}
+
+// exportTestFeatureConfiguration exports the feature configuration of the cluster, and sets the reference to the
+// feature in the FeatureGate.
+func exportTestFeatureConfiguration(t *testing.T, fgClient *featuregateclient.FeatureGateClient, gateName string, ref *corev1alpha2.FeatureReference) *featuregateclient.FeatureConfiguration {
+	var out bytes.Buffer
+	if err := exportFeatureConfiguration(context.Background(), fgClient, &out); err != nil {
+		t.Fatalf("export feature configuration: %v", err)
+	}
+	config, err := featuregateclient.ParseFeatureConfiguration(out.Bytes())
+	if err != nil {
+		t.Fatalf("parse exported feature configuration: %v", err)
+	}
+
+	if ref == nil {
+		return config
+	}
+	for i := range config.FeatureGates {
+		if config.FeatureGates[i].Name != gateName {
+			continue
+		}
+		for j := range config.FeatureGates[i].Features {
+			if config.FeatureGates[i].Features[j].Name == ref.Name {
+				config.FeatureGates[i].Features[j] = *ref
+			}
+		}
+	}
+	return config
+}
+
+func TestApplyFeatureConfiguration(t *testing.T) {
+	allow, disallow := true, false
+
+	tests := []struct {
+		description  string
+		ref          *corev1alpha2.FeatureReference
+		dryRun       bool
+		userAllows   *bool
+		wantErr      error
+		wantOutput   []string
+		wantActivate bool
+	}{
+		{
+			description: "nothing to apply when the cluster matches the feature configuration",
+			wantOutput:  []string{"No changes to apply."},
+		},
+		{
+			description:  "apply the activation of a feature",
+			ref:          &corev1alpha2.FeatureReference{Name: "bar", Activate: true},
+			wantOutput:   []string{"FeatureGate tkg-system will be updated:", "~ bar: activate=false -> activate=true", "bar: deactivated -> activated", "Feature configuration applied."},
+			wantActivate: true,
+		},
+		{
+			description: "only show the plan on dry run",
+			ref:         &corev1alpha2.FeatureReference{Name: "bar", Activate: true},
+			dryRun:      true,
+			wantOutput:  []string{"~ bar: activate=false -> activate=true"},
+		},
+		{
+			description: "reject activating an experimental feature without voiding the warranty",
+			ref:         &corev1alpha2.FeatureReference{Name: "cloud-event-relayer", Activate: true},
+			wantErr:     featuregateclient.ErrTypeForbidden,
+			wantOutput:  []string{"Violations:"},
+		},
+		{
+			description: "do not void the warranty when the user disallows it",
+			ref:         &corev1alpha2.FeatureReference{Name: "cloud-event-relayer", Activate: true, PermanentlyVoidAllSupportGuarantees: true},
+			userAllows:  &disallow,
+			wantErr:     featuregateclient.ErrTypeForbidden,
+			wantOutput:  []string{"permanently void all support guarantees for this environment: cloud-event-relayer"},
+		},
+		{
+			description:  "void the warranty when the user allows it",
+			ref:          &corev1alpha2.FeatureReference{Name: "cloud-event-relayer", Activate: true, PermanentlyVoidAllSupportGuarantees: true},
+			userAllows:   &allow,
+			wantOutput:   []string{"activate=true, permanentlyVoidAllSupportGuarantees=true", "Feature configuration applied."},
+			wantActivate: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fgClient := newTestFeatureGateClient(t)
+			config := exportTestFeatureConfiguration(t, fgClient, "tkg-system", tc.ref)
+
+			applyDryRun, applyAssumeYes = tc.dryRun, true
+			defer func() { applyDryRun, applyAssumeYes = false, false }()
+
+			var out bytes.Buffer
+			FeatureApplyCmd.SetOut(&out)
+			defer FeatureApplyCmd.SetOut(nil)
+
+			err := applyFeatureConfiguration(context.Background(), FeatureApplyCmd, fgClient, config, tc.userAllows, "")
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error: %v, want: %v", err, tc.wantErr)
+			}
+			for _, want := range tc.wantOutput {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("apply output %q does not contain %q", out.String(), want)
+				}
+			}
+
+			if tc.ref == nil {
+				return
+			}
+			gates, err := fgClient.GetFeatureGateList(context.Background())
+			if err != nil {
+				t.Fatalf("get FeatureGate List: %v", err)
+			}
+			_, ref := featuregateclient.FeatureRefFromGateList(gates, tc.ref.Name)
+			if ref.Activate != tc.wantActivate {
+				t.Errorf("got activate: %t, want: %t", ref.Activate, tc.wantActivate)
+			}
+		})
+	}
+}
+
+func TestReportFeatureConfigurationDrift(t *testing.T) {
+	tests := []struct {
+		description string
+		ref         *corev1alpha2.FeatureReference
+		wantErr     error
+		wantOutput  []string
+	}{
+		{
+			description: "no drift when the cluster matches the feature configuration",
+			wantOutput:  []string{"The cluster matches the feature configuration."},
+		},
+		{
+			description: "report the FeatureGates that differ from the feature configuration",
+			ref:         &corev1alpha2.FeatureReference{Name: "bar", Activate: true},
+			wantErr:     errFeatureConfigurationDrift,
+			wantOutput:  []string{"FeatureGate tkg-system will be updated:", "~ bar: activate=false -> activate=true"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fgClient := newTestFeatureGateClient(t)
+			config := exportTestFeatureConfiguration(t, fgClient, "tkg-system", tc.ref)
+
+			var out bytes.Buffer
+			FeatureDriftCmd.SetOut(&out)
+			defer FeatureDriftCmd.SetOut(nil)
+
+			err := reportFeatureConfigurationDrift(context.Background(), FeatureDriftCmd, fgClient, config)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error: %v, want: %v", err, tc.wantErr)
+			}
+			for _, want := range tc.wantOutput {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("drift output %q does not contain %q", out.String(), want)
+				}
+			}
+		})
+	}
+}
@@ -31,7 +31,7 @@ var FeatureDeactivateCmd = &cobra.Command{
 		ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
 		defer cancel()
 
-		gateName, err := deactivateFeature(ctx, fgClient, featureName)
+		gateName, err := deactivateFeature(ctx, fgClient, featureName, activationChangeReason)
 		if err != nil {
 			return fmt.Errorf("could not deactivate Feature %s gated by FeatureGate %s: %w", featureName, gateName, err)
 		}
@@ -41,6 +41,10 @@ var FeatureDeactivateCmd = &cobra.Command{
 	},
 }
 
-func deactivateFeature(ctx context.Context, fgClient *featuregateclient.FeatureGateClient, featureName string) (string, error) {
-	return fgClient.DeactivateFeature(ctx, featureName)
+func init() {
+	FeatureDeactivateCmd.Flags().StringVar(&activationChangeReason, "reason", "", "Reason for deactivating the Feature, recorded in the activation history")
+}
+
+func deactivateFeature(ctx context.Context, fgClient *featuregateclient.FeatureGateClient, featureName, reason string) (string, error) {
+	return fgClient.DeactivateFeature(ctx, featureName, featuregateclient.WithReason(reason))
 }
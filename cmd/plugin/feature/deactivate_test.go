@@ -78,7 +78,7 @@ func TestDeactivateFeature(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.description, func(t *testing.T) {
-			_, err := deactivateFeature(context.Background(), fgClient, tc.featureName)
+			_, err := deactivateFeature(context.Background(), fgClient, tc.featureName, "")
 
 			if !errors.Is(err, tc.wantErr) {
 				t.Fatalf("got error: %v, want: %v", err, tc.wantErr)
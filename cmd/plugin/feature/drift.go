@@ -0,0 +1,88 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+var driftFile string
+
+var errFeatureConfigurationDrift = errors.New("the feature configuration of the cluster has drifted")
+
+// FeatureDriftCmd is for reporting the drift between a feature configuration and the cluster
+var FeatureDriftCmd = &cobra.Command{
+	Use:   "drift -f <file>",
+	Short: "Report the differences between a feature configuration and the cluster",
+	Args:  cobra.NoArgs,
+	Example: `
+	# Report the Features and FeatureGates of the cluster that differ from the feature configuration
+	tanzu feature drift -f features.yaml`,
+	RunE: featureDrift,
+}
+
+func init() {
+	FeatureDriftCmd.Flags().StringVarP(&driftFile, "file", "f", "", "File holding the feature configuration to compare to the cluster")
+	_ = FeatureDriftCmd.MarkFlagRequired("file")
+}
+
+func featureDrift(cmd *cobra.Command, _ []string) error {
+	config, err := readFeatureConfiguration(driftFile)
+	if err != nil {
+		return err
+	}
+
+	fgClient, err := featuregateclient.NewFeatureGateClient()
+	if err != nil {
+		return fmt.Errorf("could not get FeatureGateClient: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	err = reportFeatureConfigurationDrift(ctx, cmd, fgClient, config)
+	if errors.Is(err, errFeatureConfigurationDrift) {
+		// Drift is reported, not a misuse of the command.
+		cmd.SilenceUsage = true
+	}
+	return err
+}
+
+// reportFeatureConfigurationDrift shows the differences between the feature configuration and the cluster, and
+// returns errFeatureConfigurationDrift if there are any.
+func reportFeatureConfigurationDrift(ctx context.Context, cmd *cobra.Command, fgClient *featuregateclient.FeatureGateClient, config *featuregateclient.FeatureConfiguration) error {
+	drift, err := fgClient.DetectFeatureConfigurationDrift(ctx, config)
+	if err != nil {
+		return fmt.Errorf("could not detect feature configuration drift: %w", err)
+	}
+
+	if !drift.HasDrift() {
+		cmd.Println("The cluster matches the feature configuration.")
+		return nil
+	}
+
+	if len(drift.Activations) > 0 {
+		cmd.Println("Features whose activation differs from the feature configuration:")
+		printActivationDifferences(cmd, drift.Activations)
+	}
+	if len(drift.MissingFeatures) > 0 {
+		cmd.Printf("Features of the feature configuration missing from the cluster: %s\n", strings.Join(drift.MissingFeatures, ", "))
+	}
+	if len(drift.UnknownFeatures) > 0 {
+		cmd.Printf("Features of the cluster missing from the feature configuration: %s\n", strings.Join(drift.UnknownFeatures, ", "))
+	}
+	if len(drift.UnmanagedFeatureGates) > 0 {
+		cmd.Printf("FeatureGates of the cluster missing from the feature configuration: %s\n", strings.Join(drift.UnmanagedFeatureGates, ", "))
+	}
+	printFeatureGateChanges(cmd, drift.FeatureGates)
+
+	return errFeatureConfigurationDrift
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+var exportFile string
+
+// FeatureExportCmd is for exporting the feature configuration of the cluster
+var FeatureExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the feature configuration of the cluster",
+	Args:  cobra.NoArgs,
+	Example: `
+	# Print the effective activation of all Features and the references of all FeatureGates
+	tanzu feature export
+
+	# Save the feature configuration to a file, to apply it to another cluster
+	tanzu feature export -f features.yaml`,
+	RunE: featureExport,
+}
+
+func init() {
+	FeatureExportCmd.Flags().StringVarP(&exportFile, "file", "f", "", "File to write the feature configuration to, defaults to stdout")
+}
+
+func featureExport(cmd *cobra.Command, _ []string) error {
+	fgClient, err := featuregateclient.NewFeatureGateClient()
+	if err != nil {
+		return fmt.Errorf("could not get FeatureGateClient: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	if exportFile == "" {
+		return exportFeatureConfiguration(ctx, fgClient, cmd.OutOrStdout())
+	}
+
+	f, err := os.Create(exportFile)
+	if err != nil {
+		return fmt.Errorf("could not create file %s: %w", exportFile, err)
+	}
+	defer f.Close()
+
+	if err := exportFeatureConfiguration(ctx, fgClient, f); err != nil {
+		return err
+	}
+	cmd.Printf("Feature configuration exported to %s.\n", exportFile)
+	return nil
+}
+
+func exportFeatureConfiguration(ctx context.Context, fgClient *featuregateclient.FeatureGateClient, w io.Writer) error {
+	config, err := fgClient.ExportFeatureConfiguration(ctx)
+	if err != nil {
+		return fmt.Errorf("could not export feature configuration: %w", err)
+	}
+
+	data, err := featuregateclient.MarshalFeatureConfiguration(config)
+	if err != nil {
+		return fmt.Errorf("could not marshal feature configuration: %w", err)
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("could not write feature configuration: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+)
+
+var historyOutputFormat string
+
+// FeatureHistoryCmd is for showing the activation history of a Feature
+var FeatureHistoryCmd = &cobra.Command{
+	Use:   "history <feature>",
+	Short: "Show the activation history of a Feature",
+	Args:  cobra.ExactArgs(1),
+	Example: `
+	# Show who activated or deactivated a cluster Feature, when and why
+	tanzu feature history myfeature`,
+	RunE: printFeatureHistory,
+}
+
+func init() {
+	FeatureHistoryCmd.Flags().StringVarP(&historyOutputFormat, "output", "o", "", "Output format (yaml|json|table)")
+}
+
+func printFeatureHistory(cmd *cobra.Command, args []string) error {
+	featureName := args[0]
+
+	fgClient, err := featuregateclient.NewFeatureGateClient()
+	if err != nil {
+		return fmt.Errorf("could not get FeatureGateClient: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	records, err := fgClient.GetFeatureActivationHistory(ctx, featureName)
+	if err != nil {
+		return fmt.Errorf("could not get activation history of Feature %s: %w", featureName, err)
+	}
+
+	return listHistory(cmd, records)
+}
+
+// listHistory renders the activation changes of a Feature, oldest first
+func listHistory(cmd *cobra.Command, records []featuregateclient.FeatureActivationRecord) error {
+	var t component.OutputWriterSpinner
+	t, err := component.NewOutputWriterWithSpinner(cmd.OutOrStdout(), historyOutputFormat,
+		"Retrieving activation history...", true, "TIME", "ACTOR", "FEATUREGATE", "PREVIOUS", "NEW", "VOIDED WARRANTY", "REASON")
+	if err != nil {
+		return fmt.Errorf("could not get OutputWriterSpinner: %w", err)
+	}
+
+	for i := range records {
+		t.AddRow(records[i].Time.UTC().Format(time.RFC3339), records[i].Actor, records[i].FeatureGate,
+			records[i].PreviousActivate, records[i].Activate, records[i].VoidedWarranty, records[i].Reason)
+	}
+	t.RenderWithSpinner()
+
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+func TestListHistory(t *testing.T) {
+	records := []featuregateclient.FeatureActivationRecord{
+		{
+			FeatureGate: "tkg-system",
+			FeatureActivationChange: corev1alpha2.FeatureActivationChange{
+				Feature:        "cloud-event-relayer",
+				Actor:          "kubernetes-admin",
+				Time:           metav1.NewTime(time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)),
+				Activate:       true,
+				VoidedWarranty: true,
+				Reason:         "needed-for-demo",
+			},
+		},
+	}
+
+	var out bytes.Buffer
+	FeatureHistoryCmd.SetOut(&out)
+	defer FeatureHistoryCmd.SetOut(nil)
+
+	if err := listHistory(FeatureHistoryCmd, records); err != nil {
+		t.Fatalf("got unwanted history list error: %v", err)
+	}
+	for _, want := range []string{"2023-06-01T00:00:00Z", "kubernetes-admin", "tkg-system", "needed-for-demo"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("history output %q does not contain %q", out.String(), want)
+		}
+	}
+}
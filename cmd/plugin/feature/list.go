@@ -6,6 +6,8 @@ package main
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -51,6 +53,15 @@ type FeatureInfo struct {
 	FeatureGate  string
 	Activated    bool
 	ShowInList   bool
+	// Priority of the FeatureGate that decides the activation of the Feature, and the FeatureGates it overrides.
+	Priority               string
+	OverriddenFeatureGates string
+	// Lifecycle metadata of the Feature, shown in the extended output.
+	Owner          string
+	IntroducedIn   string
+	PlannedGAIn    string
+	PlannedRemoval string
+	Links          string
 }
 
 func printFeatures(cmd *cobra.Command, _ []string) error {
@@ -67,10 +78,22 @@ func printFeatures(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("could not gather features' information: %w", err)
 	}
 
+	voidedWarranties, err := fgClient.GetVoidedWarranties(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get the support status of the environment: %w", err)
+	}
+
 	if extended {
-		return listExtended(cmd, infos)
+		err = listExtended(cmd, infos)
+	} else {
+		err = listBasic(cmd, infos)
+	}
+	if err != nil {
+		return err
 	}
-	return listBasic(cmd, infos)
+
+	warnIfWarrantyVoided(cmd, voidedWarranties)
+	return nil
 }
 
 // featureInfoList will determine which features' information will be listed. If a feature's info
@@ -87,7 +110,12 @@ func featureInfoList(ctx context.Context, cl *featuregateclient.FeatureGateClien
 		return nil, err
 	}
 
-	featureInfos := collectFeaturesInfo(gateList.Items, clusterFeatures.Items)
+	policyList, err := cl.GetFeaturePolicyList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	featureInfos := collectFeaturesInfo(gateList.Items, clusterFeatures.Items, policyList.Items)
 
 	setShowInList(featureInfos, includeExperimental, featuregate)
 
@@ -96,12 +124,12 @@ func featureInfoList(ctx context.Context, cl *featuregateclient.FeatureGateClien
 }
 
 // collectFeaturesInfo will create a map of features and their information from
-// FeatureGate references and features.
-func collectFeaturesInfo(gates []corev1alpha2.FeatureGate, features []corev1alpha2.Feature) map[string]*FeatureInfo {
+// FeatureGate references and features. The policies of the stability levels are resolved through the FeaturePolicies.
+func collectFeaturesInfo(gates []corev1alpha2.FeatureGate, features []corev1alpha2.Feature, policies []corev1alpha2.FeaturePolicy) map[string]*FeatureInfo {
 	infos := map[string]*FeatureInfo{}
 
 	for i := range features {
-		policy := corev1alpha2.GetPolicyForStabilityLevel(features[i].Spec.Stability)
+		policy := corev1alpha2.GetPolicyForStabilityLevel(features[i].Spec.Stability, policies...)
 
 		infos[features[i].Name] = &FeatureInfo{
 			Name:         features[i].Name,
@@ -111,32 +139,69 @@ func collectFeaturesInfo(gates []corev1alpha2.FeatureGate, features []corev1alph
 			Immutable:    policy.Immutable,
 			Discoverable: policy.Discoverable,
 			FeatureGate:  "--",
+			Priority:     "--",
+
+			Owner:          features[i].Spec.Owner,
+			IntroducedIn:   features[i].Spec.IntroducedIn,
+			PlannedGAIn:    features[i].Spec.PlannedGAIn,
+			PlannedRemoval: plannedRemoval(&features[i]),
+			Links:          links(&features[i]),
 		}
 	}
 
 	for i := range gates {
 		for _, featRef := range gates[i].Spec.Features {
-			info, ok := infos[featRef.Name]
-			if ok {
-				// FeatureGate referenced Feature is in cluster.
-				info.FeatureGate = gates[i].Name
-			}
-
-			if !ok {
+			if _, ok := infos[featRef.Name]; !ok {
 				// FeatureGate referenced Feature is not in cluster. Since the Discoverable policy
 				// cannot be known until the Feature shows up in cluster, set it to true for now.
 				infos[featRef.Name] = &FeatureInfo{
 					Name:         featRef.Name,
 					Discoverable: true,
-					FeatureGate:  gates[i].Name,
 				}
 			}
 		}
 	}
 
+	// The FeatureGate with the highest priority that references a Feature decides its activation
+	// and overrides the other FeatureGates.
+	for name, info := range infos {
+		referencing := corev1alpha2.GetFeatureGatesForFeature(gates, name)
+		if len(referencing) == 0 {
+			continue
+		}
+		info.FeatureGate = referencing[0].Name
+		info.Priority = strconv.Itoa(int(referencing[0].Spec.Priority))
+		var overridden []string
+		for _, gate := range referencing[1:] {
+			overridden = append(overridden, gate.Name)
+		}
+		info.OverriddenFeatureGates = strings.Join(overridden, ",")
+	}
+
 	return infos
 }
 
+// plannedRemoval returns the planned removal version and date of a Feature
+func plannedRemoval(feature *corev1alpha2.Feature) string {
+	var removal []string
+	if feature.Spec.PlannedRemovalIn != "" {
+		removal = append(removal, feature.Spec.PlannedRemovalIn)
+	}
+	if feature.Spec.RemovalDate != nil {
+		removal = append(removal, feature.Spec.RemovalDate.Format("2006-01-02"))
+	}
+	return strings.Join(removal, " ")
+}
+
+// links returns the links of a Feature as a comma separated list of name=url pairs
+func links(feature *corev1alpha2.Feature) string {
+	var l []string
+	for _, link := range feature.Spec.Links {
+		l = append(l, fmt.Sprintf("%s=%s", link.Name, link.URL))
+	}
+	return strings.Join(l, ",")
+}
+
 // setShowInList will determine if a Feature will be listed based on Features that can
 // be listed by default and whether or not a FeatureGate was specified by the user.
 func setShowInList(infos map[string]*FeatureInfo, inclExperimental bool, gateName string) {
@@ -149,13 +214,24 @@ func setShowInList(infos map[string]*FeatureInfo, inclExperimental bool, gateNam
 			v.ShowInList = inclExperimental
 		}
 
-		// If FeatureGate is specified, delist the Features not gated.
-		if gateName != "" && v.FeatureGate != gateName {
+		// If FeatureGate is specified, delist the Features not gated, even by an overridden reference.
+		if gateName != "" && v.FeatureGate != gateName && !overriddenBy(v, gateName) {
 			v.ShowInList = false
 		}
 	}
 }
 
+// overriddenBy returns true if the FeatureGate references the Feature, but is overridden by a FeatureGate
+// with a higher priority.
+func overriddenBy(info *FeatureInfo, gateName string) bool {
+	for _, overridden := range strings.Split(info.OverriddenFeatureGates, ",") {
+		if overridden == gateName {
+			return true
+		}
+	}
+	return false
+}
+
 // featuresFilteredByFlags will determine which features will be listed based on the flags provided.
 // If none of flags were set, then all features will be selected to display.
 func featuresFilteredByFlags(infos map[string]*FeatureInfo, activated, deactivated bool) []FeatureInfo {
@@ -181,13 +257,15 @@ func featuresFilteredByFlags(infos map[string]*FeatureInfo, activated, deactivat
 func listExtended(cmd *cobra.Command, features []FeatureInfo) error {
 	var t component.OutputWriterSpinner
 	t, err := component.NewOutputWriterWithSpinner(cmd.OutOrStdout(), outputFormat,
-		"Retrieving Features...", true, "NAME", "ACTIVATION STATE", "STABILITY", "DESCRIPTION", "IMMUTABLE", "FEATUREGATE")
+		"Retrieving Features...", true, "NAME", "ACTIVATION STATE", "STABILITY", "DESCRIPTION", "IMMUTABLE", "FEATUREGATE",
+		"PRIORITY", "OVERRIDDEN FEATUREGATES", "OWNER", "INTRODUCED IN", "PLANNED GA IN", "PLANNED REMOVAL", "LINKS")
 	if err != nil {
 		return fmt.Errorf("could not get OutputWriterSpinner: %w", err)
 	}
 
 	for _, info := range features {
-		t.AddRow(info.Name, info.Activated, info.Stability, info.Description, info.Immutable, info.FeatureGate)
+		t.AddRow(info.Name, info.Activated, info.Stability, info.Description, info.Immutable, info.FeatureGate,
+			info.Priority, info.OverriddenFeatureGates, info.Owner, info.IntroducedIn, info.PlannedGAIn, info.PlannedRemoval, info.Links)
 	}
 	t.RenderWithSpinner()
 
@@ -198,13 +276,13 @@ func listExtended(cmd *cobra.Command, features []FeatureInfo) error {
 func listBasic(cmd *cobra.Command, features []FeatureInfo) error {
 	var t component.OutputWriterSpinner
 	t, err := component.NewOutputWriterWithSpinner(cmd.OutOrStdout(), outputFormat,
-		"Retrieving Features...", true, "NAME", "ACTIVATION STATE", "FEATUREGATE")
+		"Retrieving Features...", true, "NAME", "ACTIVATION STATE", "FEATUREGATE", "PRIORITY")
 	if err != nil {
 		return fmt.Errorf("could not get OutputWriterSpinner: %w", err)
 	}
 
 	for _, info := range features {
-		t.AddRow(info.Name, info.Activated, info.FeatureGate)
+		t.AddRow(info.Name, info.Activated, info.FeatureGate, info.Priority)
 	}
 	t.RenderWithSpinner()
 
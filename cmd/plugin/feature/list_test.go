@@ -8,8 +8,10 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -274,6 +276,129 @@ func TestFeatureInfoList(t *testing.T) {
 	}
 }
 
+func TestFeatureInfoListWithFeaturePolicy(t *testing.T) {
+	objs, _, _ := fake.GetTestObjects()
+	s := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("add config scheme: (%v)", err)
+	}
+
+	discoverable := false
+	policy := &corev1alpha2.FeaturePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "regulated"},
+		Spec: corev1alpha2.FeaturePolicySpec{
+			Policies: []corev1alpha2.StabilityLevelPolicy{
+				{Stability: corev1alpha2.TechnicalPreview, Discoverable: &discoverable},
+			},
+		},
+	}
+	cl := crclient.NewClientBuilder().WithRuntimeObjects(append(objs, policy)...).Build()
+	fgClient, err := featuregateclient.NewFeatureGateClient(featuregateclient.WithClient(cl))
+	if err != nil {
+		t.Fatalf("get FeatureGate client: (%v)", err)
+	}
+
+	featuregate = ""
+	activated = true
+	deactivated = false
+	includeExperimental = false
+
+	got, err := featureInfoList(context.Background(), fgClient, featuregate)
+	if err != nil {
+		t.Fatalf("procure featureInfoList: %v", err)
+	}
+
+	// tuner is a technical preview Feature that the FeaturePolicy makes non-discoverable.
+	want := []string{"super-toaster", "bazzies"}
+	if len(got) != len(want) {
+		t.Errorf("got: %v Features, but want %v", got, want)
+	}
+	for _, name := range want {
+		if !featureInfoSliceContains(got, name) {
+			t.Errorf("got: %+v, but list is missing Feature %s", got, name)
+		}
+	}
+}
+
+func TestCollectFeaturesInfoLifecycle(t *testing.T) {
+	removalDate := metav1.NewTime(time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC))
+	features := []corev1alpha2.Feature{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "old-toaster"},
+			Spec: corev1alpha2.FeatureSpec{
+				Description:      "Toaster without a timer",
+				Stability:        corev1alpha2.Deprecated,
+				Owner:            "toaster-team",
+				IntroducedIn:     "v0.10.0",
+				PlannedRemovalIn: "v0.30.0",
+				RemovalDate:      &removalDate,
+				Links: []corev1alpha2.FeatureLink{
+					{Name: "docs", URL: "https://example.com/docs"},
+					{Name: "issue", URL: "https://example.com/issues/1"},
+				},
+			},
+		},
+	}
+
+	infos := collectFeaturesInfo(nil, features, nil)
+	got := infos["old-toaster"]
+	want := FeatureInfo{
+		Name:           "old-toaster",
+		Description:    "Toaster without a timer",
+		Stability:      corev1alpha2.Deprecated,
+		Discoverable:   true,
+		FeatureGate:    "--",
+		Priority:       "--",
+		Owner:          "toaster-team",
+		IntroducedIn:   "v0.10.0",
+		PlannedRemoval: "v0.30.0 2024-01-31",
+		Links:          "docs=https://example.com/docs,issue=https://example.com/issues/1",
+	}
+	if got == nil || *got != want {
+		t.Errorf("got: %+v, want: %+v", got, want)
+	}
+}
+
+func TestCollectFeaturesInfoWithLayeredFeatureGates(t *testing.T) {
+	features := []corev1alpha2.Feature{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "super-toaster"},
+			Spec:       corev1alpha2.FeatureSpec{Stability: corev1alpha2.TechnicalPreview},
+		},
+	}
+	gates := []corev1alpha2.FeatureGate{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+			Spec: corev1alpha2.FeatureGateSpec{Features: []corev1alpha2.FeatureReference{
+				{Name: "super-toaster", Activate: true},
+				{Name: "hard-to-get", Activate: true},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "environment"},
+			Spec: corev1alpha2.FeatureGateSpec{Priority: 200, Features: []corev1alpha2.FeatureReference{
+				{Name: "super-toaster", Activate: false},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "platform"},
+			Spec: corev1alpha2.FeatureGateSpec{Priority: 100, Features: []corev1alpha2.FeatureReference{
+				{Name: "super-toaster", Activate: true},
+			}},
+		},
+	}
+
+	infos := collectFeaturesInfo(gates, features, nil)
+	if got := infos["super-toaster"]; got.FeatureGate != "environment" || got.Priority != "200" || got.OverriddenFeatureGates != "platform,baseline" {
+		t.Errorf("got FeatureGate %s with priority %s overriding %s, want environment with priority 200 overriding platform,baseline",
+			got.FeatureGate, got.Priority, got.OverriddenFeatureGates)
+	}
+	if got := infos["hard-to-get"]; got.FeatureGate != "baseline" || got.Priority != "0" || got.OverriddenFeatureGates != "" {
+		t.Errorf("got FeatureGate %s with priority %s overriding %s, want baseline with priority 0",
+			got.FeatureGate, got.Priority, got.OverriddenFeatureGates)
+	}
+}
+
 func TestListExtended(t *testing.T) {
 	tests := []struct {
 		description string
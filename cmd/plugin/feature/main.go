@@ -35,6 +35,11 @@ func main() {
 		FeatureListCmd,
 		FeatureActivateCmd,
 		FeatureDeactivateCmd,
+		FeatureHistoryCmd,
+		FeatureStatusCmd,
+		FeatureExportCmd,
+		FeatureApplyCmd,
+		FeatureDriftCmd,
 	)
 
 	if err := p.Execute(); err != nil {
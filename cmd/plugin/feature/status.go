@@ -0,0 +1,87 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+)
+
+var statusOutputFormat string
+
+// FeatureStatusCmd is for showing whether the environment is still supported
+var FeatureStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the support status of the environment",
+	Args:  cobra.NoArgs,
+	Example: `
+	# Show whether the support guarantees of the environment were voided, and by which features
+	tanzu feature status`,
+	RunE: printSupportStatus,
+}
+
+func init() {
+	FeatureStatusCmd.Flags().StringVarP(&statusOutputFormat, "output", "o", "", "Output format (yaml|json|table)")
+}
+
+func printSupportStatus(cmd *cobra.Command, _ []string) error {
+	fgClient, err := featuregateclient.NewFeatureGateClient()
+	if err != nil {
+		return fmt.Errorf("could not get FeatureGateClient: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	records, err := fgClient.GetVoidedWarranties(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get the support status of the environment: %w", err)
+	}
+
+	return listSupportStatus(cmd, records)
+}
+
+// listSupportStatus renders whether the environment is supported, along with the features that voided its support
+// warranty. Only the features are rendered for the yaml and json output formats.
+func listSupportStatus(cmd *cobra.Command, records []corev1alpha2.WarrantyVoidRecord) error {
+	if statusOutputFormat == "" || statusOutputFormat == string(component.TableOutputType) {
+		if len(records) == 0 {
+			cmd.Println("This environment is supported.")
+			return nil
+		}
+		cmd.Println("All support guarantees for this environment have been permanently voided by the following features:")
+	}
+
+	var t component.OutputWriterSpinner
+	t, err := component.NewOutputWriterWithSpinner(cmd.OutOrStdout(), statusOutputFormat,
+		"Retrieving support status...", true, "FEATURE", "FEATUREGATE", "ACTOR", "TIME")
+	if err != nil {
+		return fmt.Errorf("could not get OutputWriterSpinner: %w", err)
+	}
+
+	for i := range records {
+		voidedAt := ""
+		if !records[i].Time.IsZero() {
+			voidedAt = records[i].Time.UTC().Format(time.RFC3339)
+		}
+		t.AddRow(records[i].Feature, records[i].FeatureGate, records[i].Actor, voidedAt)
+	}
+	t.RenderWithSpinner()
+
+	return nil
+}
+
+// warnIfWarrantyVoided warns the user if the support guarantees of the environment were voided
+func warnIfWarrantyVoided(cmd *cobra.Command, records []corev1alpha2.WarrantyVoidRecord) {
+	if len(records) > 0 {
+		cmd.PrintErrln("Warning: all support guarantees for this environment have been permanently voided. Run 'tanzu feature status' for details.")
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+func TestListSupportStatus(t *testing.T) {
+	tests := []struct {
+		description string
+		records     []corev1alpha2.WarrantyVoidRecord
+		want        []string
+		wantWarning bool
+	}{
+		{
+			description: "supported environment",
+			want:        []string{"This environment is supported."},
+		},
+		{
+			description: "environment with voided support warranty",
+			records: []corev1alpha2.WarrantyVoidRecord{
+				{
+					Feature:     "cloud-event-relayer",
+					FeatureGate: "tkg-system",
+					Actor:       "kubernetes-admin",
+					Time:        metav1.NewTime(time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+			want:        []string{"permanently voided", "cloud-event-relayer", "kubernetes-admin", "2023-06-01T00:00:00Z"},
+			wantWarning: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			FeatureStatusCmd.SetOut(&out)
+			FeatureStatusCmd.SetErr(&errOut)
+			defer FeatureStatusCmd.SetOut(nil)
+			defer FeatureStatusCmd.SetErr(nil)
+
+			if err := listSupportStatus(FeatureStatusCmd, tc.records); err != nil {
+				t.Fatalf("got unwanted support status error: %v", err)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("support status output %q does not contain %q", out.String(), want)
+				}
+			}
+
+			warnIfWarrantyVoided(FeatureStatusCmd, tc.records)
+			if got := strings.Contains(errOut.String(), "Warning"); got != tc.wantWarning {
+				t.Errorf("got warning %q, want warning: %t", errOut.String(), tc.wantWarning)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// newReadinessClient returns a client for the cluster of the current context
+func newReadinessClient() (client.Client, error) {
+	scheme := runtime.NewScheme()
+	if err := corev1alpha2.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	restConfig, err := getCurrentClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	cl, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("could not create cluster client: %w", err)
+	}
+	return cl, nil
+}
+
+// getCurrentClusterConfig returns the rest config of the cluster of the current context
+func getCurrentClusterConfig() (*rest.Config, error) {
+	c, err := config.GetCurrentContext(types.TargetK8s)
+	if err != nil {
+		return nil, err
+	}
+	if c.ClusterOpts == nil {
+		return nil, fmt.Errorf("unexpected error: context options is nil")
+	}
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: c.ClusterOpts.Path},
+		&clientcmd.ConfigOverrides{
+			CurrentContext: c.ClusterOpts.Context,
+		}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("could not get rest config: %w", err)
+	}
+	return restConfig, nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/readiness/controller/pkg/conditions"
+	"github.com/vmware-tanzu/tanzu-framework/readiness/controller/pkg/readinessprovider"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+)
+
+var (
+	evaluateFilename     string
+	evaluateObjectFiles  []string
+	evaluateKubeconfig   string
+	evaluateOutputFormat string
+)
+
+// ReadinessEvaluateCmd is for evaluating a ReadinessProvider manifest without applying it.
+var ReadinessEvaluateCmd = &cobra.Command{
+	Use:   "evaluate -f <provider-manifest>",
+	Short: "Evaluate a readiness provider without applying it",
+	Args:  cobra.NoArgs,
+	Example: `
+	# Evaluate a readiness provider against the cluster of the current context.
+	tanzu readiness evaluate -f provider.yaml
+
+	# Evaluate a readiness provider against the cluster of a kubeconfig.
+	tanzu readiness evaluate -f provider.yaml --kubeconfig ~/.kube/config
+
+	# Evaluate a readiness provider offline, against the objects of a bundle.
+	tanzu readiness evaluate -f provider.yaml --objects bundle.yaml`,
+	RunE: readinessEvaluate,
+}
+
+func init() {
+	ReadinessEvaluateCmd.Flags().StringVarP(&evaluateFilename, "filename", "f", "", "Path to the ReadinessProvider manifest")
+	ReadinessEvaluateCmd.Flags().StringSliceVar(&evaluateObjectFiles, "objects", nil, "Paths to manifests of the objects to evaluate the provider against, instead of a cluster")
+	ReadinessEvaluateCmd.Flags().StringVar(&evaluateKubeconfig, "kubeconfig", "", "Path to the kubeconfig of the cluster to evaluate the provider against; defaults to the cluster of the current context")
+	ReadinessEvaluateCmd.Flags().StringVarP(&evaluateOutputFormat, "output", "o", "", "Output format (yaml|json|table)")
+	_ = ReadinessEvaluateCmd.MarkFlagRequired("filename")
+	ReadinessEvaluateCmd.MarkFlagsMutuallyExclusive("objects", "kubeconfig")
+}
+
+func readinessEvaluate(cmd *cobra.Command, _ []string) error {
+	provider, err := loadProvider(evaluateFilename)
+	if err != nil {
+		return err
+	}
+
+	var clients *conditions.Clients
+	if len(evaluateObjectFiles) > 0 {
+		clients, err = newOfflineClients(evaluateObjectFiles)
+	} else {
+		clients, err = newEvaluationClients(evaluateKubeconfig, provider.Spec.ServiceAccountRef)
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	// A single evaluation cannot tell a condition that is still progressing from a failing one, so failures are
+	// reported as they are instead of as inprogress within their progress deadline.
+	status, _ := readinessprovider.EvaluateProvider(ctx, logr.Discard(), conditions.NewDefaultRegistry(), clients, provider,
+		readinessprovider.WithoutProgressDeadlines())
+	return printEvaluation(cmd, provider.Name, &status)
+}
+
+// loadProvider reads a ReadinessProvider from its manifest
+func loadProvider(path string) (*corev1alpha2.ReadinessProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read provider manifest: %w", err)
+	}
+
+	provider := &corev1alpha2.ReadinessProvider{}
+	if err := yaml.UnmarshalStrict(data, provider); err != nil {
+		return nil, fmt.Errorf("could not parse provider manifest: %w", err)
+	}
+	if provider.Kind != "ReadinessProvider" || provider.APIVersion != corev1alpha2.GroupVersion.String() {
+		return nil, fmt.Errorf("%s is not a %s ReadinessProvider", path, corev1alpha2.GroupVersion.String())
+	}
+
+	// The status of the manifest is ignored, so that the provider is evaluated as it is now
+	provider.Status = corev1alpha2.ReadinessProviderStatus{}
+	return provider, nil
+}
+
+// loadObjects reads the objects of multi-document manifests
+func loadObjects(paths []string) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not read objects: %w", err)
+		}
+
+		decoder := utilyaml.NewYAMLOrJSONDecoder(f, 4096)
+		for {
+			obj := &unstructured.Unstructured{}
+			if err := decoder.Decode(&obj.Object); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				_ = f.Close()
+				return nil, fmt.Errorf("could not parse objects in %s: %w", path, err)
+			}
+			if len(obj.Object) > 0 {
+				objs = append(objs, obj)
+			}
+		}
+		_ = f.Close()
+	}
+	return objs, nil
+}
+
+// newOfflineClients returns the clients for evaluating conditions against the objects of the given manifests
+func newOfflineClients(paths []string) (*conditions.Clients, error) {
+	objs, err := loadObjects(paths)
+	if err != nil {
+		return nil, err
+	}
+	return conditions.NewClientsForObjects(objs...)
+}
+
+// newEvaluationClients returns the clients for evaluating conditions in the cluster of the kubeconfig, or of the
+// current context if no kubeconfig is given. Like the readiness controller, the clients act as the service account
+// of the provider if it has one, which requires permission to impersonate it.
+func newEvaluationClients(kubeconfig string, serviceAccountRef *corev1alpha2.ServiceAccountRef) (*conditions.Clients, error) {
+	var restConfig *rest.Config
+	var err error
+	if kubeconfig != "" {
+		restConfig, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	} else {
+		restConfig, err = getCurrentClusterConfig()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get rest config: %w", err)
+	}
+
+	if serviceAccountRef != nil {
+		restConfig.Impersonate.UserName = fmt.Sprintf("system:serviceaccount:%s:%s", serviceAccountRef.Namespace, serviceAccountRef.Name)
+	}
+	return conditions.NewClientsForConfig(restConfig)
+}
+
+// printEvaluation renders the state of the provider followed by the state of each of its conditions
+func printEvaluation(cmd *cobra.Command, name string, status *corev1alpha2.ReadinessProviderStatus) error {
+	if evaluateOutputFormat == string(component.JSONOutputType) || evaluateOutputFormat == string(component.YAMLOutputType) {
+		component.NewObjectWriter(cmd.OutOrStdout(), evaluateOutputFormat, status).Render()
+		return nil
+	}
+
+	t := component.NewOutputWriter(cmd.OutOrStdout(), evaluateOutputFormat, "NAME", "KIND", "STATE", "MESSAGE")
+	t.AddRow(name, "ReadinessProvider", status.State, status.Message)
+	for i := range status.Conditions {
+		condition := &status.Conditions[i]
+		t.AddRow("└─ "+condition.Name, "Condition", condition.State, condition.Message)
+	}
+	t.Render()
+
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+const testProvider = `apiVersion: core.tanzu.vmware.com/v1alpha2
+kind: ReadinessProvider
+metadata:
+  name: cert-manager-provider
+spec:
+  checkRefs:
+  - com.vmware.tanzu.certificate-management
+  conditions:
+  - name: webhook-config
+    resourceExistenceCondition:
+      apiVersion: v1
+      kind: ConfigMap
+      namespace: cert-manager
+      name: webhook-config
+  - name: webhook-service
+    progressDeadlineSeconds: 600
+    resourceExistenceCondition:
+      apiVersion: v1
+      kind: Service
+      namespace: cert-manager
+      name: cert-manager-webhook
+`
+
+const testObjects = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: webhook-config
+  namespace: cert-manager
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: webhook-tls
+  namespace: cert-manager
+`
+
+func TestEvaluateOffline(t *testing.T) {
+	dir := t.TempDir()
+	providerFile := filepath.Join(dir, "provider.yaml")
+	objectsFile := filepath.Join(dir, "objects.yaml")
+	if err := os.WriteFile(providerFile, []byte(testProvider), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(objectsFile, []byte(testObjects), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	evaluateFilename, evaluateObjectFiles, evaluateOutputFormat = providerFile, []string{objectsFile}, ""
+	out := &bytes.Buffer{}
+	cmd := &cobra.Command{}
+	cmd.SetOut(out)
+	if err := readinessEvaluate(cmd, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"cert-manager-provider",
+		"one or more condition(s) failed",
+		"webhook-config",
+		"resource found",
+		"webhook-service",
+		`no matches for kind "Service"`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestLoadProviderRejectsOtherKinds(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "readiness.yaml")
+	manifest := "apiVersion: core.tanzu.vmware.com/v1alpha2\nkind: Readiness\nmetadata:\n  name: baseline\n"
+	if err := os.WriteFile(path, []byte(manifest), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := loadProvider(path); err == nil || !strings.Contains(err.Error(), "is not a") {
+		t.Errorf("expected the manifest to be rejected, got %v", err)
+	}
+}
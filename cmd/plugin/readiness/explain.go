@@ -0,0 +1,171 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+)
+
+var explainOutputFormat string
+
+// ReadinessExplainCmd is for explaining why the checks of a Readiness are not ready.
+var ReadinessExplainCmd = &cobra.Command{
+	Use:   "explain <readiness> [check]",
+	Short: "Explain why the checks of a readiness are not ready",
+	Args:  cobra.RangeArgs(1, 2),
+	Example: `
+	# Explain why the checks of a readiness are not ready.
+	tanzu readiness explain my-org-baseline
+
+	# Explain why a check of a readiness is not ready.
+	tanzu readiness explain my-org-baseline com.vmware.tanzu.certificate-management`,
+	RunE: readinessExplain,
+}
+
+func init() {
+	ReadinessExplainCmd.Flags().StringVarP(&explainOutputFormat, "output", "o", "", "Output format (yaml|json|table)")
+}
+
+// CheckExplanation lists the reasons why a check is or is not ready.
+type CheckExplanation struct {
+	Check   string   `json:"check" yaml:"check"`
+	Ready   bool     `json:"ready" yaml:"ready"`
+	Reasons []string `json:"reasons" yaml:"reasons"`
+}
+
+func readinessExplain(cmd *cobra.Command, args []string) error {
+	cl, err := newReadinessClient()
+	if err != nil {
+		return fmt.Errorf("could not get readiness client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	tree, err := getReadinessTree(ctx, cl, args[0])
+	if err != nil {
+		return err
+	}
+
+	checkName := ""
+	if len(args) == 2 {
+		checkName = args[1]
+	}
+
+	explanations, err := explainChecks(tree, checkName)
+	if err != nil {
+		return err
+	}
+	return printExplanations(cmd, explanations)
+}
+
+// explainChecks explains the checks of the readiness that are not ready, or the given check if a name is provided
+func explainChecks(tree *ReadinessTree, checkName string) ([]CheckExplanation, error) {
+	explanations := []CheckExplanation{}
+
+	for i := range tree.Checks {
+		check := &tree.Checks[i]
+		if checkName != "" && check.Name != checkName {
+			continue
+		}
+		if checkName == "" && check.Ready {
+			continue
+		}
+		explanations = append(explanations, CheckExplanation{
+			Check:   check.Name,
+			Ready:   check.Ready,
+			Reasons: explainCheck(check),
+		})
+	}
+
+	if checkName != "" && len(explanations) == 0 {
+		return nil, fmt.Errorf("check %s is not defined in Readiness %s or has not been evaluated yet", checkName, tree.Name)
+	}
+	return explanations, nil
+}
+
+// explainCheck lists the reasons why the check is or is not ready
+func explainCheck(check *CheckNode) []string {
+	if check.Ready {
+		return []string{fmt.Sprintf("check is ready: %s", check.Message)}
+	}
+
+	var reasons []string
+
+	if check.DeadlineExceeded {
+		reasons = append(reasons, "check did not become ready within its progress deadline")
+	}
+
+	if check.Type == string(corev1alpha2.CompositeReadinessCheck) {
+		for _, blocking := range check.BlockingChecks {
+			reasons = append(reasons, fmt.Sprintf("referenced check %s is not ready", blocking))
+		}
+		if len(reasons) == 0 {
+			reasons = append(reasons, check.Message)
+		}
+		return reasons
+	}
+
+	if len(check.Providers) == 0 {
+		return append(reasons, "no ReadinessProvider satisfies the check; providers must reference the check in checkRefs and match the selectors of the check and the readiness")
+	}
+
+	if check.Message != "" {
+		reasons = append(reasons, check.Message)
+	}
+
+	for _, provider := range check.Providers {
+		if provider.State == string(corev1alpha2.ProviderSuccessState) {
+			continue
+		}
+
+		explained := false
+		for _, condition := range provider.Conditions {
+			if condition.State == string(corev1alpha2.ConditionSuccessState) {
+				continue
+			}
+			reason := fmt.Sprintf("provider %s: condition %s is %s: %s", provider.Name, condition.Name, condition.State, condition.Message)
+			if condition.Reason != "" {
+				reason = fmt.Sprintf("%s (%s)", reason, condition.Reason)
+			}
+			reasons = append(reasons, reason)
+			explained = true
+		}
+
+		if !explained {
+			reasons = append(reasons, fmt.Sprintf("provider %s is %s: %s", provider.Name, provider.State, provider.Message))
+		}
+	}
+
+	return reasons
+}
+
+// printExplanations renders one row per reason in table output
+func printExplanations(cmd *cobra.Command, explanations []CheckExplanation) error {
+	if explainOutputFormat == string(component.JSONOutputType) || explainOutputFormat == string(component.YAMLOutputType) {
+		component.NewObjectWriter(cmd.OutOrStdout(), explainOutputFormat, explanations).Render()
+		return nil
+	}
+
+	if len(explanations) == 0 {
+		cmd.Println("All checks are ready.")
+		return nil
+	}
+
+	t := component.NewOutputWriter(cmd.OutOrStdout(), explainOutputFormat, "CHECK", "STATE", "REASON")
+	for _, explanation := range explanations {
+		for _, reason := range explanation.Reasons {
+			t.AddRow(explanation.Check, readyState(explanation.Ready), reason)
+		}
+	}
+	t.Render()
+
+	return nil
+}
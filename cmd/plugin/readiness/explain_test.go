@@ -0,0 +1,67 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestExplainChecks(t *testing.T) {
+	tree, err := getReadinessTree(context.Background(), getTestClient(t), "baseline")
+	if err != nil {
+		t.Fatalf("get readiness tree: %v", err)
+	}
+
+	tests := []struct {
+		description string
+		check       string
+		wantChecks  []string
+		wantReasons map[string]string
+		wantErr     bool
+	}{
+		{
+			description: "explain all checks that are not ready",
+			wantChecks:  []string{"packages", "ingress", "platform"},
+			wantReasons: map[string]string{
+				"packages": "provider kapp: condition kapp-rollout is failure",
+				"ingress":  "no ReadinessProvider satisfies the check",
+				"platform": "referenced check packages is not ready",
+			},
+		},
+		{
+			description: "explain a check that is ready",
+			check:       "certs",
+			wantChecks:  []string{"certs"},
+			wantReasons: map[string]string{"certs": "check is ready"},
+		},
+		{
+			description: "explain a check that is not defined",
+			check:       "missing",
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := explainChecks(tree, tc.check)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tc.wantErr)
+			}
+			if len(got) != len(tc.wantChecks) {
+				t.Fatalf("got %+v, want checks %v", got, tc.wantChecks)
+			}
+
+			for i, explanation := range got {
+				if explanation.Check != tc.wantChecks[i] {
+					t.Errorf("got check %s, want %s", explanation.Check, tc.wantChecks[i])
+				}
+				if reasons := strings.Join(explanation.Reasons, "\n"); !strings.Contains(reasons, tc.wantReasons[explanation.Check]) {
+					t.Errorf("check %s: got reasons %q, want a reason containing %q", explanation.Check, reasons, tc.wantReasons[explanation.Check])
+				}
+			}
+		})
+	}
+}
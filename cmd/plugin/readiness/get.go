@@ -0,0 +1,139 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+)
+
+var getOutputFormat string
+
+// ReadinessGetCmd is for listing Readiness resources and showing the status of a Readiness.
+var ReadinessGetCmd = &cobra.Command{
+	Use:   "get [readiness]",
+	Short: "List readinesses or show the status of a readiness",
+	Args:  cobra.MaximumNArgs(1),
+	Example: `
+	# List the readinesses in the cluster.
+	tanzu readiness get
+
+	# Show the checks, providers and conditions of a readiness.
+	tanzu readiness get my-org-baseline`,
+	RunE: readinessGet,
+}
+
+func init() {
+	ReadinessGetCmd.Flags().StringVarP(&getOutputFormat, "output", "o", "", "Output format (yaml|json|table)")
+}
+
+// ReadinessInfo is a summary of the status of a Readiness.
+type ReadinessInfo struct {
+	Name        string
+	Ready       bool
+	ReadyChecks int
+	TotalChecks int
+	Message     string
+}
+
+func readinessGet(cmd *cobra.Command, args []string) error {
+	cl, err := newReadinessClient()
+	if err != nil {
+		return fmt.Errorf("could not get readiness client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	if len(args) == 0 {
+		infos, err := readinessInfoList(ctx, cl)
+		if err != nil {
+			return err
+		}
+		return listReadinesses(cmd, infos)
+	}
+
+	tree, err := getReadinessTree(ctx, cl, args[0])
+	if err != nil {
+		return err
+	}
+	return printReadinessTree(cmd, tree)
+}
+
+// readinessInfoList summarizes the status of the Readiness resources in the cluster
+func readinessInfoList(ctx context.Context, cl client.Client) ([]ReadinessInfo, error) {
+	readinesses := &corev1alpha2.ReadinessList{}
+	if err := cl.List(ctx, readinesses); err != nil {
+		return nil, fmt.Errorf("could not list Readinesses: %w", err)
+	}
+
+	infos := make([]ReadinessInfo, 0, len(readinesses.Items))
+	for i := range readinesses.Items {
+		info := ReadinessInfo{
+			Name:        readinesses.Items[i].Name,
+			Ready:       readinesses.Items[i].Status.Ready,
+			TotalChecks: len(readinesses.Items[i].Spec.Checks),
+			Message:     readyConditionMessage(&readinesses.Items[i]),
+		}
+		for _, checkStatus := range readinesses.Items[i].Status.CheckStatus {
+			if checkStatus.Ready {
+				info.ReadyChecks++
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// listReadinesses renders the summary of the Readiness resources
+func listReadinesses(cmd *cobra.Command, infos []ReadinessInfo) error {
+	t, err := component.NewOutputWriterWithSpinner(cmd.OutOrStdout(), getOutputFormat,
+		"Retrieving Readinesses...", true, "NAME", "READY", "CHECKS READY", "MESSAGE")
+	if err != nil {
+		return fmt.Errorf("could not get OutputWriterSpinner: %w", err)
+	}
+
+	for _, info := range infos {
+		t.AddRow(info.Name, info.Ready, fmt.Sprintf("%d/%d", info.ReadyChecks, info.TotalChecks), info.Message)
+	}
+	t.RenderWithSpinner()
+
+	return nil
+}
+
+// printReadinessTree renders the checks of a readiness with their providers and conditions.
+// The table output indents providers under their check and conditions under their provider.
+func printReadinessTree(cmd *cobra.Command, tree *ReadinessTree) error {
+	if getOutputFormat == string(component.JSONOutputType) || getOutputFormat == string(component.YAMLOutputType) {
+		component.NewObjectWriter(cmd.OutOrStdout(), getOutputFormat, tree).Render()
+		return nil
+	}
+
+	t := component.NewOutputWriter(cmd.OutOrStdout(), getOutputFormat, "NAME", "KIND", "STATE", "MESSAGE")
+	for _, check := range tree.Checks {
+		t.AddRow(check.Name, "Check", readyState(check.Ready), check.Message)
+		for _, provider := range check.Providers {
+			t.AddRow("└─ "+provider.Name, "ReadinessProvider", provider.State, provider.Message)
+			for _, condition := range provider.Conditions {
+				t.AddRow("   └─ "+condition.Name, "Condition", condition.State, condition.Message)
+			}
+		}
+	}
+	t.Render()
+
+	return nil
+}
+
+func readyState(ready bool) string {
+	if ready {
+		return "ready"
+	}
+	return "not ready"
+}
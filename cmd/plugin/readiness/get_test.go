@@ -0,0 +1,150 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+func getTestObjects() []client.Object {
+	return []client.Object{
+		&corev1alpha2.Readiness{
+			ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+			Spec: corev1alpha2.ReadinessSpec{Checks: []corev1alpha2.Check{
+				{Name: "certs", Type: corev1alpha2.BasicReadinessCheck},
+				{Name: "packages", Type: corev1alpha2.BasicReadinessCheck},
+				{Name: "ingress", Type: corev1alpha2.BasicReadinessCheck},
+				{Name: "platform", Type: corev1alpha2.CompositeReadinessCheck, CheckRefs: []string{"certs", "packages"}},
+			}},
+			Status: corev1alpha2.ReadinessStatus{
+				Ready: false,
+				CheckStatus: []corev1alpha2.CheckStatus{
+					{Name: "certs", Ready: true, Message: "1 of 1 provider(s) active; at least 1 required",
+						Providers: []corev1alpha2.Provider{{Name: "cert-manager", IsActive: true, Counted: true}}},
+					{Name: "packages", Ready: false, Message: "0 of 1 provider(s) active; at least 1 required",
+						Providers: []corev1alpha2.Provider{{Name: "kapp", IsActive: false}}},
+					{Name: "ingress", Ready: false, Providers: []corev1alpha2.Provider{}},
+					{Name: "platform", Ready: false, BlockingChecks: []string{"packages"}},
+				},
+				Conditions: []metav1.Condition{
+					{Type: corev1alpha2.ReadyConditionType, Status: metav1.ConditionFalse, Reason: "ChecksNotReady", Message: "check(s) not ready: packages, ingress, platform"},
+				},
+			},
+		},
+		&corev1alpha2.Readiness{
+			ObjectMeta: metav1.ObjectMeta{Name: "empty"},
+			Status:     corev1alpha2.ReadinessStatus{Ready: true},
+		},
+		&corev1alpha2.ReadinessProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: "cert-manager"},
+			Spec:       corev1alpha2.ReadinessProviderSpec{CheckRefs: []string{"certs"}},
+			Status: corev1alpha2.ReadinessProviderStatus{
+				State: corev1alpha2.ProviderSuccessState,
+				Conditions: []corev1alpha2.ReadinessConditionStatus{
+					{Name: "certificate-crd", State: corev1alpha2.ConditionSuccessState},
+				},
+			},
+		},
+		&corev1alpha2.ReadinessProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: "kapp"},
+			Spec:       corev1alpha2.ReadinessProviderSpec{CheckRefs: []string{"packages"}},
+			Status: corev1alpha2.ReadinessProviderStatus{
+				State:   corev1alpha2.ProviderFailureState,
+				Message: "one or more condition(s) failed",
+				Conditions: []corev1alpha2.ReadinessConditionStatus{
+					{Name: "kapp-crd", State: corev1alpha2.ConditionSuccessState},
+					{Name: "kapp-rollout", State: corev1alpha2.ConditionFailureState, Reason: corev1alpha2.ProgressDeadlineExceededReason,
+						Message: "progress deadline of 5m0s exceeded: 0 of 1 replica(s) available"},
+				},
+			},
+		},
+	}
+}
+
+func getTestClient(t *testing.T) client.Client {
+	s := runtime.NewScheme()
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("add core scheme: (%v)", err)
+	}
+	return crclient.NewClientBuilder().WithScheme(s).WithObjects(getTestObjects()...).Build()
+}
+
+func TestReadinessInfoList(t *testing.T) {
+	infos, err := readinessInfoList(context.Background(), getTestClient(t))
+	if err != nil {
+		t.Fatalf("list readiness info: %v", err)
+	}
+
+	want := map[string]ReadinessInfo{
+		"baseline": {Name: "baseline", Ready: false, ReadyChecks: 1, TotalChecks: 4, Message: "check(s) not ready: packages, ingress, platform"},
+		"empty":    {Name: "empty", Ready: true},
+	}
+	if len(infos) != len(want) {
+		t.Fatalf("got %d readinesses, want %d", len(infos), len(want))
+	}
+	for _, info := range infos {
+		if info != want[info.Name] {
+			t.Errorf("got %+v, want %+v", info, want[info.Name])
+		}
+	}
+}
+
+func TestGetReadinessTree(t *testing.T) {
+	tree, err := getReadinessTree(context.Background(), getTestClient(t), "baseline")
+	if err != nil {
+		t.Fatalf("get readiness tree: %v", err)
+	}
+
+	if len(tree.Checks) != 4 {
+		t.Fatalf("got %d checks, want 4", len(tree.Checks))
+	}
+
+	packages := tree.Checks[1]
+	if packages.Name != "packages" || packages.Type != "basic" || len(packages.Providers) != 1 {
+		t.Fatalf("unexpected packages check: %+v", packages)
+	}
+	if provider := packages.Providers[0]; provider.Name != "kapp" || provider.State != "failure" || len(provider.Conditions) != 2 {
+		t.Errorf("unexpected kapp provider: %+v", provider)
+	}
+
+	if _, err := getReadinessTree(context.Background(), getTestClient(t), "missing"); err == nil {
+		t.Errorf("expected an error for a missing readiness")
+	}
+}
+
+func TestPrintReadinessTree(t *testing.T) {
+	tree, err := getReadinessTree(context.Background(), getTestClient(t), "baseline")
+	if err != nil {
+		t.Fatalf("get readiness tree: %v", err)
+	}
+
+	for _, format := range []string{"", "json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			getOutputFormat = format
+			defer func() { getOutputFormat = "" }()
+
+			out := &bytes.Buffer{}
+			cmd := &cobra.Command{}
+			cmd.SetOut(out)
+
+			if err := printReadinessTree(cmd, tree); err != nil {
+				t.Fatalf("print readiness tree: %v", err)
+			}
+			if !strings.Contains(out.String(), "kapp-rollout") {
+				t.Errorf("expected output to contain the kapp-rollout condition, got %s", out.String())
+			}
+		})
+	}
+}
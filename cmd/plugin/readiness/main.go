@@ -0,0 +1,44 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/aunum/log"
+
+	plugintypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin/buildinfo"
+)
+
+var descriptor = plugin.PluginDescriptor{
+	Name:        "readiness",
+	Description: "Inspect the readiness of the cluster",
+	Target:      plugintypes.TargetK8s,
+	Version:     buildinfo.Version,
+	Group:       plugin.RunCmdGroup,
+	BuildSHA:    buildinfo.SHA,
+}
+
+const contextTimeout = 60 * time.Second
+
+func main() {
+	p, err := plugin.NewPlugin(&descriptor)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	p.AddCommands(
+		ReadinessGetCmd,
+		ReadinessWaitCmd,
+		ReadinessExplainCmd,
+		ReadinessEvaluateCmd,
+	)
+
+	if err := p.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
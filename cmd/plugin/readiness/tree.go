@@ -0,0 +1,135 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// ReadinessTree is the status of a Readiness, with the status of the providers of each check
+type ReadinessTree struct {
+	Name    string      `json:"name" yaml:"name"`
+	Ready   bool        `json:"ready" yaml:"ready"`
+	Message string      `json:"message,omitempty" yaml:"message,omitempty"`
+	Checks  []CheckNode `json:"checks" yaml:"checks"`
+}
+
+// CheckNode is the status of a check and of its providers
+type CheckNode struct {
+	Name             string         `json:"name" yaml:"name"`
+	Type             string         `json:"type" yaml:"type"`
+	Ready            bool           `json:"ready" yaml:"ready"`
+	Message          string         `json:"message,omitempty" yaml:"message,omitempty"`
+	DeadlineExceeded bool           `json:"deadlineExceeded,omitempty" yaml:"deadlineExceeded,omitempty"`
+	BlockingChecks   []string       `json:"blockingChecks,omitempty" yaml:"blockingChecks,omitempty"`
+	Providers        []ProviderNode `json:"providers,omitempty" yaml:"providers,omitempty"`
+}
+
+// ProviderNode is the status of a provider of a check and of its conditions
+type ProviderNode struct {
+	Name       string          `json:"name" yaml:"name"`
+	State      string          `json:"state" yaml:"state"`
+	Counted    bool            `json:"counted" yaml:"counted"`
+	Message    string          `json:"message,omitempty" yaml:"message,omitempty"`
+	Conditions []ConditionNode `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+}
+
+// ConditionNode is the status of a condition of a provider
+type ConditionNode struct {
+	Name    string `json:"name" yaml:"name"`
+	State   string `json:"state" yaml:"state"`
+	Reason  string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// providerStateUnknown is reported for providers listed in the status of a check that no longer exist
+const providerStateUnknown = "unknown"
+
+// getReadinessTree fetches the readiness and the providers of its checks
+func getReadinessTree(ctx context.Context, cl client.Client, name string) (*ReadinessTree, error) {
+	readiness := &corev1alpha2.Readiness{}
+	if err := cl.Get(ctx, client.ObjectKey{Name: name}, readiness); err != nil {
+		return nil, fmt.Errorf("could not get Readiness %s: %w", name, err)
+	}
+
+	providers := &corev1alpha2.ReadinessProviderList{}
+	if err := cl.List(ctx, providers); err != nil {
+		return nil, fmt.Errorf("could not list ReadinessProviders: %w", err)
+	}
+
+	return buildReadinessTree(readiness, providers.Items), nil
+}
+
+// buildReadinessTree combines the status of the readiness with the status of the given providers
+func buildReadinessTree(readiness *corev1alpha2.Readiness, providers []corev1alpha2.ReadinessProvider) *ReadinessTree {
+	providersByName := make(map[string]*corev1alpha2.ReadinessProvider, len(providers))
+	for i := range providers {
+		providersByName[providers[i].Name] = &providers[i]
+	}
+
+	checkTypes := make(map[string]corev1alpha2.ReadinessCheckType, len(readiness.Spec.Checks))
+	for _, check := range readiness.Spec.Checks {
+		checkTypes[check.Name] = check.Type
+	}
+
+	tree := &ReadinessTree{
+		Name:    readiness.Name,
+		Ready:   readiness.Status.Ready,
+		Message: readyConditionMessage(readiness),
+		Checks:  []CheckNode{},
+	}
+
+	for _, checkStatus := range readiness.Status.CheckStatus {
+		checkNode := CheckNode{
+			Name:             checkStatus.Name,
+			Type:             string(checkTypes[checkStatus.Name]),
+			Ready:            checkStatus.Ready,
+			Message:          checkStatus.Message,
+			DeadlineExceeded: checkStatus.DeadlineExceeded,
+			BlockingChecks:   checkStatus.BlockingChecks,
+		}
+
+		for _, providerStatus := range checkStatus.Providers {
+			providerNode := ProviderNode{
+				Name:    providerStatus.Name,
+				State:   providerStateUnknown,
+				Counted: providerStatus.Counted,
+			}
+
+			if provider, ok := providersByName[providerStatus.Name]; ok {
+				providerNode.State = string(provider.Status.State)
+				providerNode.Message = provider.Status.Message
+				for _, conditionStatus := range provider.Status.Conditions {
+					providerNode.Conditions = append(providerNode.Conditions, ConditionNode{
+						Name:    conditionStatus.Name,
+						State:   string(conditionStatus.State),
+						Reason:  conditionStatus.Reason,
+						Message: conditionStatus.Message,
+					})
+				}
+			}
+
+			checkNode.Providers = append(checkNode.Providers, providerNode)
+		}
+
+		tree.Checks = append(tree.Checks, checkNode)
+	}
+
+	return tree
+}
+
+// readyConditionMessage returns the message of the Ready condition of the readiness, if any
+func readyConditionMessage(readiness *corev1alpha2.Readiness) string {
+	for _, condition := range readiness.Status.Conditions {
+		if condition.Type == corev1alpha2.ReadyConditionType {
+			return condition.Message
+		}
+	}
+	return ""
+}
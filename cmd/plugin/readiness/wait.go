@@ -0,0 +1,90 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+const (
+	waitForReady = "ready"
+	pollInterval = 2 * time.Second
+)
+
+var (
+	waitFor     string
+	waitTimeout time.Duration
+)
+
+// ReadinessWaitCmd is for waiting until a Readiness is ready.
+var ReadinessWaitCmd = &cobra.Command{
+	Use:   "wait <readiness>",
+	Short: "Wait until a readiness is ready",
+	Args:  cobra.ExactArgs(1),
+	Example: `
+	# Wait up to 10 minutes for a readiness to be ready.
+	tanzu readiness wait my-org-baseline --for=ready --timeout=10m`,
+	RunE: readinessWait,
+}
+
+func init() {
+	ReadinessWaitCmd.Flags().StringVar(&waitFor, "for", waitForReady, "Condition to wait for (ready)")
+	ReadinessWaitCmd.Flags().DurationVar(&waitTimeout, "timeout", 5*time.Minute, "Time to wait before giving up")
+}
+
+func readinessWait(cmd *cobra.Command, args []string) error {
+	if waitFor != waitForReady {
+		return fmt.Errorf("unsupported condition %q, only %q is supported", waitFor, waitForReady)
+	}
+
+	cl, err := newReadinessClient()
+	if err != nil {
+		return fmt.Errorf("could not get readiness client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), waitTimeout)
+	defer cancel()
+
+	if err := waitForReadiness(ctx, cl, args[0], pollInterval); err != nil {
+		return err
+	}
+
+	cmd.Printf("Readiness %s is ready.\n", args[0])
+	return nil
+}
+
+// waitForReadiness polls the readiness until it is ready or the context is done.
+// Errors getting the readiness are retried, so that the readiness may be created while waiting.
+func waitForReadiness(ctx context.Context, cl client.Client, name string, interval time.Duration) error {
+	var lastErr error
+	message := ""
+
+	err := wait.PollImmediateUntilWithContext(ctx, interval, func(ctx context.Context) (bool, error) {
+		readiness := &corev1alpha2.Readiness{}
+		if lastErr = cl.Get(ctx, client.ObjectKey{Name: name}, readiness); lastErr != nil {
+			return false, nil
+		}
+		message = readyConditionMessage(readiness)
+		return readiness.Status.Ready, nil
+	})
+	if err == nil {
+		return nil
+	}
+
+	if lastErr != nil {
+		return fmt.Errorf("timed out waiting for Readiness %s: %w", name, lastErr)
+	}
+	if message != "" {
+		return fmt.Errorf("timed out waiting for Readiness %s to be ready: %s", name, message)
+	}
+	return fmt.Errorf("timed out waiting for Readiness %s to be ready", name)
+}
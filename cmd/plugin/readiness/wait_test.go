@@ -0,0 +1,53 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitForReadiness(t *testing.T) {
+	tests := []struct {
+		description string
+		readiness   string
+		wantErr     string
+	}{
+		{
+			description: "ready readiness returns immediately",
+			readiness:   "empty",
+		},
+		{
+			description: "readiness that is not ready times out with the reason",
+			readiness:   "baseline",
+			wantErr:     "check(s) not ready: packages, ingress, platform",
+		},
+		{
+			description: "missing readiness times out with the error",
+			readiness:   "missing",
+			wantErr:     "not found",
+		},
+	}
+
+	cl := getTestClient(t)
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			err := waitForReadiness(ctx, cl, tc.readiness, 10*time.Millisecond)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("got unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("got error %v, want an error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package featureflags provides an informer-backed API for controllers to check the activation of features and to be
+// notified when it changes.
+package featureflags
@@ -0,0 +1,175 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featureflags
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// Feature is the handle of a feature, which is the name of its Feature resource. Typed handles are generated from
+// +tanzu:feature markers by the featureflags generator of the codegen plugin.
+type Feature string
+
+// Flags serves the activation of features from an informer on Feature resources, so that checking a feature does not
+// call the API server. Features are deactivated until their Feature resource is observed.
+type Flags struct {
+	hasSynced func() bool
+	// done is closed when the context Flags were created with ends, which stops the sources
+	done <-chan struct{}
+
+	mu          sync.RWMutex
+	activated   map[Feature]bool
+	callbacks   map[Feature][]func(activated bool)
+	subscribers []subscriber
+}
+
+// subscriber receives an event when the activation of one of its features changes. The changed Feature resources are
+// queued, so that the informer is not blocked until the controller consuming the source is started, and sent in order.
+type subscriber struct {
+	features map[Feature]bool
+	queue    workqueue.Interface
+	events   chan event.GenericEvent
+}
+
+// send sends the queued Feature resources to the events channel, one at a time and in the order they were queued,
+// until done is closed
+func (s *subscriber) send(done <-chan struct{}) {
+	for {
+		item, shutdown := s.queue.Get()
+		if shutdown {
+			return
+		}
+		select {
+		case s.events <- event.GenericEvent{Object: item.(*corev1alpha2.Feature)}:
+			s.queue.Done(item)
+		case <-done:
+			s.queue.Done(item)
+			return
+		}
+	}
+}
+
+// New returns Flags served from the informer on Feature resources of the cache, such as the cache of a
+// controller-runtime Manager. The scheme of the cache must include the core v1alpha2 types. The sources of the Flags
+// stop sending events once ctx is done.
+func New(ctx context.Context, c cache.Cache) (*Flags, error) {
+	informer, err := c.GetInformer(ctx, &corev1alpha2.Feature{})
+	if err != nil {
+		return nil, fmt.Errorf("could not get Feature informer: %w", err)
+	}
+
+	f := &Flags{
+		hasSynced: informer.HasSynced,
+		done:      ctx.Done(),
+		activated: map[Feature]bool{},
+		callbacks: map[Feature][]func(bool){},
+	}
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			f.observe(obj, false)
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			f.observe(obj, false)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			f.observe(obj, true)
+		},
+	})
+	return f, nil
+}
+
+// HasSynced returns true once the activation of all the features has been observed.
+func (f *Flags) HasSynced() bool {
+	return f.hasSynced()
+}
+
+// Enabled returns true if the feature is activated.
+func (f *Flags) Enabled(name Feature) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.activated[name]
+}
+
+// OnChange registers a callback that is called with the new activation of the feature whenever it changes. Callbacks
+// are called from the informer, so they must not block.
+func (f *Flags) OnChange(name Feature, callback func(activated bool)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.callbacks[name] = append(f.callbacks[name], callback)
+}
+
+// Source returns a controller-runtime event source that emits a generic event with the Feature resource whenever the
+// activation of one of the named features changes, or of any feature if no names are given. It lets controllers
+// requeue their resources when the features they depend on are toggled, for example:
+//
+//	ctrl.NewControllerManagedBy(mgr).
+//		For(&MyResource{}).
+//		Watches(flags.Source(MyFeature), handler.EnqueueRequestsFromMapFunc(r.toAllMyResources)).
+//		Complete(r)
+func (f *Flags) Source(names ...Feature) source.Source {
+	s := subscriber{features: map[Feature]bool{}, queue: workqueue.New(), events: make(chan event.GenericEvent)}
+	for _, name := range names {
+		s.features[name] = true
+	}
+	go s.send(f.done)
+	go func() {
+		<-f.done
+		s.queue.ShutDown()
+	}()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subscribers = append(f.subscribers, s)
+	return &source.Channel{Source: s.events}
+}
+
+// observe records the activation of the observed Feature resource, and notifies the callbacks and sources if it
+// changed. A deleted feature is deactivated.
+func (f *Flags) observe(obj interface{}, deleted bool) {
+	feature, ok := obj.(*corev1alpha2.Feature)
+	if !ok {
+		return
+	}
+	name := Feature(feature.Name)
+	activated := feature.Status.Activated && !deleted
+
+	f.mu.Lock()
+	changed := f.activated[name] != activated
+	if activated {
+		f.activated[name] = true
+	} else {
+		delete(f.activated, name)
+	}
+	var callbacks []func(bool)
+	var subscribers []subscriber
+	if changed {
+		callbacks = append(callbacks, f.callbacks[name]...)
+		for _, s := range f.subscribers {
+			if len(s.features) == 0 || s.features[name] {
+				subscribers = append(subscribers, s)
+			}
+		}
+	}
+	f.mu.Unlock()
+
+	for _, callback := range callbacks {
+		callback(activated)
+	}
+	for _, s := range subscribers {
+		s.queue.Add(feature)
+	}
+}
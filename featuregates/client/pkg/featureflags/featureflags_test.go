@@ -0,0 +1,224 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featureflags
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+func feature(name string, activated bool) *corev1alpha2.Feature {
+	return &corev1alpha2.Feature{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     corev1alpha2.FeatureStatus{Activated: activated},
+	}
+}
+
+func TestFlags(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	informers := &informertest.FakeInformers{Scheme: scheme}
+	flags, err := New(context.Background(), informers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	informer, err := informers.FakeInformerFor(&corev1alpha2.Feature{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var changes []bool
+	flags.OnChange("foo", func(activated bool) {
+		changes = append(changes, activated)
+	})
+	src, ok := flags.Source("foo").(*source.Channel)
+	if !ok {
+		t.Fatalf("expected a channel source")
+	}
+
+	if flags.Enabled("foo") {
+		t.Errorf("expected foo to be deactivated before it is observed")
+	}
+
+	informer.Add(feature("foo", true))
+	informer.Add(feature("bar", true))
+	if !flags.Enabled("foo") || !flags.Enabled("bar") {
+		t.Errorf("expected foo and bar to be activated")
+	}
+	expectEvent(t, src.Source, "foo")
+
+	// An update that does not change the activation is not notified
+	informer.Update(feature("foo", true), feature("foo", true))
+	informer.Update(feature("bar", true), feature("bar", false))
+	informer.Update(feature("foo", true), feature("foo", false))
+	if flags.Enabled("foo") || flags.Enabled("bar") {
+		t.Errorf("expected foo and bar to be deactivated")
+	}
+	expectEvent(t, src.Source, "foo")
+
+	informer.Add(feature("foo", true))
+	informer.Delete(feature("foo", true))
+	if flags.Enabled("foo") {
+		t.Errorf("expected deleted foo to be deactivated")
+	}
+
+	want := []bool{true, false, true, false}
+	if len(changes) != len(want) {
+		t.Fatalf("got changes %v, want %v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("got changes %v, want %v", changes, want)
+		}
+	}
+}
+
+func TestSourceSendsQueuedEventsInOrder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	informers := &informertest.FakeInformers{Scheme: scheme}
+	flags, err := New(context.Background(), informers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	informer, err := informers.FakeInformerFor(&corev1alpha2.Feature{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, ok := flags.Source().(*source.Channel)
+	if !ok {
+		t.Fatalf("expected a channel source")
+	}
+
+	// The changes are observed before the source is consumed, so the informer must not block on it
+	const toggles = 100
+	for i := 0; i < toggles; i++ {
+		informer.Update(feature("foo", i%2 == 1), feature("foo", i%2 == 0))
+	}
+
+	for i := 0; i < toggles; i++ {
+		select {
+		case e := <-src.Source:
+			if activated := e.Object.(*corev1alpha2.Feature).Status.Activated; activated != (i%2 == 0) {
+				t.Fatalf("got event %d with activated=%t, want events in the order of the changes", i, activated)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected %d events, got %d", toggles, i)
+		}
+	}
+}
+
+func TestSourceStopsWithContext(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	informers := &informertest.FakeInformers{Scheme: scheme}
+	ctx, cancel := context.WithCancel(context.Background())
+	flags, err := New(ctx, informers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	informer, err := informers.FakeInformerFor(&corev1alpha2.Feature{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, ok := flags.Source("foo").(*source.Channel)
+	if !ok {
+		t.Fatalf("expected a channel source")
+	}
+
+	informer.Add(feature("foo", true))
+	expectEvent(t, src.Source, "foo")
+
+	cancel()
+	queue := flags.subscribers[0].queue
+	deadline := time.Now().Add(time.Second)
+	for !queue.ShuttingDown() {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the queue of the source to be shut down once the context ended")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Changes are no longer queued once the context ended
+	informer.Update(feature("foo", true), feature("foo", false))
+	if queue.Len() != 0 {
+		t.Errorf("expected no queued changes after the context ended, got %d", queue.Len())
+	}
+}
+
+func expectEvent(t *testing.T, events <-chan event.GenericEvent, name string) {
+	t.Helper()
+	select {
+	case e := <-events:
+		if e.Object.GetName() != name {
+			t.Errorf("got event for %s, want %s", e.Object.GetName(), name)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("expected an event for %s", name)
+	}
+}
+
+func TestActivationChanged(t *testing.T) {
+	p := ActivationChanged("foo")
+
+	testCases := []struct {
+		description string
+		got         bool
+		want        bool
+	}{
+		{
+			description: "activated feature created",
+			got:         p.Create(event.CreateEvent{Object: feature("foo", true)}),
+			want:        true,
+		},
+		{
+			description: "deactivated feature created",
+			got:         p.Create(event.CreateEvent{Object: feature("foo", false)}),
+			want:        false,
+		},
+		{
+			description: "feature activated",
+			got:         p.Update(event.UpdateEvent{ObjectOld: feature("foo", false), ObjectNew: feature("foo", true)}),
+			want:        true,
+		},
+		{
+			description: "feature updated without activation change",
+			got:         p.Update(event.UpdateEvent{ObjectOld: feature("foo", true), ObjectNew: feature("foo", true)}),
+			want:        false,
+		},
+		{
+			description: "other feature activated",
+			got:         p.Update(event.UpdateEvent{ObjectOld: feature("bar", false), ObjectNew: feature("bar", true)}),
+			want:        false,
+		},
+		{
+			description: "activated feature deleted",
+			got:         p.Delete(event.DeleteEvent{Object: feature("foo", true)}),
+			want:        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if tc.got != tc.want {
+				t.Errorf("got %t, want %t", tc.got, tc.want)
+			}
+		})
+	}
+}
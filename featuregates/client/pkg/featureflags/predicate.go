@@ -0,0 +1,52 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featureflags
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// ActivationChanged returns a predicate for the events of Feature resources that passes the events changing the
+// activation of one of the named features, or of any feature if no names are given. Creating or deleting an activated
+// feature changes its activation. It lets controllers watch the Feature resources they depend on, for example:
+//
+//	ctrl.NewControllerManagedBy(mgr).
+//		For(&MyResource{}).
+//		Watches(&source.Kind{Type: &corev1alpha2.Feature{}}, handler.EnqueueRequestsFromMapFunc(r.toAllMyResources),
+//			builder.WithPredicates(featureflags.ActivationChanged(MyFeature))).
+//		Complete(r)
+func ActivationChanged(names ...Feature) predicate.Predicate {
+	selected := map[Feature]bool{}
+	for _, name := range names {
+		selected[name] = true
+	}
+	isSelected := func(o client.Object) bool {
+		return len(selected) == 0 || selected[Feature(o.GetName())]
+	}
+
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isSelected(e.Object) && isActivated(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isSelected(e.ObjectNew) && isActivated(e.ObjectOld) != isActivated(e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return isSelected(e.Object) && isActivated(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return isSelected(e.Object)
+		},
+	}
+}
+
+// isActivated returns true if the object is an activated Feature
+func isActivated(o client.Object) bool {
+	feature, ok := o.(*corev1alpha2.Feature)
+	return ok && feature.Status.Activated
+}
@@ -6,6 +6,7 @@ package featuregateclient
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -121,11 +122,53 @@ func (f *FeatureGateClient) GetFeatureList(ctx context.Context) (*corev1alpha2.F
 	return features, nil
 }
 
+// GetFeaturePolicyList fetches all featurepolicies on the cluster.
+func (f *FeatureGateClient) GetFeaturePolicyList(ctx context.Context) (*corev1alpha2.FeaturePolicyList, error) {
+	policies := &corev1alpha2.FeaturePolicyList{}
+	err := f.crClient.List(ctx, policies)
+	if err != nil {
+		return nil, fmt.Errorf("could not get featurepolicies on cluster: %w", err)
+	}
+	return policies, nil
+}
+
+// GetVoidedWarranties returns the records of the features that permanently voided all support guarantees for the
+// environment. Besides the records of the SupportStatus, features whose support warranty is voided in a FeatureGate but
+// not recorded yet are included, without an actor and time. The environment is supported if there are none.
+func (f *FeatureGateClient) GetVoidedWarranties(ctx context.Context) ([]corev1alpha2.WarrantyVoidRecord, error) {
+	supportStatus := &corev1alpha2.SupportStatus{}
+	err := f.crClient.Get(ctx, client.ObjectKey{Name: corev1alpha2.SupportStatusName}, supportStatus)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("could not get supportstatus %s: %w", corev1alpha2.SupportStatusName, err)
+	}
+
+	gates, err := f.GetFeatureGateList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get FeatureGateList: %w", err)
+	}
+
+	records := supportStatus.Spec.WarrantyVoids
+	recorded := map[string]bool{}
+	for _, record := range records {
+		recorded[record.FeatureGate+"/"+record.Feature] = true
+	}
+	for i := range gates.Items {
+		for _, featRef := range gates.Items[i].Spec.Features {
+			if featRef.PermanentlyVoidAllSupportGuarantees && !recorded[gates.Items[i].Name+"/"+featRef.Name] {
+				records = append(records, corev1alpha2.WarrantyVoidRecord{Feature: featRef.Name, FeatureGate: gates.Items[i].Name})
+			}
+		}
+	}
+	return records, nil
+}
+
 // ActivateFeature activates a Feature if it passes validation and warranty checks.
 // Warning: Before sending `true` via the warrantyVoidAllowed function argument, ensure
 // explicit user awareness and approval if activating a Feature will cause the support
 // warranty to be void. Once warranty is void, it is is permanent for the environment.
-func (f *FeatureGateClient) ActivateFeature(ctx context.Context, featureName string, warrantyVoidAllowed bool) error {
+func (f *FeatureGateClient) ActivateFeature(ctx context.Context, featureName string, warrantyVoidAllowed bool, options ...ActivationOption) error {
+	reason := newActivationOptions(options...).reason
+
 	// A Feature must exist in the cluster to be activated.
 	feature, err := f.GetFeature(ctx, featureName)
 	if err != nil {
@@ -137,6 +180,11 @@ func (f *FeatureGateClient) ActivateFeature(ctx context.Context, featureName str
 		return fmt.Errorf("could not get FeatureGateList: %w", err)
 	}
 
+	policies, err := f.GetFeaturePolicyList(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get FeaturePolicyList: %w", err)
+	}
+
 	gateName, featRef := FeatureRefFromGateList(gates, featureName)
 
 	if featRef.Activate {
@@ -144,7 +192,7 @@ func (f *FeatureGateClient) ActivateFeature(ctx context.Context, featureName str
 		return nil
 	}
 
-	if err := validateFeatureActivationToggle(gates, feature); err != nil {
+	if err := validateFeatureActivationToggle(gates, feature, policies.Items); err != nil {
 		return err
 	}
 
@@ -153,30 +201,26 @@ func (f *FeatureGateClient) ActivateFeature(ctx context.Context, featureName str
 		return err
 	}
 
-	ok, err := setVoidWarrantyChecksPass(featRef, feature, warrantyVoidAllowed)
+	ok, err := setVoidWarrantyChecksPass(featRef, feature, policies.Items, warrantyVoidAllowed)
 	if err != nil {
 		return err
 	}
 	if ok {
-		if err := f.setVoidWarranty(ctx, gate, feature.Name); err != nil {
+		if err := f.setVoidWarranty(ctx, gate, feature.Name, reason); err != nil {
 			return err
 		}
 	}
 
-	return f.setActivated(ctx, gate, featureName)
+	return f.setActivated(ctx, gate, featureName, reason)
 }
 
 // FeatureRefFromGateList finds the requested Feature from a list of featuregates. If found,
-// the name of the FeatureGate and the FeatureReference is returned.
+// the name of the FeatureGate and the FeatureReference is returned. If several FeatureGates
+// reference the Feature, the FeatureGate with the highest priority is returned, as it decides
+// the activation of the Feature.
 func FeatureRefFromGateList(gates *corev1alpha2.FeatureGateList, featureName string) (string, corev1alpha2.FeatureReference) {
-	for i := range gates.Items {
-		for _, featRef := range gates.Items[i].Spec.Features {
-			if featureName == featRef.Name {
-				return gates.Items[i].Name, featRef
-			}
-		}
-	}
-	return "", corev1alpha2.FeatureReference{}
+	gateName, featRef, _ := corev1alpha2.GetWinningFeatureReference(gates.Items, featureName)
+	return gateName, featRef
 }
 
 // setVoidWarrantyChecksPass will check if voiding the support warranty will happen for a Feature and if so,
@@ -193,9 +237,9 @@ func FeatureRefFromGateList(gates *corev1alpha2.FeatureGateList, featureName str
 //   - Warranty will be voided, but user does not give permission to do so.
 //   - The new activation setting is the same as the default. Another way to say this is that the old
 //     activation setting is different than the default (policy.DefaultActivation != ref.Activate)
-func setVoidWarrantyChecksPass(ref corev1alpha2.FeatureReference, feature *corev1alpha2.Feature, warrantyVoidAllowed bool) (bool, error) {
+func setVoidWarrantyChecksPass(ref corev1alpha2.FeatureReference, feature *corev1alpha2.Feature, policies []corev1alpha2.FeaturePolicy, warrantyVoidAllowed bool) (bool, error) {
 	stability := feature.Spec.Stability
-	policy := corev1alpha2.GetPolicyForStabilityLevel(stability)
+	policy := corev1alpha2.GetPolicyForStabilityLevel(stability, policies...)
 
 	// Check if toggling activation state will void the warranty if not already voided.
 	if policy.VoidsWarranty && !ref.PermanentlyVoidAllSupportGuarantees && policy.DefaultActivation == ref.Activate {
@@ -211,10 +255,11 @@ func setVoidWarrantyChecksPass(ref corev1alpha2.FeatureReference, feature *corev
 	return false, nil
 }
 
-func (f *FeatureGateClient) setVoidWarranty(ctx context.Context, gate *corev1alpha2.FeatureGate, featureName string) error {
+func (f *FeatureGateClient) setVoidWarranty(ctx context.Context, gate *corev1alpha2.FeatureGate, featureName, reason string) error {
 	for i, featureRef := range gate.Spec.Features {
 		if featureRef.Name == featureName {
 			gate.Spec.Features[i].PermanentlyVoidAllSupportGuarantees = true
+			setActivationChangeReason(gate, reason)
 			return f.crClient.Update(ctx, gate)
 		}
 	}
@@ -222,10 +267,11 @@ func (f *FeatureGateClient) setVoidWarranty(ctx context.Context, gate *corev1alp
 }
 
 // setActivated sets the Feature to activate in FeatureGate
-func (f *FeatureGateClient) setActivated(ctx context.Context, gate *corev1alpha2.FeatureGate, featureName string) error {
+func (f *FeatureGateClient) setActivated(ctx context.Context, gate *corev1alpha2.FeatureGate, featureName, reason string) error {
 	for i := range gate.Spec.Features {
 		if gate.Spec.Features[i].Name == featureName {
 			gate.Spec.Features[i].Activate = true
+			setActivationChangeReason(gate, reason)
 			return f.crClient.Update(ctx, gate)
 		}
 	}
@@ -234,7 +280,9 @@ func (f *FeatureGateClient) setActivated(ctx context.Context, gate *corev1alpha2
 
 // DeactivateFeature deactivates a Feature. Along with the error, it returns the name of the FeatureGate
 // that gates the Feature.
-func (f *FeatureGateClient) DeactivateFeature(ctx context.Context, featureName string) (string, error) {
+func (f *FeatureGateClient) DeactivateFeature(ctx context.Context, featureName string, options ...ActivationOption) (string, error) {
+	reason := newActivationOptions(options...).reason
+
 	// A Feature must exist in the cluster to be deactivated.
 	feature, err := f.GetFeature(ctx, featureName)
 	if err != nil {
@@ -246,6 +294,11 @@ func (f *FeatureGateClient) DeactivateFeature(ctx context.Context, featureName s
 		return "", fmt.Errorf("could not get FeatureGateList: %w", err)
 	}
 
+	policies, err := f.GetFeaturePolicyList(ctx)
+	if err != nil {
+		return "", fmt.Errorf("could not get FeaturePolicyList: %w", err)
+	}
+
 	gateName, featRef := FeatureRefFromGateList(gates, featureName)
 
 	if gateName != "" && !featRef.Activate {
@@ -253,7 +306,7 @@ func (f *FeatureGateClient) DeactivateFeature(ctx context.Context, featureName s
 		return gateName, nil
 	}
 
-	if err := validateFeatureActivationToggle(gates, feature); err != nil {
+	if err := validateFeatureActivationToggle(gates, feature, policies.Items); err != nil {
 		return gateName, err
 	}
 
@@ -262,20 +315,90 @@ func (f *FeatureGateClient) DeactivateFeature(ctx context.Context, featureName s
 		return gateName, err
 	}
 
-	return gateName, f.setDeactivated(ctx, gate, featureName)
+	return gateName, f.setDeactivated(ctx, gate, featureName, reason)
 }
 
 // setDeactivated sets the Feature to 'deactivate' in the FeatureGate resource.
-func (f *FeatureGateClient) setDeactivated(ctx context.Context, gate *corev1alpha2.FeatureGate, featureName string) error {
+func (f *FeatureGateClient) setDeactivated(ctx context.Context, gate *corev1alpha2.FeatureGate, featureName, reason string) error {
 	for i, featureRef := range gate.Spec.Features {
 		if featureRef.Name == featureName {
 			gate.Spec.Features[i].Activate = false
+			setActivationChangeReason(gate, reason)
 			return f.crClient.Update(ctx, gate)
 		}
 	}
 	return nil
 }
 
+// ActivationOption is an option for the activation and deactivation of a Feature
+type ActivationOption func(*activationOptions)
+
+type activationOptions struct {
+	reason string
+}
+
+func newActivationOptions(options ...ActivationOption) *activationOptions {
+	opts := &activationOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+	return opts
+}
+
+// WithReason records the reason, if not empty, for the activation change in the activation history of the FeatureGate
+func WithReason(reason string) ActivationOption {
+	return func(opts *activationOptions) {
+		opts.reason = reason
+	}
+}
+
+// setActivationChangeReason sets the reason for the activation change that the FeatureGate webhook records in the
+// activation history. The webhook removes the annotation once the change is recorded.
+func setActivationChangeReason(gate *corev1alpha2.FeatureGate, reason string) {
+	if reason == "" {
+		return
+	}
+	if gate.Annotations == nil {
+		gate.Annotations = map[string]string{}
+	}
+	gate.Annotations[corev1alpha2.ActivationChangeReasonAnnotation] = reason
+}
+
+// FeatureActivationRecord is an activation change of a Feature along with the FeatureGate it was made in.
+type FeatureActivationRecord struct {
+	FeatureGate string
+	corev1alpha2.FeatureActivationChange
+}
+
+// GetFeatureActivationHistory returns the activation changes of the Feature across all featuregates, oldest first.
+// Changes that the FeatureGate controller has not moved to the FeatureGate status yet are included.
+func (f *FeatureGateClient) GetFeatureActivationHistory(ctx context.Context, featureName string) ([]FeatureActivationRecord, error) {
+	gates, err := f.GetFeatureGateList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get FeatureGateList: %w", err)
+	}
+
+	var records []FeatureActivationRecord
+	for i := range gates.Items {
+		gate := &gates.Items[i]
+		pending, err := corev1alpha2.GetPendingActivationChanges(gate)
+		if err != nil {
+			return nil, fmt.Errorf("could not get pending activation changes of FeatureGate %s: %w", gate.Name, err)
+		}
+		for _, changes := range [][]corev1alpha2.FeatureActivationChange{gate.Status.History, corev1alpha2.UnrecordedActivationChanges(pending, gate.Status.History)} {
+			for _, change := range changes {
+				if change.Feature == featureName {
+					records = append(records, FeatureActivationRecord{FeatureGate: gate.Name, FeatureActivationChange: change})
+				}
+			}
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Time.Before(&records[j].Time)
+	})
+	return records, nil
+}
+
 // getCurrentClusterConfig gets the config of current logged in cluster
 func getCurrentClusterConfig() (*rest.Config, error) {
 	c, err := config.GetCurrentContext(types.TargetK8s)
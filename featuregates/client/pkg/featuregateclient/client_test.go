@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -510,3 +511,207 @@ func featureListContainsFeature(features *corev1alpha2.FeatureList, feature stri
 	}
 	return false
 }
+
+func TestActivateFeatureWithFeaturePolicy(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	objs, _, _ := fake.GetTestObjects()
+	s := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+	immutable, voidsWarranty := true, false
+	policy := &corev1alpha2.FeaturePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "regulated"},
+		Spec: corev1alpha2.FeaturePolicySpec{
+			Policies: []corev1alpha2.StabilityLevelPolicy{
+				{Stability: corev1alpha2.TechnicalPreview, Immutable: &immutable},
+				{Stability: corev1alpha2.WorkInProgress, VoidsWarranty: &voidsWarranty},
+			},
+		},
+	}
+	cl := crclient.NewClientBuilder().WithRuntimeObjects(append(objs, policy)...).Build()
+	featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+	if err != nil {
+		t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+	}
+
+	tests := []struct {
+		description string
+		featureName string
+		wantErr     error
+	}{
+		{
+			description: "should throw an error when a FeaturePolicy makes the Feature immutable",
+			featureName: "bar",
+			wantErr:     ErrTypeForbidden,
+		},
+		{
+			description: "should activate without voiding warranty when a FeaturePolicy says it does not void warranty",
+			featureName: "foo",
+			wantErr:     nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			err := featureGateClient.ActivateFeature(ctx, tc.featureName, false)
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("%v, want: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestActivateFeatureWithLayeredFeatureGates(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	objs, _, gates := fake.GetTestObjects()
+	s := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+	// The tanzu-fg FeatureGate overrides the tkg-system FeatureGate for the features they both reference
+	gates["tanzu-fg"].Spec.Priority = 100
+	cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+	featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+	if err != nil {
+		t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+	}
+
+	if err := featureGateClient.ActivateFeature(ctx, "baz", false); err != nil {
+		t.Fatal(err)
+	}
+	gateList, err := featureGateClient.GetFeatureGateList(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gateName, featRef := FeatureRefFromGateList(gateList, "baz")
+	if gateName != "tanzu-fg" || !featRef.Activate {
+		t.Errorf("got Feature baz activated %t in FeatureGate %s, want activated in FeatureGate tanzu-fg", featRef.Activate, gateName)
+	}
+
+	gate, err := featureGateClient.GetFeatureGate(ctx, "tkg-system")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ref := range gate.Spec.Features {
+		if ref.Name == "baz" && ref.Activate {
+			t.Errorf("the overridden reference of Feature baz in FeatureGate tkg-system should not change")
+		}
+	}
+}
+
+func TestFeatureActivationHistory(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	objs, _, gates := fake.GetTestObjects()
+	s := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+	earlier := metav1.NewTime(time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(earlier.Add(time.Hour))
+	gates["tkg-system"].Status.History = []corev1alpha2.FeatureActivationChange{
+		{Feature: "bar", Actor: "admin", Time: later, PreviousActivate: true, Activate: false},
+		{Feature: "barries", Actor: "admin", Time: earlier, Activate: true},
+	}
+	gates["tkg-system"].Annotations = map[string]string{
+		corev1alpha2.PendingActivationChangesAnnotation: `[{"feature":"bar","actor":"admin","time":"2023-06-01T00:00:00Z","previousActivate":false,"activate":true}]`,
+	}
+	cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+	featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+	if err != nil {
+		t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+	}
+
+	records, err := featureGateClient.GetFeatureActivationHistory(ctx, "bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2: %v", len(records), records)
+	}
+	if records[0].FeatureGate != "tkg-system" || !records[0].Activate || records[1].Activate {
+		t.Errorf("unexpected records: %v", records)
+	}
+
+	if err := featureGateClient.ActivateFeature(ctx, "bar", false, WithReason("needed for testing")); err != nil {
+		t.Fatal(err)
+	}
+	gate, err := featureGateClient.GetFeatureGate(ctx, "tkg-system")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := gate.Annotations[corev1alpha2.ActivationChangeReasonAnnotation]; got != "needed for testing" {
+		t.Errorf("got activation change reason %q, want %q", got, "needed for testing")
+	}
+}
+
+func TestGetVoidedWarranties(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	s := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+	supportStatus := &corev1alpha2.SupportStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: corev1alpha2.SupportStatusName},
+		Spec: corev1alpha2.SupportStatusSpec{
+			WarrantyVoids: []corev1alpha2.WarrantyVoidRecord{
+				{Feature: "cloud-event-listener", FeatureGate: "tkg-system", Actor: "admin"},
+				// Recorded for a FeatureGate that was deleted since
+				{Feature: "cloud-event-listener", FeatureGate: "deleted-gate", Actor: "admin"},
+			},
+		},
+	}
+
+	tests := []struct {
+		description string
+		withStatus  bool
+		wantRecords int
+		wantActor   string
+	}{
+		{
+			description: "should return the recorded warranty voids",
+			withStatus:  true,
+			wantRecords: 2,
+			wantActor:   "admin",
+		},
+		{
+			description: "should return the warranty voids of featuregates that are not recorded",
+			withStatus:  false,
+			wantRecords: 1,
+			wantActor:   "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			objs, _, _ := fake.GetTestObjects()
+			if tc.withStatus {
+				objs = append(objs, supportStatus.DeepCopy())
+			}
+			cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+			featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+			if err != nil {
+				t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+			}
+
+			records, err := featureGateClient.GetVoidedWarranties(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(records) != tc.wantRecords {
+				t.Fatalf("got %d records, want %d: %v", len(records), tc.wantRecords, records)
+			}
+			if records[0].Feature != "cloud-event-listener" || records[0].FeatureGate != "tkg-system" || records[0].Actor != tc.wantActor {
+				t.Errorf("unexpected record: %+v", records[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,542 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+const (
+	// FeatureConfigurationKind is the kind of the FeatureConfiguration file format.
+	FeatureConfigurationKind = "FeatureConfiguration"
+	// FeatureConfigurationVersion is the version of the FeatureConfiguration file format.
+	FeatureConfigurationVersion = "v1alpha1"
+)
+
+// FeatureConfiguration is a snapshot of the feature configuration of a cluster: the effective activation of its
+// Features and the feature references of its FeatureGates. It can be exported from a cluster and applied to another.
+type FeatureConfiguration struct {
+	Kind    string `json:"kind"`
+	Version string `json:"version"`
+	// Features holds the effective activation of the Features. It is only used to report drift, as applying a
+	// FeatureConfiguration only changes FeatureGates.
+	Features []FeatureState `json:"features,omitempty"`
+	// FeatureGates holds the FeatureGates and their feature references.
+	FeatureGates []FeatureGateConfiguration `json:"featureGates,omitempty"`
+}
+
+// FeatureState is the effective activation of a Feature
+type FeatureState struct {
+	Name      string                      `json:"name"`
+	Stability corev1alpha2.StabilityLevel `json:"stability,omitempty"`
+	Activated bool                        `json:"activated"`
+	// FeatureGate is the FeatureGate that decides the activation of the Feature, if any.
+	FeatureGate string `json:"featureGate,omitempty"`
+}
+
+// FeatureGateConfiguration is a FeatureGate and its feature references
+type FeatureGateConfiguration struct {
+	Name     string                          `json:"name"`
+	Priority int32                           `json:"priority,omitempty"`
+	Features []corev1alpha2.FeatureReference `json:"features,omitempty"`
+}
+
+// ChangeType is the type of a change to a FeatureGate or a feature reference
+type ChangeType string
+
+const (
+	// ChangeTypeCreate indicates that the FeatureGate or feature reference is created.
+	ChangeTypeCreate ChangeType = "Create"
+	// ChangeTypeUpdate indicates that the FeatureGate or feature reference is updated.
+	ChangeTypeUpdate ChangeType = "Update"
+	// ChangeTypeRemove indicates that the feature reference is removed.
+	ChangeTypeRemove ChangeType = "Remove"
+)
+
+// FeatureGateChange is a change to a FeatureGate
+type FeatureGateChange struct {
+	Name             string
+	Type             ChangeType
+	PreviousPriority int32
+	Priority         int32
+	References       []FeatureReferenceChange
+}
+
+// FeatureReferenceChange is a change to a feature reference of a FeatureGate. Previous is nil for created references,
+// and Desired is nil for removed references.
+type FeatureReferenceChange struct {
+	Feature  string
+	Type     ChangeType
+	Previous *corev1alpha2.FeatureReference
+	Desired  *corev1alpha2.FeatureReference
+}
+
+// FeatureActivationDifference is a Feature whose effective activation differs between the cluster and the desired
+// feature configuration
+type FeatureActivationDifference struct {
+	Feature   string
+	Activated bool
+	Desired   bool
+}
+
+// FeatureConfigurationPlan holds the changes to the FeatureGates of the cluster needed to apply a FeatureConfiguration,
+// and their consequences.
+type FeatureConfigurationPlan struct {
+	// FeatureGates are the changes to the FeatureGates, ordered by name.
+	FeatureGates []FeatureGateChange
+	// Activations are the Features whose effective activation changes once the plan is applied.
+	Activations []FeatureActivationDifference
+	// VoidedWarranties are the Features whose support warranty is permanently voided by the plan.
+	VoidedWarranties []string
+	// Violations are the reasons why the plan cannot be applied, such as violations of the stability level policies.
+	Violations []string
+
+	desired map[string]corev1alpha2.FeatureGateSpec
+	// planned are the FeatureGates of the cluster the plan was computed from. Their resourceVersion is sent when they
+	// are updated, so that the plan is not applied over concurrent changes.
+	planned map[string]*corev1alpha2.FeatureGate
+}
+
+// HasChanges returns true if applying the plan changes any FeatureGate.
+func (p *FeatureConfigurationPlan) HasChanges() bool {
+	return len(p.FeatureGates) > 0
+}
+
+// FeatureConfigurationDrift is the difference between the feature configuration of the cluster and a
+// FeatureConfiguration.
+type FeatureConfigurationDrift struct {
+	// Activations are the Features whose effective activation in the cluster differs from the FeatureConfiguration.
+	Activations []FeatureActivationDifference
+	// FeatureGates are the changes that applying the FeatureConfiguration would make to the FeatureGates.
+	FeatureGates []FeatureGateChange
+	// MissingFeatures are the Features of the FeatureConfiguration that do not exist in the cluster.
+	MissingFeatures []string
+	// UnknownFeatures are the Features of the cluster that are not in the FeatureConfiguration.
+	UnknownFeatures []string
+	// UnmanagedFeatureGates are the FeatureGates of the cluster that are not in the FeatureConfiguration.
+	UnmanagedFeatureGates []string
+}
+
+// HasDrift returns true if the feature configuration of the cluster differs from the FeatureConfiguration.
+func (d *FeatureConfigurationDrift) HasDrift() bool {
+	return len(d.Activations) > 0 || len(d.FeatureGates) > 0 || len(d.MissingFeatures) > 0 ||
+		len(d.UnknownFeatures) > 0 || len(d.UnmanagedFeatureGates) > 0
+}
+
+// ParseFeatureConfiguration parses a FeatureConfiguration from YAML or JSON, and checks its kind and version.
+func ParseFeatureConfiguration(data []byte) (*FeatureConfiguration, error) {
+	config := &FeatureConfiguration{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("could not parse FeatureConfiguration: %w", err)
+	}
+	if config.Kind != FeatureConfigurationKind {
+		return nil, fmt.Errorf("expected kind %s, but got %q: %w", FeatureConfigurationKind, config.Kind, ErrTypeInvalid)
+	}
+	if config.Version != FeatureConfigurationVersion {
+		return nil, fmt.Errorf("unsupported FeatureConfiguration version %q, supported version is %s: %w", config.Version, FeatureConfigurationVersion, ErrTypeInvalid)
+	}
+
+	gateNames := sets.String{}
+	for _, gate := range config.FeatureGates {
+		if gateNames.Has(gate.Name) {
+			return nil, fmt.Errorf("FeatureGate %s is defined more than once: %w", gate.Name, ErrTypeInvalid)
+		}
+		gateNames.Insert(gate.Name)
+	}
+	return config, nil
+}
+
+// MarshalFeatureConfiguration returns the YAML representation of a FeatureConfiguration.
+func MarshalFeatureConfiguration(config *FeatureConfiguration) ([]byte, error) {
+	return yaml.Marshal(config)
+}
+
+// ExportFeatureConfiguration returns the feature configuration of the cluster.
+func (f *FeatureGateClient) ExportFeatureConfiguration(ctx context.Context) (*FeatureConfiguration, error) {
+	features, err := f.GetFeatureList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get FeatureList: %w", err)
+	}
+
+	gates, err := f.GetFeatureGateList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get FeatureGateList: %w", err)
+	}
+
+	config := &FeatureConfiguration{
+		Kind:    FeatureConfigurationKind,
+		Version: FeatureConfigurationVersion,
+	}
+	for i := range features.Items {
+		config.Features = append(config.Features, FeatureState{
+			Name:        features.Items[i].Name,
+			Stability:   features.Items[i].Spec.Stability,
+			Activated:   features.Items[i].Status.Activated,
+			FeatureGate: features.Items[i].Status.FeatureGate,
+		})
+	}
+	for i := range gates.Items {
+		config.FeatureGates = append(config.FeatureGates, FeatureGateConfiguration{
+			Name:     gates.Items[i].Name,
+			Priority: gates.Items[i].Spec.Priority,
+			Features: gates.Items[i].Spec.Features,
+		})
+	}
+
+	sort.Slice(config.Features, func(i, j int) bool { return config.Features[i].Name < config.Features[j].Name })
+	sort.Slice(config.FeatureGates, func(i, j int) bool { return config.FeatureGates[i].Name < config.FeatureGates[j].Name })
+	return config, nil
+}
+
+// PlanFeatureConfiguration computes the changes to the FeatureGates of the cluster needed to apply the
+// FeatureConfiguration, and validates them against the stability level policies of the Features. FeatureGates of the
+// cluster that are not in the FeatureConfiguration are left unchanged.
+func (f *FeatureGateClient) PlanFeatureConfiguration(ctx context.Context, config *FeatureConfiguration) (*FeatureConfigurationPlan, error) {
+	features, err := f.GetFeatureList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get FeatureList: %w", err)
+	}
+
+	gates, err := f.GetFeatureGateList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get FeatureGateList: %w", err)
+	}
+
+	policies, err := f.GetFeaturePolicyList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get FeaturePolicyList: %w", err)
+	}
+
+	return computeFeatureConfigurationPlan(config, features.Items, gates.Items, policies.Items), nil
+}
+
+// ApplyFeatureConfiguration applies the plan of a FeatureConfiguration by creating and updating FeatureGates. A plan
+// with violations is not applied, and a plan that voids support warranties is only applied if warrantyVoidAllowed is
+// true. The reason, if not empty, is recorded in the activation history of the FeatureGates.
+// The FeatureGates that remove feature references are updated first, so that a reference moved to another FeatureGate
+// with the same priority does not conflict with itself. Each FeatureGate is updated once, and FeatureGates changed since
+// the plan was computed are not updated: a conflict error is returned.
+// Warning: Before sending `true` via the warrantyVoidAllowed function argument, ensure explicit user awareness and
+// approval, as voiding the support warranty is permanent for the environment.
+func (f *FeatureGateClient) ApplyFeatureConfiguration(ctx context.Context, plan *FeatureConfigurationPlan, warrantyVoidAllowed bool, reason string) error {
+	if len(plan.Violations) > 0 {
+		return fmt.Errorf("could not apply FeatureConfiguration: %s: %w", strings.Join(plan.Violations, "; "), ErrTypeForbidden)
+	}
+	if len(plan.VoidedWarranties) > 0 && !warrantyVoidAllowed {
+		return fmt.Errorf("applying the FeatureConfiguration voids the warranty of Features %v, but user has not given express permission to void the warranty: %w", plan.VoidedWarranties, ErrTypeForbidden)
+	}
+
+	// FeatureGates that remove references are updated first, and each FeatureGate is updated only once
+	var removing, others []FeatureGateChange
+	for _, change := range plan.FeatureGates {
+		if change.Type == ChangeTypeUpdate && removesReferences(change) {
+			removing = append(removing, change)
+		} else {
+			others = append(others, change)
+		}
+	}
+
+	for _, change := range append(removing, others...) {
+		if change.Type == ChangeTypeCreate {
+			gate := &corev1alpha2.FeatureGate{
+				ObjectMeta: metav1.ObjectMeta{Name: change.Name},
+				Spec:       plan.desired[change.Name],
+			}
+			setActivationChangeReason(gate, reason)
+			if err := f.crClient.Create(ctx, gate); err != nil {
+				return fmt.Errorf("could not create FeatureGate %s: %w", change.Name, err)
+			}
+			continue
+		}
+
+		planned, found := plan.planned[change.Name]
+		if !found {
+			return fmt.Errorf("could not update FeatureGate %s as it is not in the plan: %w", change.Name, ErrTypeNotFound)
+		}
+		// The FeatureGate is updated from the planned object, so that the update fails with a conflict if the
+		// FeatureGate was changed since the plan was computed
+		gate := planned.DeepCopy()
+		gate.Spec = plan.desired[change.Name]
+		setActivationChangeReason(gate, reason)
+		if err := f.crClient.Update(ctx, gate); err != nil {
+			if apierrors.IsConflict(err) {
+				return fmt.Errorf("could not update FeatureGate %s as it was changed since the plan was computed, plan the FeatureConfiguration again: %w", gate.Name, err)
+			}
+			return fmt.Errorf("could not update FeatureGate %s: %w", gate.Name, err)
+		}
+	}
+	return nil
+}
+
+// removesReferences returns true if the change removes feature references from the FeatureGate
+func removesReferences(change FeatureGateChange) bool {
+	for _, refChange := range change.References {
+		if refChange.Type == ChangeTypeRemove {
+			return true
+		}
+	}
+	return false
+}
+
+// DetectFeatureConfigurationDrift reports how the feature configuration of the cluster differs from the
+// FeatureConfiguration.
+func (f *FeatureGateClient) DetectFeatureConfigurationDrift(ctx context.Context, config *FeatureConfiguration) (*FeatureConfigurationDrift, error) {
+	features, err := f.GetFeatureList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get FeatureList: %w", err)
+	}
+
+	gates, err := f.GetFeatureGateList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get FeatureGateList: %w", err)
+	}
+
+	return computeFeatureConfigurationDrift(config, features.Items, gates.Items), nil
+}
+
+// computeFeatureConfigurationPlan computes the plan to apply the FeatureConfiguration to the Features and FeatureGates
+// of a cluster
+func computeFeatureConfigurationPlan(config *FeatureConfiguration, features []corev1alpha2.Feature, gates []corev1alpha2.FeatureGate, policies []corev1alpha2.FeaturePolicy) *FeatureConfigurationPlan {
+	plan := &FeatureConfigurationPlan{
+		FeatureGates: computeFeatureGateChanges(config, gates),
+		desired:      map[string]corev1alpha2.FeatureGateSpec{},
+		planned:      map[string]*corev1alpha2.FeatureGate{},
+	}
+	for _, gate := range config.FeatureGates {
+		plan.desired[gate.Name] = corev1alpha2.FeatureGateSpec{Priority: gate.Priority, Features: gate.Features}
+	}
+	for i := range gates {
+		if _, found := plan.desired[gates[i].Name]; found {
+			plan.planned[gates[i].Name] = gates[i].DeepCopy()
+		}
+	}
+
+	// The FeatureGates of the cluster once the plan is applied
+	desiredGates := make([]corev1alpha2.FeatureGate, 0, len(gates)+len(config.FeatureGates))
+	for i := range gates {
+		if _, found := plan.desired[gates[i].Name]; !found {
+			desiredGates = append(desiredGates, gates[i])
+		}
+	}
+	for _, gate := range config.FeatureGates {
+		desiredGates = append(desiredGates, corev1alpha2.FeatureGate{
+			ObjectMeta: metav1.ObjectMeta{Name: gate.Name},
+			Spec:       plan.desired[gate.Name],
+		})
+	}
+
+	plan.Violations = computeFeatureConfigurationViolations(plan.FeatureGates, features, desiredGates, policies)
+	for _, change := range plan.FeatureGates {
+		for _, refChange := range change.References {
+			if refChange.Desired != nil && refChange.Desired.PermanentlyVoidAllSupportGuarantees &&
+				(refChange.Previous == nil || !refChange.Previous.PermanentlyVoidAllSupportGuarantees) {
+				plan.VoidedWarranties = append(plan.VoidedWarranties, refChange.Feature)
+			}
+		}
+	}
+	plan.VoidedWarranties = sets.NewString(plan.VoidedWarranties...).List()
+
+	activated, _ := corev1alpha2.ComputeFeatureActivation(features, corev1alpha2.ComputeFeatureIntents(features, desiredGates, nil, policies))
+	for i := range features {
+		if features[i].Status.Activated != activated[features[i].Name] {
+			plan.Activations = append(plan.Activations, FeatureActivationDifference{
+				Feature:   features[i].Name,
+				Activated: features[i].Status.Activated,
+				Desired:   activated[features[i].Name],
+			})
+		}
+	}
+	sort.Slice(plan.Activations, func(i, j int) bool { return plan.Activations[i].Feature < plan.Activations[j].Feature })
+	return plan
+}
+
+// computeFeatureGateChanges computes the changes to the FeatureGates needed to apply the FeatureConfiguration
+func computeFeatureGateChanges(config *FeatureConfiguration, gates []corev1alpha2.FeatureGate) []FeatureGateChange {
+	current := map[string]*corev1alpha2.FeatureGate{}
+	for i := range gates {
+		current[gates[i].Name] = &gates[i]
+	}
+
+	var changes []FeatureGateChange
+	for _, desired := range config.FeatureGates {
+		gate, found := current[desired.Name]
+		if !found {
+			change := FeatureGateChange{Name: desired.Name, Type: ChangeTypeCreate, Priority: desired.Priority}
+			for i := range desired.Features {
+				change.References = append(change.References, FeatureReferenceChange{
+					Feature: desired.Features[i].Name,
+					Type:    ChangeTypeCreate,
+					Desired: &desired.Features[i],
+				})
+			}
+			changes = append(changes, change)
+			continue
+		}
+
+		change := FeatureGateChange{
+			Name:             desired.Name,
+			Type:             ChangeTypeUpdate,
+			PreviousPriority: gate.Spec.Priority,
+			Priority:         desired.Priority,
+			References:       computeFeatureReferenceChanges(gate.Spec.Features, desired.Features),
+		}
+		if change.PreviousPriority != change.Priority || len(change.References) > 0 {
+			changes = append(changes, change)
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+// computeFeatureReferenceChanges computes the changes from the previous to the desired feature references of a
+// FeatureGate, ordered by feature name
+func computeFeatureReferenceChanges(previous, desired []corev1alpha2.FeatureReference) []FeatureReferenceChange {
+	previousRefs := map[string]*corev1alpha2.FeatureReference{}
+	for i := range previous {
+		previousRefs[previous[i].Name] = &previous[i]
+	}
+	desiredRefs := map[string]*corev1alpha2.FeatureReference{}
+	for i := range desired {
+		desiredRefs[desired[i].Name] = &desired[i]
+	}
+
+	var changes []FeatureReferenceChange
+	for name, ref := range desiredRefs {
+		previousRef, found := previousRefs[name]
+		switch {
+		case !found:
+			changes = append(changes, FeatureReferenceChange{Feature: name, Type: ChangeTypeCreate, Desired: ref})
+		case !equality.Semantic.DeepEqual(previousRef, ref):
+			changes = append(changes, FeatureReferenceChange{Feature: name, Type: ChangeTypeUpdate, Previous: previousRef, Desired: ref})
+		}
+	}
+	for name, ref := range previousRefs {
+		if _, found := desiredRefs[name]; !found {
+			changes = append(changes, FeatureReferenceChange{Feature: name, Type: ChangeTypeRemove, Previous: ref})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Feature < changes[j].Feature })
+	return changes
+}
+
+// computeFeatureConfigurationViolations validates the changed feature references against the Features of the cluster
+// and the policies of their stability levels, the priorities of the FeatureGates that reference them, and their
+// dependencies and conflicts. It returns the violations found.
+func computeFeatureConfigurationViolations(changes []FeatureGateChange, features []corev1alpha2.Feature, desiredGates []corev1alpha2.FeatureGate, policies []corev1alpha2.FeaturePolicy) []string {
+	clusterFeatures := map[string]*corev1alpha2.Feature{}
+	for i := range features {
+		clusterFeatures[features[i].Name] = &features[i]
+	}
+
+	violations := sets.String{}
+	activatedInChanges := sets.String{}
+	for _, change := range changes {
+		for _, refChange := range change.References {
+			ref := refChange.Desired
+			if ref == nil {
+				continue
+			}
+			feature, found := clusterFeatures[ref.Name]
+			if !found {
+				violations.Insert(fmt.Sprintf("Feature %s referenced by FeatureGate %s does not exist in the cluster", ref.Name, change.Name))
+				continue
+			}
+			if ref.Activate {
+				activatedInChanges.Insert(ref.Name)
+			}
+
+			policy := corev1alpha2.GetPolicyForStabilityLevel(feature.Spec.Stability, policies...)
+			switch {
+			case policy.DefaultActivation == ref.Activate:
+			case !corev1alpha2.IsStabilityLevelDefined(feature.Spec.Stability, policies...):
+				violations.Insert(fmt.Sprintf("Feature %s cannot be toggled by FeatureGate %s as its stability level %s is not defined by any FeaturePolicy", ref.Name, change.Name, feature.Spec.Stability))
+			case policy.Immutable:
+				violations.Insert(fmt.Sprintf("Feature %s cannot be toggled by FeatureGate %s as its stability level is %s", ref.Name, change.Name, feature.Spec.Stability))
+			case policy.VoidsWarranty && !ref.PermanentlyVoidAllSupportGuarantees:
+				violations.Insert(fmt.Sprintf("Feature %s can only be toggled by FeatureGate %s by permanently voiding all support guarantees of the environment", ref.Name, change.Name))
+			}
+			if refChange.Previous != nil && refChange.Previous.PermanentlyVoidAllSupportGuarantees && !ref.PermanentlyVoidAllSupportGuarantees {
+				violations.Insert(fmt.Sprintf("Feature %s cannot be set back to not void all support guarantees in FeatureGate %s", ref.Name, change.Name))
+			}
+
+			for _, gate := range corev1alpha2.GetFeatureGatesForFeature(desiredGates, ref.Name) {
+				if gate.Name != change.Name && gate.Spec.Priority == change.Priority {
+					names := sets.NewString(change.Name, gate.Name).List()
+					violations.Insert(fmt.Sprintf("Feature %s is referenced by FeatureGates %s and %s with the same priority %d", ref.Name, names[0], names[1], change.Priority))
+				}
+			}
+		}
+	}
+
+	_, blocked := corev1alpha2.ComputeFeatureActivation(features, corev1alpha2.ComputeFeatureIntents(features, desiredGates, nil, policies))
+	for _, name := range activatedInChanges.List() {
+		if reason, found := blocked[name]; found {
+			violations.Insert(fmt.Sprintf("Feature %s cannot be activated: %s", name, reason))
+		}
+	}
+	return violations.List()
+}
+
+// computeFeatureConfigurationDrift computes how the Features and FeatureGates of a cluster differ from the
+// FeatureConfiguration
+func computeFeatureConfigurationDrift(config *FeatureConfiguration, features []corev1alpha2.Feature, gates []corev1alpha2.FeatureGate) *FeatureConfigurationDrift {
+	drift := &FeatureConfigurationDrift{
+		FeatureGates: computeFeatureGateChanges(config, gates),
+	}
+
+	clusterFeatures := map[string]*corev1alpha2.Feature{}
+	for i := range features {
+		clusterFeatures[features[i].Name] = &features[i]
+	}
+	configFeatures := sets.String{}
+	for _, state := range config.Features {
+		configFeatures.Insert(state.Name)
+		feature, found := clusterFeatures[state.Name]
+		if !found {
+			drift.MissingFeatures = append(drift.MissingFeatures, state.Name)
+			continue
+		}
+		if feature.Status.Activated != state.Activated {
+			drift.Activations = append(drift.Activations, FeatureActivationDifference{
+				Feature:   state.Name,
+				Activated: feature.Status.Activated,
+				Desired:   state.Activated,
+			})
+		}
+	}
+	for name := range clusterFeatures {
+		if !configFeatures.Has(name) {
+			drift.UnknownFeatures = append(drift.UnknownFeatures, name)
+		}
+	}
+
+	configGates := sets.String{}
+	for _, gate := range config.FeatureGates {
+		configGates.Insert(gate.Name)
+	}
+	for i := range gates {
+		if !configGates.Has(gates[i].Name) {
+			drift.UnmanagedFeatureGates = append(drift.UnmanagedFeatureGates, gates[i].Name)
+		}
+	}
+
+	sort.Slice(drift.Activations, func(i, j int) bool { return drift.Activations[i].Feature < drift.Activations[j].Feature })
+	sort.Strings(drift.MissingFeatures)
+	sort.Strings(drift.UnknownFeatures)
+	sort.Strings(drift.UnmanagedFeatureGates)
+	return drift
+}
@@ -0,0 +1,445 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+func configurationTestObjects() []runtime.Object {
+	feature := func(name string, stability corev1alpha2.StabilityLevel, activated bool, dependsOn ...string) *corev1alpha2.Feature {
+		return &corev1alpha2.Feature{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1alpha2.FeatureSpec{Stability: stability, DependsOn: dependsOn},
+			Status:     corev1alpha2.FeatureStatus{Activated: activated},
+		}
+	}
+	return []runtime.Object{
+		feature("big-cache", corev1alpha2.TechnicalPreview, true),
+		feature("big-cache-eviction", corev1alpha2.TechnicalPreview, false, "big-cache"),
+		feature("periscope", corev1alpha2.Experimental, false),
+		feature("toaster", corev1alpha2.Stable, true),
+		&corev1alpha2.FeatureGate{
+			ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+			Spec: corev1alpha2.FeatureGateSpec{Features: []corev1alpha2.FeatureReference{
+				{Name: "big-cache", Activate: true},
+				{Name: "toaster", Activate: true},
+			}},
+		},
+	}
+}
+
+func TestFeatureConfigurationRoundTrip(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	if err := corev1alpha2.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+	cl := crclient.NewClientBuilder().WithRuntimeObjects(configurationTestObjects()...).Build()
+	featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+	if err != nil {
+		t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+	}
+
+	config, err := featureGateClient.ExportFeatureConfiguration(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := MarshalFeatureConfiguration(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseFeatureConfiguration(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(config, parsed); diff != "" {
+		t.Errorf("the parsed FeatureConfiguration differs from the exported one: %s", diff)
+	}
+
+	// The cluster the configuration was exported from has neither changes to apply nor drift
+	plan, err := featureGateClient.PlanFeatureConfiguration(ctx, parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.HasChanges() || len(plan.Activations) > 0 || len(plan.Violations) > 0 {
+		t.Errorf("expected an empty plan, got %+v", plan)
+	}
+	drift, err := featureGateClient.DetectFeatureConfigurationDrift(ctx, parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if drift.HasDrift() {
+		t.Errorf("expected no drift, got %+v", drift)
+	}
+}
+
+func TestParseFeatureConfiguration(t *testing.T) {
+	tests := []struct {
+		description string
+		data        string
+		wantErr     error
+	}{
+		{
+			description: "parse a FeatureConfiguration",
+			data:        "kind: FeatureConfiguration\nversion: v1alpha1\nfeatureGates:\n- name: baseline\n  features:\n  - name: big-cache\n    activate: true\n",
+		},
+		{
+			description: "reject an unsupported version",
+			data:        "kind: FeatureConfiguration\nversion: v2\n",
+			wantErr:     ErrTypeInvalid,
+		},
+		{
+			description: "reject another kind",
+			data:        "kind: FeatureGate\nversion: v1alpha1\n",
+			wantErr:     ErrTypeInvalid,
+		},
+		{
+			description: "reject FeatureGates defined more than once",
+			data:        "kind: FeatureConfiguration\nversion: v1alpha1\nfeatureGates:\n- name: baseline\n- name: baseline\n",
+			wantErr:     ErrTypeInvalid,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			_, err := ParseFeatureConfiguration([]byte(tc.data))
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("%v, want: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestPlanAndApplyFeatureConfiguration(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	if err := corev1alpha2.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	tests := []struct {
+		description          string
+		featureGates         []FeatureGateConfiguration
+		warrantyVoidAllowed  bool
+		wantChanges          []FeatureGateChange
+		wantActivations      []FeatureActivationDifference
+		wantVoidedWarranties []string
+		wantViolations       int
+		wantErr              error
+	}{
+		{
+			description: "update a FeatureGate and create an overriding one",
+			featureGates: []FeatureGateConfiguration{
+				{Name: "baseline", Features: []corev1alpha2.FeatureReference{
+					{Name: "big-cache", Activate: true},
+					{Name: "big-cache-eviction", Activate: true},
+				}},
+				{Name: "environment", Priority: 100, Features: []corev1alpha2.FeatureReference{
+					{Name: "toaster", Activate: true},
+				}},
+			},
+			wantChanges: []FeatureGateChange{
+				{Name: "baseline", Type: ChangeTypeUpdate, References: []FeatureReferenceChange{
+					{Feature: "big-cache-eviction", Type: ChangeTypeCreate, Desired: &corev1alpha2.FeatureReference{Name: "big-cache-eviction", Activate: true}},
+					{Feature: "toaster", Type: ChangeTypeRemove, Previous: &corev1alpha2.FeatureReference{Name: "toaster", Activate: true}},
+				}},
+				{Name: "environment", Type: ChangeTypeCreate, Priority: 100, References: []FeatureReferenceChange{
+					{Feature: "toaster", Type: ChangeTypeCreate, Desired: &corev1alpha2.FeatureReference{Name: "toaster", Activate: true}},
+				}},
+			},
+			wantActivations: []FeatureActivationDifference{{Feature: "big-cache-eviction", Activated: false, Desired: true}},
+		},
+		{
+			description: "reject toggling immutable features and activating features without their dependencies",
+			featureGates: []FeatureGateConfiguration{
+				{Name: "baseline", Features: []corev1alpha2.FeatureReference{
+					{Name: "big-cache", Activate: false},
+					{Name: "big-cache-eviction", Activate: true},
+					{Name: "toaster", Activate: false},
+				}},
+			},
+			wantActivations: []FeatureActivationDifference{{Feature: "big-cache", Activated: true, Desired: false}},
+			wantViolations:  2,
+			wantErr:         ErrTypeForbidden,
+		},
+		{
+			description: "reject activating an experimental feature without voiding the warranty",
+			featureGates: []FeatureGateConfiguration{
+				{Name: "baseline", Features: []corev1alpha2.FeatureReference{
+					{Name: "big-cache", Activate: true},
+					{Name: "periscope", Activate: true},
+					{Name: "toaster", Activate: true},
+				}},
+			},
+			wantViolations: 1,
+			wantErr:        ErrTypeForbidden,
+		},
+		{
+			description: "reject voiding the warranty without the permission of the user",
+			featureGates: []FeatureGateConfiguration{
+				{Name: "baseline", Features: []corev1alpha2.FeatureReference{
+					{Name: "big-cache", Activate: true},
+					{Name: "periscope", Activate: true, PermanentlyVoidAllSupportGuarantees: true},
+					{Name: "toaster", Activate: true},
+				}},
+			},
+			wantActivations:      []FeatureActivationDifference{{Feature: "periscope", Activated: false, Desired: true}},
+			wantVoidedWarranties: []string{"periscope"},
+			wantErr:              ErrTypeForbidden,
+		},
+		{
+			description: "void the warranty with the permission of the user",
+			featureGates: []FeatureGateConfiguration{
+				{Name: "baseline", Features: []corev1alpha2.FeatureReference{
+					{Name: "big-cache", Activate: true},
+					{Name: "periscope", Activate: true, PermanentlyVoidAllSupportGuarantees: true},
+					{Name: "toaster", Activate: true},
+				}},
+			},
+			warrantyVoidAllowed:  true,
+			wantActivations:      []FeatureActivationDifference{{Feature: "periscope", Activated: false, Desired: true}},
+			wantVoidedWarranties: []string{"periscope"},
+		},
+		{
+			description: "reject FeatureGates with the same priority referencing the same feature",
+			featureGates: []FeatureGateConfiguration{
+				{Name: "platform", Features: []corev1alpha2.FeatureReference{
+					{Name: "big-cache", Activate: true},
+				}},
+			},
+			wantViolations: 1,
+			wantErr:        ErrTypeForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			cl := crclient.NewClientBuilder().WithRuntimeObjects(configurationTestObjects()...).Build()
+			featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+			if err != nil {
+				t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+			}
+			config := &FeatureConfiguration{Kind: FeatureConfigurationKind, Version: FeatureConfigurationVersion, FeatureGates: tc.featureGates}
+
+			plan, err := featureGateClient.PlanFeatureConfiguration(ctx, config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.wantChanges != nil {
+				if diff := cmp.Diff(plan.FeatureGates, tc.wantChanges); diff != "" {
+					t.Errorf("unexpected FeatureGate changes: %s", diff)
+				}
+			}
+			if diff := cmp.Diff(plan.Activations, tc.wantActivations, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("unexpected activation changes: %s", diff)
+			}
+			if diff := cmp.Diff(plan.VoidedWarranties, tc.wantVoidedWarranties, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("unexpected voided warranties: %s", diff)
+			}
+			if len(plan.Violations) != tc.wantViolations {
+				t.Errorf("got violations %v, want %d", plan.Violations, tc.wantViolations)
+			}
+
+			err = featureGateClient.ApplyFeatureConfiguration(ctx, plan, tc.warrantyVoidAllowed, "applied from a file")
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("%v, want: %v", err, tc.wantErr)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+
+			// The FeatureGates of the cluster match the FeatureConfiguration once it is applied
+			drift, err := featureGateClient.DetectFeatureConfigurationDrift(ctx, config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(drift.FeatureGates) > 0 {
+				t.Errorf("expected the FeatureGates to be applied, got drift %+v", drift.FeatureGates)
+			}
+			gate, err := featureGateClient.GetFeatureGate(ctx, tc.featureGates[0].Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := gate.Annotations[corev1alpha2.ActivationChangeReasonAnnotation]; got != "applied from a file" {
+				t.Errorf("got activation change reason %q, want %q", got, "applied from a file")
+			}
+		})
+	}
+}
+
+// recordingClient records the FeatureGates created and updated through it
+type recordingClient struct {
+	client.Client
+	calls []string
+}
+
+func (c *recordingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	c.calls = append(c.calls, "create "+obj.GetName())
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *recordingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	c.calls = append(c.calls, "update "+obj.GetName())
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func TestApplyFeatureConfigurationRemovesReferencesFirst(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	if err := corev1alpha2.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+	cl := &recordingClient{Client: crclient.NewClientBuilder().WithRuntimeObjects(configurationTestObjects()...).Build()}
+	featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+	if err != nil {
+		t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+	}
+
+	// The toaster reference moves from the baseline FeatureGate to a FeatureGate with the same priority, which the
+	// FeatureGate webhook only allows once the reference is removed from the baseline FeatureGate
+	config := &FeatureConfiguration{Kind: FeatureConfigurationKind, Version: FeatureConfigurationVersion, FeatureGates: []FeatureGateConfiguration{
+		{Name: "appliance", Features: []corev1alpha2.FeatureReference{
+			{Name: "toaster", Activate: true},
+		}},
+		{Name: "baseline", Features: []corev1alpha2.FeatureReference{
+			{Name: "big-cache", Activate: true},
+			{Name: "big-cache-eviction", Activate: true},
+		}},
+	}}
+	plan, err := featureGateClient.PlanFeatureConfiguration(ctx, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := featureGateClient.ApplyFeatureConfiguration(ctx, plan, false, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// The baseline FeatureGate is updated once, with its desired references, before the appliance one is created
+	wantCalls := []string{"update baseline", "create appliance"}
+	if diff := cmp.Diff(cl.calls, wantCalls); diff != "" {
+		t.Errorf("unexpected FeatureGate calls: %s", diff)
+	}
+	drift, err := featureGateClient.DetectFeatureConfigurationDrift(ctx, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(drift.FeatureGates) > 0 {
+		t.Errorf("expected the FeatureGates to be applied, got drift %+v", drift.FeatureGates)
+	}
+}
+
+func TestApplyFeatureConfigurationConflicts(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	if err := corev1alpha2.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+	cl := crclient.NewClientBuilder().WithRuntimeObjects(configurationTestObjects()...).Build()
+	featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+	if err != nil {
+		t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+	}
+
+	config := &FeatureConfiguration{Kind: FeatureConfigurationKind, Version: FeatureConfigurationVersion, FeatureGates: []FeatureGateConfiguration{
+		{Name: "baseline", Features: []corev1alpha2.FeatureReference{
+			{Name: "big-cache", Activate: true},
+			{Name: "big-cache-eviction", Activate: true},
+			{Name: "toaster", Activate: true},
+		}},
+	}}
+	plan, err := featureGateClient.PlanFeatureConfiguration(ctx, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The FeatureGate is changed after the plan is computed
+	gate, err := featureGateClient.GetFeatureGate(ctx, "baseline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gate.Spec.Features[0].Activate = false
+	if err := cl.Update(ctx, gate); err != nil {
+		t.Fatal(err)
+	}
+
+	err = featureGateClient.ApplyFeatureConfiguration(ctx, plan, false, "")
+	if !apierrors.IsConflict(err) {
+		t.Fatalf("got error %v, want a conflict", err)
+	}
+	gate, err = featureGateClient.GetFeatureGate(ctx, "baseline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gate.Spec.Features[0].Activate || len(gate.Spec.Features) != 2 {
+		t.Errorf("expected the concurrent change to be kept, got %+v", gate.Spec.Features)
+	}
+}
+
+func TestDetectFeatureConfigurationDrift(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	if err := corev1alpha2.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+	cl := crclient.NewClientBuilder().WithRuntimeObjects(configurationTestObjects()...).Build()
+	featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+	if err != nil {
+		t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+	}
+
+	config := &FeatureConfiguration{
+		Kind:    FeatureConfigurationKind,
+		Version: FeatureConfigurationVersion,
+		Features: []FeatureState{
+			{Name: "big-cache", Activated: false},
+			{Name: "big-cache-eviction", Activated: false},
+			{Name: "toaster", Activated: true},
+			{Name: "tuner", Activated: true},
+		},
+		FeatureGates: []FeatureGateConfiguration{
+			{Name: "environment", Features: []corev1alpha2.FeatureReference{{Name: "big-cache", Activate: false}}},
+		},
+	}
+
+	got, err := featureGateClient.DetectFeatureConfigurationDrift(ctx, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &FeatureConfigurationDrift{
+		Activations: []FeatureActivationDifference{{Feature: "big-cache", Activated: true, Desired: false}},
+		FeatureGates: []FeatureGateChange{
+			{Name: "environment", Type: ChangeTypeCreate, References: []FeatureReferenceChange{
+				{Feature: "big-cache", Type: ChangeTypeCreate, Desired: &corev1alpha2.FeatureReference{Name: "big-cache", Activate: false}},
+			}},
+		},
+		MissingFeatures:       []string{"tuner"},
+		UnknownFeatures:       []string{"periscope"},
+		UnmanagedFeatureGates: []string{"baseline"},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("unexpected drift: %s", diff)
+	}
+	if !got.HasDrift() {
+		t.Errorf("expected drift to be reported")
+	}
+}
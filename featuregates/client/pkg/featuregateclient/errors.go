@@ -16,6 +16,8 @@ const (
 	ErrTypeForbidden ErrType = "Forbidden"
 	// ErrTypeTooMany indicates there are too many of a resource.
 	ErrTypeTooMany ErrType = "TooMany"
+	// ErrTypeInvalid indicates a resource or a file is not valid.
+	ErrTypeInvalid ErrType = "Invalid"
 )
 
 // Error converts a ErrorType into its corresponding canonical error message.
@@ -27,6 +29,8 @@ func (t ErrType) Error() string {
 		return "Forbidden"
 	case ErrTypeTooMany:
 		return "Too many"
+	case ErrTypeInvalid:
+		return "Invalid"
 	default:
 		return fmt.Sprintf("unrecognized validation error: %q", string(t))
 	}
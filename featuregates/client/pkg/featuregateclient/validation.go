@@ -10,49 +10,40 @@ import (
 )
 
 // validateFeatureActivationToggle ensures the given Feature can be activated.
-func validateFeatureActivationToggle(gates *corev1alpha2.FeatureGateList, feature *corev1alpha2.Feature) error {
-	if err := featureExistsInOneAndOnlyOneFeaturegate(gates, feature.Name); err != nil {
+func validateFeatureActivationToggle(gates *corev1alpha2.FeatureGateList, feature *corev1alpha2.Feature, policies []corev1alpha2.FeaturePolicy) error {
+	if err := featureExistsInOneAndOnlyOneWinningFeaturegate(gates, feature.Name); err != nil {
 		return fmt.Errorf("could not validate Feature changing activation set point: %w", err)
 	}
 
-	if err := featureActivationToggleAllowed(feature); err != nil {
+	if err := featureActivationToggleAllowed(feature, policies); err != nil {
 		return fmt.Errorf("could not validate Feature changing activation set point: %w", err)
 	}
 
 	return nil
 }
 
-// featureExistsInOneFeaturegate checks that the Feature exists in one and only one FeatureGate.
-func featureExistsInOneAndOnlyOneFeaturegate(gates *corev1alpha2.FeatureGateList, featureName string) error {
-	n := qtyFeatureGatesContainingFeature(gates, featureName)
-	if n > 1 {
-		return fmt.Errorf("the Feature %s was found in more than one FeatureGate: %w", featureName, ErrTypeTooMany)
-	}
-	if n == 0 {
+// featureExistsInOneAndOnlyOneWinningFeaturegate checks that the Feature exists in at least one FeatureGate, and that
+// one and only one FeatureGate references it with the highest priority, as that FeatureGate decides its activation.
+func featureExistsInOneAndOnlyOneWinningFeaturegate(gates *corev1alpha2.FeatureGateList, featureName string) error {
+	referencing := corev1alpha2.GetFeatureGatesForFeature(gates.Items, featureName)
+	if len(referencing) == 0 {
 		return fmt.Errorf("the Feature %s must exist in one FeatureGate: %w", featureName, ErrTypeNotFound)
 	}
-	return nil
-}
-
-// qtyFeatureGatesContainingFeature counts the number of FeatureGates that
-// references the provided Feature.
-func qtyFeatureGatesContainingFeature(gates *corev1alpha2.FeatureGateList, featureName string) int {
-	var n int
-	for i := range gates.Items {
-		for _, ref := range gates.Items[i].Spec.Features {
-			if ref.Name == featureName {
-				n++
-			}
-		}
+	if len(referencing) > 1 && referencing[0].Spec.Priority == referencing[1].Spec.Priority {
+		return fmt.Errorf("the Feature %s was found in more than one FeatureGate with priority %d: %w", featureName, referencing[0].Spec.Priority, ErrTypeTooMany)
 	}
-	return n
+	return nil
 }
 
 // featureActivationToggleAllowed checks if a Feature is considered immutable by its stability
 // level and associated policy. Immutable means a Feature's activation setting cannot be toggled.
-func featureActivationToggleAllowed(feature *corev1alpha2.Feature) error {
+// Features whose stability level is not defined by any FeaturePolicy cannot be toggled either.
+func featureActivationToggleAllowed(feature *corev1alpha2.Feature, policies []corev1alpha2.FeaturePolicy) error {
 	stability := feature.Spec.Stability
-	policy := corev1alpha2.GetPolicyForStabilityLevel(stability)
+	if !corev1alpha2.IsStabilityLevelDefined(stability, policies...) {
+		return fmt.Errorf("activation setting for Feature %s cannot be toggled as its stability level %s is not defined by any FeaturePolicy: %w", feature.Name, stability, ErrTypeForbidden)
+	}
+	policy := corev1alpha2.GetPolicyForStabilityLevel(stability, policies...)
 
 	if policy.Immutable {
 		return fmt.Errorf("activation setting for Feature %s cannot be toggled as its stability level is %s: %w", feature.Name, stability, ErrTypeForbidden)
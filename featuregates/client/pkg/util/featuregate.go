@@ -141,7 +141,8 @@ func FeaturesActivatedInNamespacesMatchingSelector(ctx context.Context, c client
 	return true, nil
 }
 
-// IsFeatureActivated returns true only if the feature is activated.
+// IsFeatureActivated returns true only if the feature is activated. It gets the Feature resource on every call, controllers
+// that check features often should use the informer-backed featureflags package instead.
 func IsFeatureActivated(ctx context.Context, c client.Client, featureName string) (bool, error) {
 	feature := &corev1alpha2.Feature{}
 	if err := c.Get(ctx, types.NamespacedName{
@@ -153,21 +154,16 @@ func IsFeatureActivated(ctx context.Context, c client.Client, featureName string
 	return feature.Status.Activated, nil
 }
 
-// GetFeatureGateForFeature returns FeatureGate resource that is gating the feature
+// GetFeatureGateForFeature returns FeatureGate resource that is gating the feature. If several FeatureGate resources
+// reference the feature, the one with the highest priority is returned, as it decides the activation of the feature.
 func GetFeatureGateForFeature(ctx context.Context, c client.Client, featureName string) (*corev1alpha2.FeatureGate, bool, error) {
 	featureGateList := &corev1alpha2.FeatureGateList{}
 	if err := c.List(ctx, featureGateList); err != nil {
 		return nil, false, fmt.Errorf("could not list FeatureGate resources: %w", err)
 	}
 
-	for i, fg := range featureGateList.Items { //nolint:gocritic
-		for _, f := range featureGateList.Items[i].Spec.Features {
-			if f.Name == featureName {
-				return &fg, true, nil
-			}
-		}
-	}
-	return nil, false, nil
+	featureGate, found := corev1alpha2.GetWinningFeatureGate(featureGateList.Items, featureName)
+	return featureGate, found, nil
 }
 
 // GetFeatureGateWithFeatureInStatus returns FeatureGate resource with feature in its status
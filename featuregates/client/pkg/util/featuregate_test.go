@@ -409,6 +409,15 @@ func TestGetFeatureGateForFeature(t *testing.T) {
 				},
 			},
 		},
+		&corev1alpha2.FeatureGate{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-override-featuregate"},
+			Spec: corev1alpha2.FeatureGateSpec{
+				Priority: 100,
+				Features: []corev1alpha2.FeatureReference{
+					{Name: "bar", Activate: true},
+				},
+			},
+		},
 	}
 	var objs []runtime.Object
 	objs = append(objs, featureGates...)
@@ -430,6 +439,13 @@ func TestGetFeatureGateForFeature(t *testing.T) {
 			wantedFeatureGateName: "my-featuregate",
 			returnErr:             false,
 		},
+		{
+			description:           "should return the featuregate with the highest priority when feature is found in several featuregates",
+			featureName:           "bar",
+			want:                  true,
+			wantedFeatureGateName: "my-override-featuregate",
+			returnErr:             false,
+		},
 		{
 			description:           "should return false when feature is not found in any featuregate",
 			featureName:           "bax",
@@ -0,0 +1,77 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// NamespacesInRollout returns the sorted list of namespaces selected by the rollout of a feature.
+func NamespacesInRollout(ctx context.Context, c client.Client, featureName string, rollout *corev1alpha2.FeatureRollout) ([]string, error) {
+	// A nil selector selects nothing, so that namespaces are only selected by the other fields of the rollout
+	selector, err := metav1.LabelSelectorAsSelector(rollout.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespaces from rollout NamespaceSelector: %w", err)
+	}
+
+	nsList := &corev1.NamespaceList{}
+	if err := c.List(ctx, nsList); err != nil {
+		return nil, fmt.Errorf("failed to get namespaces for rollout: %w", err)
+	}
+
+	listed := sets.NewString(rollout.Namespaces...)
+	namespaces := []string{}
+	for i := range nsList.Items {
+		ns := &nsList.Items[i]
+		if listed.Has(ns.Name) || selector.Matches(labels.Set(ns.Labels)) || inRolloutPercentage(featureName, ns.Name, rollout.Percentage) {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// inRolloutPercentage returns true if the namespace is among the percentage of namespaces selected for the feature.
+// The selection is stable: a namespace selected for a percentage is selected for any higher percentage.
+func inRolloutPercentage(featureName, namespace string, percentage *int32) bool {
+	if percentage == nil {
+		return false
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(featureName + "/" + namespace))
+	return int32(h.Sum32()%100) < *percentage
+}
+
+// IsFeatureActivatedInNamespace returns true only if the feature is activated in the whole cluster, or its activation
+// is rolled out to the namespace.
+func IsFeatureActivatedInNamespace(ctx context.Context, c client.Client, featureName, namespace string) (bool, error) {
+	feature := &corev1alpha2.Feature{}
+	if err := c.Get(ctx, types.NamespacedName{
+		Name: featureName,
+	}, feature); err != nil {
+		return false, fmt.Errorf("could not retrieve feature %s :%w", featureName, err)
+	}
+
+	if feature.Status.Activated {
+		return true, nil
+	}
+	for _, ns := range feature.Status.Namespaces {
+		if ns == namespace {
+			return true, nil
+		}
+	}
+	return false, nil
+}
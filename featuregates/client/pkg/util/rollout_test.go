@@ -0,0 +1,124 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	stringcmp "github.com/vmware-tanzu/tanzu-framework/util/cmp/strings"
+)
+
+func TestNamespacesInRollout(t *testing.T) {
+	newNamespace := func(name string, labels map[string]string) runtime.Object {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	namespaces := []runtime.Object{
+		newNamespace("default", nil),
+		newNamespace("tenant-a", map[string]string{"tier": "early-adopter"}),
+		newNamespace("tenant-b", map[string]string{"tier": "early-adopter"}),
+		newNamespace("tenant-c", map[string]string{"tier": "standard"}),
+	}
+
+	testCases := []struct {
+		description string
+		rollout     *corev1alpha2.FeatureRollout
+		want        []string
+	}{
+		{
+			description: "Empty rollout selects no namespaces",
+			rollout:     &corev1alpha2.FeatureRollout{},
+			want:        []string{},
+		},
+		{
+			description: "Listed namespaces",
+			rollout:     &corev1alpha2.FeatureRollout{Namespaces: []string{"default", "missing"}},
+			want:        []string{"default"},
+		},
+		{
+			description: "Namespaces matching the selector and listed namespaces",
+			rollout: &corev1alpha2.FeatureRollout{
+				Namespaces:        []string{"default"},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "early-adopter"}},
+			},
+			want: []string{"default", "tenant-a", "tenant-b"},
+		},
+		{
+			description: "Full percentage selects all namespaces",
+			rollout:     &corev1alpha2.FeatureRollout{Percentage: pointer.Int32(100)},
+			want:        []string{"default", "tenant-a", "tenant-b", "tenant-c"},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(k8sscheme.Scheme).WithRuntimeObjects(namespaces...).Build()
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := NamespacesInRollout(context.Background(), c, "big-cache", tc.rollout)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := stringcmp.SliceDiffIgnoreOrder(got, tc.want); diff != "" {
+				t.Errorf("got namespaces %v, want %v, diff: %s", got, tc.want, diff)
+			}
+		})
+	}
+}
+
+func TestInRolloutPercentageIsStable(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		namespace := fmt.Sprintf("namespace-%d", i)
+		selected := false
+		for percentage := int32(0); percentage <= 100; percentage += 10 {
+			in := inRolloutPercentage("big-cache", namespace, &percentage)
+			if selected && !in {
+				t.Fatalf("namespace %s is not selected at %d%% but was selected for a lower percentage", namespace, percentage)
+			}
+			selected = in
+		}
+		if !selected {
+			t.Fatalf("namespace %s is not selected at 100%%", namespace)
+		}
+	}
+}
+
+func TestIsFeatureActivatedInNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1alpha2.Feature{ObjectMeta: metav1.ObjectMeta{Name: "cluster-wide"}, Status: corev1alpha2.FeatureStatus{Activated: true}},
+		&corev1alpha2.Feature{ObjectMeta: metav1.ObjectMeta{Name: "rolled-out"}, Status: corev1alpha2.FeatureStatus{Namespaces: []string{"tenant-a"}}},
+	).Build()
+
+	testCases := []struct {
+		feature   string
+		namespace string
+		want      bool
+	}{
+		{feature: "cluster-wide", namespace: "tenant-b", want: true},
+		{feature: "rolled-out", namespace: "tenant-a", want: true},
+		{feature: "rolled-out", namespace: "tenant-b", want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.feature+"/"+tc.namespace, func(t *testing.T) {
+			got, err := IsFeatureActivatedInNamespace(context.Background(), c, tc.feature, tc.namespace)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
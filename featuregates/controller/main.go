@@ -23,6 +23,7 @@ import (
 
 	configv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
 	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	coreActivationHistoryController "github.com/vmware-tanzu/tanzu-framework/featuregates/controller/pkg/activationhistory"
 	coreFeatureController "github.com/vmware-tanzu/tanzu-framework/featuregates/controller/pkg/feature"
 	configFeatureGateController "github.com/vmware-tanzu/tanzu-framework/featuregates/controller/pkg/featuregate"
 	"github.com/vmware-tanzu/tanzu-framework/util/buildinfo"
@@ -126,6 +127,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&coreActivationHistoryController.ActivationHistoryReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("ActivationHistory").WithValues("apigroup", "core"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("tanzu-featuregates-manager"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ActivationHistory", "apigroup", "core")
+		os.Exit(1)
+	}
+
 	if err = (&configv1alpha1.FeatureGate{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "FeatureGate", "apigroup", "config")
 		os.Exit(1)
@@ -136,6 +147,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&corev1alpha2.SupportStatus{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "SupportStatus", "apigroup", "core")
+		os.Exit(1)
+	}
+
+	if err = (&corev1alpha2.FeaturePolicy{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "FeaturePolicy", "apigroup", "core")
+		os.Exit(1)
+	}
+
 	//+kubebuilder:scaffold:builder
 
 	signalHandler := ctrl.SetupSignalHandler()
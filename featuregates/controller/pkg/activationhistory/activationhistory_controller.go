@@ -0,0 +1,173 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package activationhistory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+const contextTimeout = 30 * time.Second
+
+// ActivationHistoryReconciler moves the activation changes recorded by the FeatureGate webhook to the FeatureGate
+// status history, and records the support warranties they void in the SupportStatus of the environment.
+type ActivationHistoryReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=core.tanzu.vmware.com,resources=featuregates,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=core.tanzu.vmware.com,resources=featuregates/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core.tanzu.vmware.com,resources=supportstatuses,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile appends the pending activation changes of the FeatureGate to its status history, emits an Event for each
+// of them and clears them from the FeatureGate. The support warranties voided by the changes are recorded first, so
+// that they are recorded again if moving the changes to the history fails, and the WarrantyVoidsFinalizer is only
+// removed once they are, so that FeatureGates deleted before they are reconciled do not lose their warranty voids.
+func (r *ActivationHistoryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctxCancel, cancel := context.WithTimeout(ctx, contextTimeout)
+	defer cancel()
+
+	log := r.Log.WithValues("featuregate", req.NamespacedName)
+
+	featureGate := &corev1alpha2.FeatureGate{}
+	if err := r.Client.Get(ctxCancel, req.NamespacedName, featureGate); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	_, ok := featureGate.Annotations[corev1alpha2.PendingActivationChangesAnnotation]
+	if !ok && !controllerutil.ContainsFinalizer(featureGate, corev1alpha2.WarrantyVoidsFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	pending, err := corev1alpha2.GetPendingActivationChanges(featureGate)
+	if err != nil {
+		// The annotation is only written by the FeatureGate webhook, so it is not expected to be malformed.
+		log.Error(err, "Dropping malformed pending activation changes")
+	}
+
+	unrecorded := corev1alpha2.UnrecordedActivationChanges(pending, featureGate.Status.History)
+	if len(unrecorded) > 0 {
+		if err := r.recordWarrantyVoids(ctxCancel, featureGate.Name, unrecorded); err != nil {
+			return ctrl.Result{}, fmt.Errorf("could not record support warranties voided by %s FeatureGate: %w", featureGate.Name, err)
+		}
+		featureGate.Status.History = corev1alpha2.AppendActivationHistory(featureGate.Status.History, unrecorded)
+		if err := r.Client.Status().Update(ctxCancel, featureGate); err != nil {
+			return ctrl.Result{}, fmt.Errorf("could not update %s FeatureGate status: %w", featureGate.Name, err)
+		}
+		// Events are emitted once the changes are in the history, so that they are not emitted again on retries.
+		for i := range unrecorded {
+			r.recordEvent(featureGate, &unrecorded[i])
+		}
+	}
+
+	// Clearing the annotation goes through the FeatureGate webhook, which drops the changes now in the history. The
+	// warranty voids are recorded, so the FeatureGate can be deleted.
+	patch := client.MergeFrom(featureGate.DeepCopy())
+	delete(featureGate.Annotations, corev1alpha2.PendingActivationChangesAnnotation)
+	controllerutil.RemoveFinalizer(featureGate, corev1alpha2.WarrantyVoidsFinalizer)
+	if err := r.Client.Patch(ctxCancel, featureGate, patch); err != nil {
+		return ctrl.Result{}, fmt.Errorf("could not clear pending activation changes of %s FeatureGate: %w", featureGate.Name, err)
+	}
+
+	log.Info("Recorded activation changes", "count", len(unrecorded))
+	return ctrl.Result{}, nil
+}
+
+// recordWarrantyVoids appends the features whose support warranty is voided by the activation changes of the
+// FeatureGate to the SupportStatus of the environment, creating it if it does not exist
+func (r *ActivationHistoryReconciler) recordWarrantyVoids(ctx context.Context, featureGateName string, changes []corev1alpha2.FeatureActivationChange) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		supportStatus := &corev1alpha2.SupportStatus{}
+		err := r.Client.Get(ctx, client.ObjectKey{Name: corev1alpha2.SupportStatusName}, supportStatus)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		exists := err == nil
+
+		records := computeWarrantyVoids(featureGateName, changes, supportStatus)
+		if len(records) == 0 {
+			return nil
+		}
+		supportStatus.Spec.WarrantyVoids = append(supportStatus.Spec.WarrantyVoids, records...)
+		if exists {
+			return r.Client.Update(ctx, supportStatus)
+		}
+		supportStatus.Name = corev1alpha2.SupportStatusName
+		if err := r.Client.Create(ctx, supportStatus); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				// Created concurrently, retry as an update.
+				return apierrors.NewConflict(corev1alpha2.GroupVersion.WithResource("supportstatuses").GroupResource(), corev1alpha2.SupportStatusName, err)
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+// computeWarrantyVoids returns the records of the features whose support warranty is voided by the activation changes
+// of the FeatureGate and that are not recorded in the SupportStatus yet
+func computeWarrantyVoids(featureGateName string, changes []corev1alpha2.FeatureActivationChange, supportStatus *corev1alpha2.SupportStatus) []corev1alpha2.WarrantyVoidRecord {
+	recorded := map[string]bool{}
+	for _, record := range supportStatus.Spec.WarrantyVoids {
+		recorded[record.FeatureGate+"/"+record.Feature] = true
+	}
+
+	var records []corev1alpha2.WarrantyVoidRecord
+	for _, change := range changes {
+		if change.VoidedWarranty && !recorded[featureGateName+"/"+change.Feature] {
+			recorded[featureGateName+"/"+change.Feature] = true
+			records = append(records, corev1alpha2.WarrantyVoidRecord{
+				Feature:     change.Feature,
+				FeatureGate: featureGateName,
+				Actor:       change.Actor,
+				Time:        change.Time,
+			})
+		}
+	}
+	return records
+}
+
+// recordEvent emits an Event on the FeatureGate for an activation change
+func (r *ActivationHistoryReconciler) recordEvent(featureGate *corev1alpha2.FeatureGate, change *corev1alpha2.FeatureActivationChange) {
+	reason := "FeatureDeactivated"
+	if change.Activate {
+		reason = "FeatureActivated"
+	}
+	message := fmt.Sprintf("Feature %s set to activate=%t (was %t) by %s", change.Feature, change.Activate, change.PreviousActivate, change.Actor)
+	if change.Reason != "" {
+		message += fmt.Sprintf(": %s", change.Reason)
+	}
+	r.Recorder.Event(featureGate, corev1.EventTypeNormal, reason, message)
+
+	if change.VoidedWarranty {
+		r.Recorder.Eventf(featureGate, corev1.EventTypeWarning, "SupportWarrantyVoided",
+			"Feature %s permanently voided all support guarantees for this environment by %s", change.Feature, change.Actor)
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ActivationHistoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("activationhistory").
+		For(&corev1alpha2.FeatureGate{}, builder.WithPredicates(predicate.AnnotationChangedPredicate{})).
+		Complete(r)
+}
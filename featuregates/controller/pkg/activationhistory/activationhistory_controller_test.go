@@ -0,0 +1,197 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package activationhistory
+
+import (
+	"context"
+	"encoding/json"
+
+	"testing"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	changeTime := metav1.NewTime(time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC))
+	recorded := corev1alpha2.FeatureActivationChange{Feature: "foo", Actor: "admin", Time: changeTime, Activate: true}
+	pending := []corev1alpha2.FeatureActivationChange{
+		recorded,
+		{Feature: "bar", Actor: "operator", Time: changeTime, Activate: true, VoidedWarranty: true, Reason: "testing"},
+	}
+	value, err := json.Marshal(pending)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	featureGate := &corev1alpha2.FeatureGate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "tkg-system",
+			Annotations: map[string]string{corev1alpha2.PendingActivationChangesAnnotation: string(value)},
+		},
+		Status: corev1alpha2.FeatureGateStatus{History: []corev1alpha2.FeatureActivationChange{recorded}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(featureGate).Build()
+	recorder := record.NewFakeRecorder(10)
+
+	r := &ActivationHistoryReconciler{
+		Client:   c,
+		Log:      ctrl.Log.WithName("test"),
+		Scheme:   scheme,
+		Recorder: recorder,
+	}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "tkg-system"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := &corev1alpha2.FeatureGate{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "tkg-system"}, got); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got.Annotations[corev1alpha2.PendingActivationChangesAnnotation]; ok {
+		t.Errorf("expected pending activation changes to be cleared")
+	}
+	if len(got.Status.History) != 2 || got.Status.History[1].Feature != "bar" || got.Status.History[1].Reason != "testing" {
+		t.Errorf("unexpected history: %+v", got.Status.History)
+	}
+
+	supportStatus := &corev1alpha2.SupportStatus{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: corev1alpha2.SupportStatusName}, supportStatus); err != nil {
+		t.Fatal(err)
+	}
+	wantRecords := []corev1alpha2.WarrantyVoidRecord{{Feature: "bar", FeatureGate: "tkg-system", Actor: "operator", Time: changeTime}}
+	if !apiequality.Semantic.DeepEqual(supportStatus.Spec.WarrantyVoids, wantRecords) {
+		t.Errorf("got voided support warranties %+v, want %+v", supportStatus.Spec.WarrantyVoids, wantRecords)
+	}
+
+	wantEvents := []string{
+		"Normal FeatureActivated Feature bar set to activate=true (was false) by operator: testing",
+		"Warning SupportWarrantyVoided Feature bar permanently voided all support guarantees for this environment by operator",
+	}
+	for _, want := range wantEvents {
+		select {
+		case event := <-recorder.Events:
+			if event != want {
+				t.Errorf("got event %q, want %q", event, want)
+			}
+		default:
+			t.Errorf("missing event %q", want)
+		}
+	}
+	select {
+	case event := <-recorder.Events:
+		t.Errorf("unexpected event %q", event)
+	default:
+	}
+}
+
+func TestReconcileDeletedFeatureGate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	changeTime := metav1.NewTime(time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC))
+	value, err := json.Marshal([]corev1alpha2.FeatureActivationChange{
+		{Feature: "bar", Actor: "operator", Time: changeTime, Activate: true, VoidedWarranty: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The FeatureGate was deleted before its activation changes were reconciled
+	deletionTime := metav1.NewTime(changeTime.Add(time.Minute))
+	featureGate := &corev1alpha2.FeatureGate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "tkg-system",
+			Annotations:       map[string]string{corev1alpha2.PendingActivationChangesAnnotation: string(value)},
+			Finalizers:        []string{corev1alpha2.WarrantyVoidsFinalizer},
+			DeletionTimestamp: &deletionTime,
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(featureGate).Build()
+
+	r := &ActivationHistoryReconciler{
+		Client:   c,
+		Log:      ctrl.Log.WithName("test"),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "tkg-system"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	supportStatus := &corev1alpha2.SupportStatus{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: corev1alpha2.SupportStatusName}, supportStatus); err != nil {
+		t.Fatal(err)
+	}
+	wantRecords := []corev1alpha2.WarrantyVoidRecord{{Feature: "bar", FeatureGate: "tkg-system", Actor: "operator", Time: changeTime}}
+	if !apiequality.Semantic.DeepEqual(supportStatus.Spec.WarrantyVoids, wantRecords) {
+		t.Errorf("got voided support warranties %+v, want %+v", supportStatus.Spec.WarrantyVoids, wantRecords)
+	}
+
+	got := &corev1alpha2.FeatureGate{}
+	err = c.Get(context.Background(), types.NamespacedName{Name: "tkg-system"}, got)
+	if err != nil && !apierrors.IsNotFound(err) {
+		t.Fatal(err)
+	}
+	if err == nil && len(got.Finalizers) != 0 {
+		t.Errorf("expected the finalizer to be removed once the warranty voids are recorded, got %v", got.Finalizers)
+	}
+}
+
+func TestRecordWarrantyVoids(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &ActivationHistoryReconciler{Client: c, Log: ctrl.Log.WithName("test"), Scheme: scheme}
+	ctx := context.Background()
+	changeTime := metav1.NewTime(time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC))
+
+	changes := []corev1alpha2.FeatureActivationChange{
+		{Feature: "foo", Actor: "admin", Time: changeTime, Activate: true, VoidedWarranty: true},
+		{Feature: "bar", Actor: "admin", Time: changeTime, Activate: true},
+	}
+	if err := r.recordWarrantyVoids(ctx, "tkg-system", changes); err != nil {
+		t.Fatal(err)
+	}
+
+	// Voiding the warranty of another feature appends a record, while the already recorded one is kept as is.
+	laterTime := metav1.NewTime(changeTime.Add(time.Hour))
+	changes = []corev1alpha2.FeatureActivationChange{
+		{Feature: "foo", Actor: "operator", Time: laterTime, Activate: true, VoidedWarranty: true},
+		{Feature: "bar", Actor: "operator", Time: laterTime, Activate: true, VoidedWarranty: true},
+	}
+	if err := r.recordWarrantyVoids(ctx, "tkg-system", changes); err != nil {
+		t.Fatal(err)
+	}
+
+	supportStatus := &corev1alpha2.SupportStatus{}
+	if err := c.Get(ctx, types.NamespacedName{Name: corev1alpha2.SupportStatusName}, supportStatus); err != nil {
+		t.Fatal(err)
+	}
+	wantRecords := []corev1alpha2.WarrantyVoidRecord{
+		{Feature: "foo", FeatureGate: "tkg-system", Actor: "admin", Time: changeTime},
+		{Feature: "bar", FeatureGate: "tkg-system", Actor: "operator", Time: laterTime},
+	}
+	if !apiequality.Semantic.DeepEqual(supportStatus.Spec.WarrantyVoids, wantRecords) {
+		t.Errorf("got voided support warranties %+v, want %+v", supportStatus.Spec.WarrantyVoids, wantRecords)
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package activationhistory has the controller that records the changes to the activation of features in the status
+// history of the FeatureGates in core API group and as Events, and the support warranties they void in the SupportStatus
+// of the environment
+package activationhistory
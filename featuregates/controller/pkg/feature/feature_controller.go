@@ -6,10 +6,14 @@ package feature
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -36,6 +40,8 @@ type FeatureReconciler struct {
 // +kubebuilder:rbac:groups=core.tanzu.vmware.com,resources=featuregates/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=core.tanzu.vmware.com,resources=features,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core.tanzu.vmware.com,resources=features/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core.tanzu.vmware.com,resources=featurepolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 // Reconcile reconciles the FeatureGate spec by computing activated, deactivated and unavailable features.
 func (r *FeatureReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -61,17 +67,29 @@ func (r *FeatureReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, err
 	}
 
-	// Check if the feature is part of any FeatureGate spec
-	featureGate, found, err := util.GetFeatureGateForFeature(ctx, r.Client, feature.Name)
+	// Stability level policies are resolved through the FeaturePolicy resources
+	featurePolicies := &corev1alpha2.FeaturePolicyList{}
+	if err := r.Client.List(ctxCancel, featurePolicies); err != nil {
+		return ctrl.Result{}, fmt.Errorf("could not list FeaturePolicy resources: %w", err)
+	}
+
+	featureGates := &corev1alpha2.FeatureGateList{}
+	if err := r.Client.List(ctxCancel, featureGates); err != nil {
+		return ctrl.Result{}, fmt.Errorf("could not list FeatureGate resources: %w", err)
+	}
+
+	// The FeatureGate with the highest priority that references the feature decides its activation. The references of
+	// the other FeatureGates are overridden, and the results of the FeatureGates that no longer reference the feature
+	// are removed from their status.
+	featureGate, found := corev1alpha2.GetWinningFeatureGate(featureGates.Items, feature.Name)
+	overridden, err := reconcileOverriddenFeatureReferences(ctx, r.Client, featureGates.Items, featureGate, feature.Name)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	// If the feature is not found in any FeatureGate spec. Check if it exists in the FeatureGate status.
-	// If found in the FeatureGate status, remove its entry from Results and update the feature status to default
-	// activation
+	// If the feature is not found in any FeatureGate spec, update the feature status to default activation
 	if !found {
-		if err := reconcileFeatureNotInFeatureGateSpec(ctx, r.Client, feature); err != nil {
+		if err := reconcileFeatureNotInFeatureGateSpec(ctx, r.Client, feature, featurePolicies.Items); err != nil {
 			return ctrl.Result{}, err
 		}
 		return ctrl.Result{}, nil
@@ -79,17 +97,109 @@ func (r *FeatureReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 	// If the feature is found in any FeatureGate spec, update the Results in FeatureGate status and the feature status
 	// to the intent specified in the FeatureGate spec
-	if err := reconcileFeatureInFeatureGateSpec(ctx, r.Client, featureGate, feature); err != nil {
+	if err := reconcileFeatureInFeatureGateSpec(ctx, r.Client, featureGate, overridden, feature, featurePolicies.Items); err != nil {
 		return ctrl.Result{}, err
 	}
 	return ctrl.Result{}, nil
 }
 
+// reconcileOverriddenFeatureReferences sets the result of the references to the feature that are overridden by the
+// reference of the winning FeatureGate as Overridden, and removes the result of the feature from the status of the
+// FeatureGates that no longer reference it. It returns the names of the FeatureGates whose references are overridden.
+func reconcileOverriddenFeatureReferences(ctx context.Context, c client.Client, featureGates []corev1alpha2.FeatureGate, winner *corev1alpha2.FeatureGate, featureName string) ([]string, error) {
+	var overridden []string
+	for i := range featureGates {
+		featureGate := &featureGates[i]
+		if winner != nil && featureGate.Name == winner.Name {
+			continue
+		}
+
+		result, hasResult := getFeatureReferenceResult(featureGate.Status, featureName)
+		if _, referenced := util.GetFeatureReferenceFromFeatureGate(featureGate, featureName); referenced {
+			overridden = append(overridden, featureGate.Name)
+			message := fmt.Sprintf("Feature reference is overridden by FeatureGate %s with priority %d", winner.Name, winner.Spec.Priority)
+			if hasResult && result.Status == corev1alpha2.OverriddenReferenceStatus && result.Message == message {
+				continue
+			}
+			featureGate.Status.FeatureReferenceResults = computeFeatureGateStatusResults(featureGate.Status, corev1alpha2.FeatureReferenceResult{
+				Name:    featureName,
+				Status:  corev1alpha2.OverriddenReferenceStatus,
+				Message: message,
+			}, true)
+		} else if hasResult {
+			featureGate.Status.FeatureReferenceResults = computeFeatureGateStatusResults(featureGate.Status, corev1alpha2.FeatureReferenceResult{
+				Name: featureName,
+			}, false)
+		} else {
+			continue
+		}
+
+		if err := c.Status().Update(ctx, featureGate); err != nil {
+			return nil, fmt.Errorf("could not update %s FeatureGate status :%w", featureGate.Name, err)
+		}
+	}
+	sort.Strings(overridden)
+	return overridden, nil
+}
+
+// getFeatureReferenceResult returns the result of the feature reference from FeatureGate status
+func getFeatureReferenceResult(featureGateStatus corev1alpha2.FeatureGateStatus, featureName string) (corev1alpha2.FeatureReferenceResult, bool) {
+	for _, result := range featureGateStatus.FeatureReferenceResults {
+		if result.Name == featureName {
+			return result, true
+		}
+	}
+	return corev1alpha2.FeatureReferenceResult{}, false
+}
+
 // reconcileFeatureInFeatureGateSpec reconciles Feature resource that is present in FeatureGate spec
-func reconcileFeatureInFeatureGateSpec(ctx context.Context, c client.Client, featureGate *corev1alpha2.FeatureGate, feature *corev1alpha2.Feature) error {
-	policy := corev1alpha2.GetPolicyForStabilityLevel(feature.Spec.Stability)
+func reconcileFeatureInFeatureGateSpec(ctx context.Context, c client.Client, featureGate *corev1alpha2.FeatureGate, overridden []string, feature *corev1alpha2.Feature, featurePolicies []corev1alpha2.FeaturePolicy) error {
+	policy := corev1alpha2.GetPolicyForStabilityLevel(feature.Spec.Stability, featurePolicies...)
 	featureReference, _ := util.GetFeatureReferenceFromFeatureGate(featureGate, feature.Name)
 	featureResult, activate := applyPolicyToComputeFeatureResultAndActivation(policy, featureReference)
+	if !corev1alpha2.IsStabilityLevelDefined(feature.Spec.Stability, featurePolicies...) {
+		featureResult.Status = corev1alpha2.InvalidReferenceStatus
+		featureResult.Message = fmt.Sprintf("Feature could not be toggled because its stability level %q is not defined by any FeaturePolicy", feature.Spec.Stability)
+		activate = policy.DefaultActivation
+	}
+	reason := corev1alpha2.ExplicitActivationReason
+	message := fmt.Sprintf("Feature activation is set by FeatureGate %s with priority %d", featureGate.Name, featureGate.Spec.Priority)
+	if len(overridden) > 0 {
+		message = fmt.Sprintf("%s, overriding FeatureGates %v", message, overridden)
+	}
+	if featureResult.Status == corev1alpha2.InvalidReferenceStatus {
+		reason = corev1alpha2.PolicyBlockedActivationReason
+		message = featureResult.Message
+	}
+
+	// A feature is only activated if its dependencies are activated and it does not conflict with activated features.
+	// A feature that is rolled out to a subset of the namespaces is checked as if it was activated.
+	rollout := featureResult.Status == corev1alpha2.AppliedReferenceStatus && activate && featureReference.Rollout != nil
+	rolloutFeature := ""
+	if rollout {
+		rolloutFeature = feature.Name
+	}
+	activated, blocked, err := computeFeatureActivation(ctx, c, featurePolicies, rolloutFeature)
+	if err != nil {
+		return err
+	}
+	feature.Status.Namespaces = nil
+	if featureResult.Status == corev1alpha2.AppliedReferenceStatus && activate && !activated[feature.Name] {
+		featureResult.Status = corev1alpha2.BlockedReferenceStatus
+		featureResult.Message = fmt.Sprintf("Feature could not be activated: %s", blocked[feature.Name])
+		activate = false
+		reason = corev1alpha2.DependencyBlockedActivationReason
+		message = featureResult.Message
+	} else if rollout {
+		namespaces, err := util.NamespacesInRollout(ctx, c, feature.Name, featureReference.Rollout)
+		if err != nil {
+			return err
+		}
+		featureResult.Message = fmt.Sprintf("Feature has been rolled out to %d namespace(s)", len(namespaces))
+		feature.Status.Namespaces = namespaces
+		activate = false
+		message = fmt.Sprintf("%s by FeatureGate %s with priority %d", featureResult.Message, featureGate.Name, featureGate.Spec.Priority)
+	}
 
 	// Update FeatureGate status
 	featureGate.Status.FeatureReferenceResults = computeFeatureGateStatusResults(featureGate.Status, featureResult, true)
@@ -98,22 +208,18 @@ func reconcileFeatureInFeatureGateSpec(ctx context.Context, c client.Client, fea
 	}
 
 	// Update Feature status to the intent specified in the FeatureGate spec
-	feature.Status.Activated = activate
-	if err := c.Update(ctx, feature); err != nil {
-		return fmt.Errorf("could not update %s Feature status :%w", feature.Name, err)
-	}
-	return nil
+	return updateFeatureStatus(ctx, c, feature, featureGate, overridden, activate, reason, message)
 }
 
 // reconcileDeletedFeature reconciles Feature resource that has been deleted
 func reconcileDeletedFeature(ctx context.Context, c client.Client, featureName string) error {
-	// Check if the feature is part of any FeatureGate spec and update the feature Result in FeatureGate status to
-	// Invalid
-	featureGate, found, err := util.GetFeatureGateForFeature(ctx, c, featureName)
-	if err != nil {
-		return err
+	// Check if the feature is part of any FeatureGate spec and update the feature Result in the status of these
+	// FeatureGates to Invalid
+	featureGates := &corev1alpha2.FeatureGateList{}
+	if err := c.List(ctx, featureGates); err != nil {
+		return fmt.Errorf("could not list FeatureGate resources: %w", err)
 	}
-	if found {
+	for _, featureGate := range corev1alpha2.GetFeatureGatesForFeature(featureGates.Items, featureName) {
 		featureGate.Status.FeatureReferenceResults = computeFeatureGateStatusResults(featureGate.Status, corev1alpha2.FeatureReferenceResult{
 			Name:    featureName,
 			Status:  corev1alpha2.InvalidReferenceStatus,
@@ -127,32 +233,89 @@ func reconcileDeletedFeature(ctx context.Context, c client.Client, featureName s
 }
 
 // reconcileFeatureNotInFeatureGateSpec reconciles Feature resource that is not found in any FeatureGate spec
-func reconcileFeatureNotInFeatureGateSpec(ctx context.Context, c client.Client, feature *corev1alpha2.Feature) error {
-	// If feature is not part of any FeatureGate spec, check if it exists in any FeatureGate status.
-	// If found in the FeatureGate status, remove its entry from Results in FeatureGate status and update the
-	// feature status to default activation
-	featureGate, found, err := util.GetFeatureGateWithFeatureInStatus(ctx, c, feature.Name)
-	policy := corev1alpha2.GetPolicyForStabilityLevel(feature.Spec.Stability)
+func reconcileFeatureNotInFeatureGateSpec(ctx context.Context, c client.Client, feature *corev1alpha2.Feature, featurePolicies []corev1alpha2.FeaturePolicy) error {
+	policy := corev1alpha2.GetPolicyForStabilityLevel(feature.Spec.Stability, featurePolicies...)
+
+	// Update Feature status to set feature to its default activation, if its dependencies and conflicts allow it
+	activated, blocked, err := computeFeatureActivation(ctx, c, featurePolicies, "")
 	if err != nil {
 		return err
 	}
-	if found {
-		// Remove feature from FeatureGate status
-		featureGate.Status.FeatureReferenceResults = computeFeatureGateStatusResults(featureGate.Status, corev1alpha2.FeatureReferenceResult{
-			Name: feature.Name,
-		}, false)
-		if err := c.Status().Update(ctx, featureGate); err != nil {
-			return fmt.Errorf("could not update %s FeatureGate status :%w", featureGate.Name, err)
-		}
+	reason := corev1alpha2.DefaultActivationReason
+	message := "Feature is set to the default activation of its stability level"
+	if policy.DefaultActivation && !activated[feature.Name] {
+		reason = corev1alpha2.DependencyBlockedActivationReason
+		message = fmt.Sprintf("Feature could not be activated: %s", blocked[feature.Name])
 	}
-	// Update Feature status to set feature as deactivated
-	feature.Status.Activated = policy.DefaultActivation
-	if err := c.Update(ctx, feature); err != nil {
+	feature.Status.Namespaces = nil
+	return updateFeatureStatus(ctx, c, feature, nil, nil, policy.DefaultActivation && activated[feature.Name], reason, message)
+}
+
+// updateFeatureStatus sets the activation of the feature, along with the FeatureGate that controls it, the FeatureGates
+// it overrides and the reason for it, and writes the feature status
+func updateFeatureStatus(ctx context.Context, c client.Client, feature *corev1alpha2.Feature, featureGate *corev1alpha2.FeatureGate, overridden []string, activate bool, reason corev1alpha2.FeatureActivationReason, message string) error {
+	setFeatureStatus(feature, featureGate, overridden, activate, reason, message, metav1.Now())
+	if err := c.Status().Update(ctx, feature); err != nil {
 		return fmt.Errorf("could not update %s Feature status :%w", feature.Name, err)
 	}
 	return nil
 }
 
+// setFeatureStatus sets the activation of the feature, along with the FeatureGate that controls it, the FeatureGates
+// it overrides and the reason for it. The last transition time is only updated when the activation changes.
+func setFeatureStatus(feature *corev1alpha2.Feature, featureGate *corev1alpha2.FeatureGate, overridden []string, activate bool, reason corev1alpha2.FeatureActivationReason, message string, now metav1.Time) {
+	if feature.Status.LastTransitionTime == nil || feature.Status.Activated != activate {
+		feature.Status.LastTransitionTime = &now
+	}
+	feature.Status.Activated = activate
+	feature.Status.FeatureGate = ""
+	feature.Status.FeatureGatePriority = nil
+	if featureGate != nil {
+		priority := featureGate.Spec.Priority
+		feature.Status.FeatureGate = featureGate.Name
+		feature.Status.FeatureGatePriority = &priority
+	}
+	feature.Status.OverriddenFeatureGates = overridden
+	feature.Status.Reason = reason
+	feature.Status.ObservedGeneration = feature.Generation
+
+	conditionStatus := metav1.ConditionFalse
+	if activate {
+		conditionStatus = metav1.ConditionTrue
+	}
+	meta.SetStatusCondition(&feature.Status.Conditions, metav1.Condition{
+		Type:               corev1alpha2.FeatureActivatedCondition,
+		Status:             conditionStatus,
+		ObservedGeneration: feature.Generation,
+		LastTransitionTime: now,
+		Reason:             string(reason),
+		Message:            message,
+	})
+}
+
+// computeFeatureActivation computes the effective activation of all the features from the intent in the FeatureGates,
+// taking the dependencies and conflicts between features into account. It also returns the reasons why features
+// that are intended to be activated are blocked. If rolloutFeature is not empty, that feature is considered to be
+// intended to be activated.
+func computeFeatureActivation(ctx context.Context, c client.Client, featurePolicies []corev1alpha2.FeaturePolicy, rolloutFeature string) (map[string]bool, map[string]string, error) {
+	features := &corev1alpha2.FeatureList{}
+	if err := c.List(ctx, features); err != nil {
+		return nil, nil, fmt.Errorf("could not list Feature resources: %w", err)
+	}
+
+	featureGates := &corev1alpha2.FeatureGateList{}
+	if err := c.List(ctx, featureGates); err != nil {
+		return nil, nil, fmt.Errorf("could not list FeatureGate resources: %w", err)
+	}
+
+	intents := corev1alpha2.ComputeFeatureIntents(features.Items, featureGates.Items, nil, featurePolicies)
+	if rolloutFeature != "" {
+		intents[rolloutFeature] = true
+	}
+	activated, blocked := corev1alpha2.ComputeFeatureActivation(features.Items, intents)
+	return activated, blocked, nil
+}
+
 // applyPolicyToComputeFeatureResultAndActivation applies stability level policy and returns feature result for
 // FeatureGate status and feature activate status
 func applyPolicyToComputeFeatureResultAndActivation(policy corev1alpha2.Policy, featureRef corev1alpha2.FeatureReference) (corev1alpha2.FeatureReferenceResult, bool) {
@@ -220,18 +383,94 @@ func (r *FeatureReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(
 			&source.Kind{Type: &corev1alpha2.FeatureGate{}},
 			handler.EnqueueRequestsFromMapFunc(r.toFeatureRequests)).
+		Watches(
+			&source.Kind{Type: &corev1alpha2.Feature{}},
+			handler.EnqueueRequestsFromMapFunc(r.toRelatedFeatureRequests)).
+		Watches(
+			&source.Kind{Type: &corev1.Namespace{}},
+			handler.EnqueueRequestsFromMapFunc(r.toRolloutFeatureRequests)).
+		Watches(
+			&source.Kind{Type: &corev1alpha2.FeaturePolicy{}},
+			handler.EnqueueRequestsFromMapFunc(r.toAllFeatureRequests)).
 		Complete(r)
 }
 
+// toAllFeatureRequests enqueues all the features, as the policies of their stability levels may change with the
+// FeaturePolicy
+func (r *FeatureReconciler) toAllFeatureRequests(_ client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+
+	features := &corev1alpha2.FeatureList{}
+	if err := r.Client.List(context.Background(), features); err != nil {
+		r.Log.Error(err, "failed to list features in event handler")
+		return requests
+	}
+
+	for i := range features.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: features.Items[i].Name,
+			},
+		})
+	}
+	return requests
+}
+
+// toRolloutFeatureRequests enqueues the features that are rolled out to a subset of the namespaces, as the
+// namespaces they are activated in may change with the namespace
+func (r *FeatureReconciler) toRolloutFeatureRequests(_ client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+
+	featureGates := &corev1alpha2.FeatureGateList{}
+	if err := r.Client.List(context.Background(), featureGates); err != nil {
+		r.Log.Error(err, "failed to list featuregates in event handler")
+		return requests
+	}
+
+	for i := range featureGates.Items {
+		for _, featureRef := range featureGates.Items[i].Spec.Features {
+			if featureRef.Rollout == nil {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: featureRef.Name,
+				},
+			})
+		}
+	}
+	return requests
+}
+
+// toRelatedFeatureRequests enqueues the features that depend on or conflict with the changed feature, and the
+// features it depends on or conflicts with, as their activation may change with it
+func (r *FeatureReconciler) toRelatedFeatureRequests(o client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+
+	features := &corev1alpha2.FeatureList{}
+	if err := r.Client.List(context.Background(), features); err != nil {
+		r.Log.Error(err, "failed to list features in event handler")
+		return requests
+	}
+
+	for _, feature := range corev1alpha2.FeaturesRelatedTo(features.Items, o.GetName()) {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: feature,
+			},
+		})
+	}
+	return requests
+}
+
+// toFeatureRequests enqueues the features referenced by the changed FeatureGate. The requests are built from the event
+// object rather than from the cluster, so that the features referenced by a deleted FeatureGate are reconciled too.
 func (r *FeatureReconciler) toFeatureRequests(o client.Object) []reconcile.Request {
 	var requests []reconcile.Request
 
-	featureGate := &corev1alpha2.FeatureGate{}
-	if err := r.Client.Get(context.Background(), types.NamespacedName{
-		Name: o.GetName(),
-	}, featureGate); err != nil {
-		r.Log.Error(err, "failed to get featuregate in event handler",
-			"Featuregate", o.GetName())
+	featureGate, ok := o.(*corev1alpha2.FeatureGate)
+	if !ok {
+		r.Log.Error(nil, "unexpected object in featuregate event handler", "type", fmt.Sprintf("%T", o))
 		return requests
 	}
 
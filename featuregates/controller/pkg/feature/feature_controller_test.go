@@ -0,0 +1,99 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package feature
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+func TestSetFeatureStatus(t *testing.T) {
+	start := metav1.NewTime(time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(start.Add(time.Hour))
+	feature := &corev1alpha2.Feature{ObjectMeta: metav1.ObjectMeta{Name: "foo", Generation: 2}}
+	featureGate := &corev1alpha2.FeatureGate{
+		ObjectMeta: metav1.ObjectMeta{Name: "tkg-system"},
+		Spec:       corev1alpha2.FeatureGateSpec{Priority: 100},
+	}
+
+	setFeatureStatus(feature, nil, nil, false, corev1alpha2.DefaultActivationReason, "default", start)
+	if feature.Status.LastTransitionTime == nil || !feature.Status.LastTransitionTime.Equal(&start) {
+		t.Errorf("expected the last transition time to be set on the first reconcile, got %v", feature.Status.LastTransitionTime)
+	}
+	if feature.Status.ObservedGeneration != 2 || feature.Status.Reason != corev1alpha2.DefaultActivationReason {
+		t.Errorf("unexpected status: %+v", feature.Status)
+	}
+
+	// The reason changes, but not the activation
+	setFeatureStatus(feature, featureGate, nil, false, corev1alpha2.PolicyBlockedActivationReason, "immutable", later)
+	if !feature.Status.LastTransitionTime.Equal(&start) {
+		t.Errorf("expected the last transition time to be kept, got %v", feature.Status.LastTransitionTime)
+	}
+	if feature.Status.FeatureGate != "tkg-system" || feature.Status.Reason != corev1alpha2.PolicyBlockedActivationReason {
+		t.Errorf("unexpected status: %+v", feature.Status)
+	}
+	if feature.Status.FeatureGatePriority == nil || *feature.Status.FeatureGatePriority != 100 {
+		t.Errorf("expected the priority of the FeatureGate to be set, got %v", feature.Status.FeatureGatePriority)
+	}
+
+	setFeatureStatus(feature, featureGate, []string{"baseline"}, true, corev1alpha2.ExplicitActivationReason, "activated", later)
+	if !feature.Status.LastTransitionTime.Equal(&later) {
+		t.Errorf("expected the last transition time to be updated, got %v", feature.Status.LastTransitionTime)
+	}
+	if len(feature.Status.OverriddenFeatureGates) != 1 || feature.Status.OverriddenFeatureGates[0] != "baseline" {
+		t.Errorf("unexpected overridden FeatureGates: %v", feature.Status.OverriddenFeatureGates)
+	}
+	condition := meta.FindStatusCondition(feature.Status.Conditions, corev1alpha2.FeatureActivatedCondition)
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Reason != string(corev1alpha2.ExplicitActivationReason) || condition.ObservedGeneration != 2 {
+		t.Errorf("unexpected condition: %+v", condition)
+	}
+
+	// The feature is no longer referenced by any FeatureGate
+	setFeatureStatus(feature, nil, nil, false, corev1alpha2.DefaultActivationReason, "default", later)
+	if feature.Status.FeatureGate != "" || feature.Status.FeatureGatePriority != nil || feature.Status.OverriddenFeatureGates != nil {
+		t.Errorf("expected the FeatureGates to be cleared, got %+v", feature.Status)
+	}
+}
+
+func TestToFeatureRequestsOfDeletedFeatureGate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	// The FeatureGate is deleted, so it is not in the cluster anymore
+	r := &FeatureReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Log:    ctrl.Log.WithName("test"),
+		Scheme: scheme,
+	}
+	featureGate := &corev1alpha2.FeatureGate{
+		ObjectMeta: metav1.ObjectMeta{Name: "override"},
+		Spec: corev1alpha2.FeatureGateSpec{
+			Priority: 100,
+			Features: []corev1alpha2.FeatureReference{{Name: "foo"}},
+		},
+		Status: corev1alpha2.FeatureGateStatus{
+			FeatureReferenceResults: []corev1alpha2.FeatureReferenceResult{{Name: "bar"}},
+		},
+	}
+
+	var got []string
+	for _, request := range r.toFeatureRequests(featureGate) {
+		got = append(got, request.Name)
+	}
+	sort.Strings(got)
+	if want := []string{"bar", "foo"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got requests for %v, want %v", got, want)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
@@ -112,6 +113,7 @@ var _ = BeforeSuite(func() {
 	Expect(cfg).NotTo(BeNil())
 
 	testEnv.ControlPlane.APIServer.Configure().Append("admission-control", "ValidatingAdmissionWebhook")
+	testEnv.ControlPlane.APIServer.Configure().Append("admission-control", "MutatingAdmissionWebhook")
 
 	err = corev1alpha2.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
@@ -154,6 +156,12 @@ var _ = BeforeSuite(func() {
 	err = (&corev1alpha2.FeatureGate{}).SetupWebhookWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&corev1alpha2.SupportStatus{}).SetupWebhookWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&corev1alpha2.FeaturePolicy{}).SetupWebhookWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	go func() {
 		defer GinkgoRecover()
 		err = k8sManager.Start(ctx)
@@ -237,6 +245,10 @@ var _ = Describe("Featuregate controller", func() {
 		Expect(featureGate.Status.FeatureReferenceResults[0].Status).Should(Equal(corev1alpha2.FeatureReferenceStatus("Applied")))
 		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: feature.Name}, feature)).Should(BeNil())
 		Expect(feature.Status.Activated).Should(Equal(false))
+		Expect(feature.Status.FeatureGate).Should(Equal(featureGate.Name))
+		Expect(feature.Status.Reason).Should(Equal(corev1alpha2.ExplicitActivationReason))
+		Expect(feature.Status.ObservedGeneration).Should(Equal(feature.Generation))
+		Expect(meta.IsStatusConditionFalse(feature.Status.Conditions, corev1alpha2.FeatureActivatedCondition)).Should(BeTrue())
 
 		featureGate.Spec.Features[0].Activate = true
 		Expect(k8sClient.Update(ctx, featureGate)).ShouldNot(Succeed())
@@ -453,4 +465,187 @@ var _ = Describe("Featuregate controller", func() {
 		Expect(k8sClient.Delete(ctx, feature)).Should(BeNil())
 		Expect(k8sClient.Delete(ctx, featureGate)).Should(BeNil())
 	})
+
+	It("Should activate features only when their dependencies are activated", func() {
+		dependency := getTestFeature(corev1alpha2.TechnicalPreview)
+		Expect(k8sClient.Create(ctx, dependency)).Should(Succeed())
+		feature := getTestFeature(corev1alpha2.TechnicalPreview)
+		feature.Spec.DependsOn = []string{dependency.Name}
+		Expect(k8sClient.Create(ctx, feature)).Should(Succeed())
+
+		// Activating a feature without its dependency is rejected
+		featureGate := getTestFeatureGate()
+		featureGate.Spec.Features = append(featureGate.Spec.Features, corev1alpha2.FeatureReference{
+			Name:     feature.Name,
+			Activate: true,
+		})
+		Expect(k8sClient.Create(ctx, featureGate)).ShouldNot(Succeed())
+
+		featureGate.Spec.Features = append(featureGate.Spec.Features, corev1alpha2.FeatureReference{
+			Name:     dependency.Name,
+			Activate: true,
+		})
+		Expect(k8sClient.Create(ctx, featureGate)).Should(Succeed())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: feature.Name}, feature)
+			return err == nil && feature.Status.Activated == true
+		}, timeout, interval).Should(BeTrue())
+
+		// Deactivating the dependency of an activated feature is rejected
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: featureGate.Name}, featureGate)).Should(Succeed())
+		featureGate.Spec.Features[1].Activate = false
+		Expect(k8sClient.Update(ctx, featureGate)).ShouldNot(Succeed())
+
+		// The feature is blocked once its dependency no longer exists
+		Expect(k8sClient.Delete(ctx, dependency)).Should(BeNil())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: feature.Name}, feature)
+			return err == nil && feature.Status.Activated == false
+		}, timeout, interval).Should(BeTrue())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: featureGate.Name}, featureGate)
+			if err != nil {
+				return false
+			}
+			for _, result := range featureGate.Status.FeatureReferenceResults {
+				if result.Name == feature.Name {
+					return result.Status == corev1alpha2.BlockedReferenceStatus
+				}
+			}
+			return false
+		}, timeout, interval).Should(BeTrue())
+
+		Expect(k8sClient.Delete(ctx, feature)).Should(BeNil())
+		Expect(k8sClient.Delete(ctx, featureGate)).Should(BeNil())
+	})
+
+	It("Should activate rolled out features only in the selected namespaces", func() {
+		feature := getTestFeature(corev1alpha2.TechnicalPreview)
+		Expect(k8sClient.Create(ctx, feature)).Should(Succeed())
+
+		featureGate := getTestFeatureGate()
+		featureGate.Spec.Features = append(featureGate.Spec.Features, corev1alpha2.FeatureReference{
+			Name:     feature.Name,
+			Activate: true,
+			Rollout:  &corev1alpha2.FeatureRollout{Namespaces: []string{"tkg-system"}},
+		})
+		Expect(k8sClient.Create(ctx, featureGate)).Should(Succeed())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: feature.Name}, feature)
+			return err == nil && len(feature.Status.Namespaces) == 1
+		}, timeout, interval).Should(BeTrue())
+
+		Expect(feature.Status.Activated).Should(Equal(false))
+		Expect(feature.Status.Namespaces).Should(Equal([]string{"tkg-system"}))
+
+		// Removing the rollout activates the feature in the whole cluster
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: featureGate.Name}, featureGate)).Should(Succeed())
+		featureGate.Spec.Features[0].Rollout = nil
+		Expect(k8sClient.Update(ctx, featureGate)).Should(Succeed())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: feature.Name}, feature)
+			return err == nil && feature.Status.Activated == true && len(feature.Status.Namespaces) == 0
+		}, timeout, interval).Should(BeTrue())
+
+		Expect(k8sClient.Delete(ctx, feature)).Should(BeNil())
+		Expect(k8sClient.Delete(ctx, featureGate)).Should(BeNil())
+	})
+
+	It("Should let the featuregate with the highest priority decide the activation of a feature", func() {
+		feature := getTestFeature(corev1alpha2.TechnicalPreview)
+		Expect(k8sClient.Create(ctx, feature)).Should(Succeed())
+
+		baseline := getTestFeatureGate()
+		baseline.Spec.Features = append(baseline.Spec.Features, corev1alpha2.FeatureReference{
+			Name:     feature.Name,
+			Activate: true,
+		})
+		Expect(k8sClient.Create(ctx, baseline)).Should(Succeed())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: feature.Name}, feature)
+			return err == nil && feature.Status.Activated == true
+		}, timeout, interval).Should(BeTrue())
+
+		// A featuregate with the same priority cannot reference the feature
+		override := getTestFeatureGate()
+		override.Spec.Features = append(override.Spec.Features, corev1alpha2.FeatureReference{
+			Name:     feature.Name,
+			Activate: false,
+		})
+		Expect(k8sClient.Create(ctx, override)).ShouldNot(Succeed())
+
+		override.Spec.Priority = 100
+		Expect(k8sClient.Create(ctx, override)).Should(Succeed())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: feature.Name}, feature)
+			return err == nil && feature.Status.Activated == false && feature.Status.FeatureGate == override.Name
+		}, timeout, interval).Should(BeTrue())
+
+		Expect(*feature.Status.FeatureGatePriority).Should(Equal(int32(100)))
+		Expect(feature.Status.OverriddenFeatureGates).Should(Equal([]string{baseline.Name}))
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: baseline.Name}, baseline)
+			return err == nil && len(baseline.Status.FeatureReferenceResults) == 1 &&
+				baseline.Status.FeatureReferenceResults[0].Status == corev1alpha2.OverriddenReferenceStatus
+		}, timeout, interval).Should(BeTrue())
+
+		// Lowering the priority of the override lets the baseline featuregate decide again
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: override.Name}, override)).Should(Succeed())
+		override.Spec.Priority = -1
+		Expect(k8sClient.Update(ctx, override)).Should(Succeed())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: feature.Name}, feature)
+			return err == nil && feature.Status.Activated == true && feature.Status.FeatureGate == baseline.Name
+		}, timeout, interval).Should(BeTrue())
+
+		Expect(k8sClient.Delete(ctx, feature)).Should(BeNil())
+		Expect(k8sClient.Delete(ctx, override)).Should(BeNil())
+		Expect(k8sClient.Delete(ctx, baseline)).Should(BeNil())
+	})
+
+	It("Should let the baseline featuregate decide again when the override featuregate is deleted", func() {
+		feature := getTestFeature(corev1alpha2.TechnicalPreview)
+		Expect(k8sClient.Create(ctx, feature)).Should(Succeed())
+
+		baseline := getTestFeatureGate()
+		baseline.Spec.Features = append(baseline.Spec.Features, corev1alpha2.FeatureReference{
+			Name:     feature.Name,
+			Activate: true,
+		})
+		Expect(k8sClient.Create(ctx, baseline)).Should(Succeed())
+
+		override := getTestFeatureGate()
+		override.Spec.Priority = 100
+		override.Spec.Features = append(override.Spec.Features, corev1alpha2.FeatureReference{
+			Name:     feature.Name,
+			Activate: false,
+		})
+		Expect(k8sClient.Create(ctx, override)).Should(Succeed())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: feature.Name}, feature)
+			return err == nil && feature.Status.Activated == false && feature.Status.FeatureGate == override.Name
+		}, timeout, interval).Should(BeTrue())
+
+		Expect(k8sClient.Delete(ctx, override)).Should(BeNil())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: feature.Name}, feature)
+			return err == nil && feature.Status.Activated == true && feature.Status.FeatureGate == baseline.Name
+		}, timeout, interval).Should(BeTrue())
+
+		Expect(feature.Status.OverriddenFeatureGates).Should(BeEmpty())
+
+		Expect(k8sClient.Delete(ctx, feature)).Should(BeNil())
+		Expect(k8sClient.Delete(ctx, baseline)).Should(BeNil())
+	})
 })
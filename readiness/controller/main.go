@@ -19,11 +19,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
 	capabilitiesdiscovery "github.com/vmware-tanzu/tanzu-framework/capabilities/client/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-framework/readiness/controller/pkg/clusterreadiness"
 	"github.com/vmware-tanzu/tanzu-framework/readiness/controller/pkg/conditions"
 	readinesscontroller "github.com/vmware-tanzu/tanzu-framework/readiness/controller/pkg/readiness"
+	"github.com/vmware-tanzu/tanzu-framework/readiness/controller/pkg/readinessgate"
 	readinessprovidercontroller "github.com/vmware-tanzu/tanzu-framework/readiness/controller/pkg/readinessprovider"
 	"github.com/vmware-tanzu/tanzu-framework/util/webhook/certs"
 	//+kubebuilder:scaffold:imports
@@ -84,8 +87,10 @@ func main() {
 
 	var metricsAddr string
 	var probeAddr string
+	var enableReadinessGating bool
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.BoolVar(&enableReadinessGating, "enable-readiness-gating", false, "Hold the workloads annotated with "+readinessgate.RequiresAnnotation+" until the Readiness is ready.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -133,32 +138,65 @@ func main() {
 	}
 
 	if err = (&readinesscontroller.ReadinessReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Log:    ctrl.Log.WithName("controllers").WithName("Readiness").WithValues("apigroup", "core"),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Log:      ctrl.Log.WithName("controllers").WithName("Readiness").WithValues("apigroup", "core"),
+		Recorder: mgr.GetEventRecorderFor("readiness-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Readiness")
 		os.Exit(1)
 	}
 
 	if err = (&readinessprovidercontroller.ReadinessProviderReconciler{
-		Client:                     mgr.GetClient(),
-		Clientset:                  k8sClientset,
-		Log:                        ctrl.Log.WithName("controllers").WithName("ReadinessProvider").WithValues("apigroup", "core"),
-		Scheme:                     mgr.GetScheme(),
-		ResourceExistenceCondition: conditions.NewResourceExistenceConditionFunc(),
-		RestConfig:                 restConfig,
-		DefaultQueryClient:         clusterQueryClient,
+		Client:              mgr.GetClient(),
+		Clientset:           k8sClientset,
+		Log:                 ctrl.Log.WithName("controllers").WithName("ReadinessProvider").WithValues("apigroup", "core"),
+		Scheme:              mgr.GetScheme(),
+		ConditionEvaluators: conditions.NewDefaultRegistry(),
+		RestConfig:          restConfig,
+		DefaultQueryClient:  clusterQueryClient,
+		Recorder:            mgr.GetEventRecorderFor("readinessprovider-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ReadinessProvider")
 		os.Exit(1)
 	}
 
 	if err = (&corev1alpha2.ReadinessProvider{}).SetupWebhookWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create webhook", "webhook", "ReadinessProvider", "apigroup", "config")
+		setupLog.Error(err, "unable to create webhook", "webhook", "ReadinessProvider", "apigroup", "core")
 		os.Exit(1)
 	}
 
+	if err = (&corev1alpha2.Readiness{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "Readiness", "apigroup", "core")
+		os.Exit(1)
+	}
+
+	if err = (&clusterreadiness.ClusterReadinessReconciler{
+		Client:    mgr.GetClient(),
+		APIReader: mgr.GetAPIReader(),
+		Log:       ctrl.Log.WithName("controllers").WithName("ClusterReadiness").WithValues("apigroup", "core"),
+		Scheme:    mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterReadiness")
+		os.Exit(1)
+	}
+
+	if enableReadinessGating {
+		if err = (&readinessgate.ReadinessGateReconciler{
+			Client:   mgr.GetClient(),
+			Log:      ctrl.Log.WithName("controllers").WithName("ReadinessGate").WithValues("apigroup", "core"),
+			Recorder: mgr.GetEventRecorderFor("readinessgate-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ReadinessGate")
+			os.Exit(1)
+		}
+
+		mgr.GetWebhookServer().Register(readinessgate.WebhookPath, &webhook.Admission{Handler: &readinessgate.Webhook{
+			Client: mgr.GetClient(),
+			Log:    ctrl.Log.WithName("readinessgate-webhook"),
+		}})
+	}
+
 	//+kubebuilder:scaffold:builder
 
 	signalHandler := ctrl.SetupSignalHandler()
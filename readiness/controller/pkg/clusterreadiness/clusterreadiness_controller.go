@@ -0,0 +1,240 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterreadiness
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+const (
+	defaultResyncInterval = 60 * time.Second
+	contextTimeout        = 60 * time.Second
+
+	// kubeconfigSecretSuffix and kubeconfigSecretKey locate the kubeconfig Secret that Cluster API creates for each Cluster
+	kubeconfigSecretSuffix = "-kubeconfig"
+	kubeconfigSecretKey    = "value"
+)
+
+// clusterListGVK is the kind of the list of Cluster API Clusters. Clusters are read as unstructured objects,
+// so that the controller does not depend on the Cluster API types and runs on clusters without Cluster API.
+var clusterListGVK = schema.GroupVersionKind{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "ClusterList"}
+
+// RemoteClientFunc returns a client for the workload cluster with the given kubeconfig
+type RemoteClientFunc func(kubeconfig []byte, scheme *runtime.Scheme) (client.Client, error)
+
+// ClusterReadinessReconciler reconciles a ClusterReadiness object
+type ClusterReadinessReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+
+	// APIReader reads the kubeconfig Secrets of the workload clusters from the API server. Reading them with the
+	// cached client would cache all the Secrets of the cluster and require watching them. Defaults to Client.
+	APIReader client.Reader
+
+	// RemoteClient returns the client for a workload cluster. Defaults to NewRemoteClient.
+	RemoteClient RemoteClientFunc
+}
+
+//+kubebuilder:rbac:groups=core.tanzu.vmware.com,resources=clusterreadinesses,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core.tanzu.vmware.com,resources=clusterreadinesses/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters,verbs=get;list
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get
+
+// Reconcile collects the Readiness of the selected workload clusters and aggregates it in the ClusterReadiness status
+func (r *ClusterReadinessReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctxCancel, cancel := context.WithTimeout(ctx, contextTimeout)
+	defer cancel()
+
+	log := r.Log.WithValues("clusterreadiness", req.NamespacedName)
+
+	clusterReadiness := &corev1alpha2.ClusterReadiness{}
+	if err := r.Client.Get(ctxCancel, req.NamespacedName, clusterReadiness); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	resyncInterval := defaultResyncInterval
+	if clusterReadiness.Spec.ResyncInterval != nil && clusterReadiness.Spec.ResyncInterval.Duration > 0 {
+		resyncInterval = clusterReadiness.Spec.ResyncInterval.Duration
+	}
+
+	clusterNames, err := r.selectClusters(ctxCancel, clusterReadiness)
+	if meta.IsNoMatchError(err) {
+		log.Info("Cluster API is not installed; no clusters are selected")
+		clusterNames, err = nil, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	collected := make([]collectedReadiness, 0, len(clusterNames))
+	for _, name := range clusterNames {
+		collected = append(collected, r.collect(ctxCancel, clusterReadiness, name))
+	}
+
+	clusterReadiness.Status = aggregate(clusterReadiness.Spec.Checks, collected)
+	return ctrl.Result{RequeueAfter: resyncInterval}, r.Client.Status().Update(ctxCancel, clusterReadiness)
+}
+
+// selectClusters returns the sorted names of the Clusters selected by the ClusterReadiness
+func (r *ClusterReadinessReconciler) selectClusters(ctx context.Context, clusterReadiness *corev1alpha2.ClusterReadiness) ([]string, error) {
+	selector := labels.Everything()
+	if clusterReadiness.Spec.ClusterSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(clusterReadiness.Spec.ClusterSelector); err != nil {
+			return nil, err
+		}
+	}
+
+	clusters := &unstructured.UnstructuredList{}
+	clusters.SetGroupVersionKind(clusterListGVK)
+	if err := r.Client.List(ctx, clusters, client.InNamespace(clusterReadiness.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(clusters.Items))
+	for i := range clusters.Items {
+		names = append(names, clusters.Items[i].GetName())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// collectedReadiness is the Readiness collected from a workload cluster
+type collectedReadiness struct {
+	status corev1alpha2.WorkloadClusterReadiness
+
+	// checks is nil when the Readiness could not be collected
+	checks []corev1alpha2.CheckStatus
+}
+
+// collect reads the Readiness from the workload cluster through its Cluster API kubeconfig Secret
+func (r *ClusterReadinessReconciler) collect(ctx context.Context, clusterReadiness *corev1alpha2.ClusterReadiness, clusterName string) collectedReadiness {
+	now := metav1.Now()
+	result := collectedReadiness{status: corev1alpha2.WorkloadClusterReadiness{Name: clusterName, LastCollectedTime: &now}}
+
+	apiReader := r.APIReader
+	if apiReader == nil {
+		apiReader = r.Client
+	}
+	secret := &corev1.Secret{}
+	if err := apiReader.Get(ctx, client.ObjectKey{Namespace: clusterReadiness.Namespace, Name: clusterName + kubeconfigSecretSuffix}, secret); err != nil {
+		result.status.Message = fmt.Sprintf("unable to get kubeconfig: %s", err.Error())
+		return result
+	}
+
+	remoteClient := r.RemoteClient
+	if remoteClient == nil {
+		remoteClient = NewRemoteClient
+	}
+	c, err := remoteClient(secret.Data[kubeconfigSecretKey], r.Scheme)
+	if err != nil {
+		result.status.Message = fmt.Sprintf("unable to create client: %s", err.Error())
+		return result
+	}
+
+	readiness := &corev1alpha2.Readiness{}
+	if err := c.Get(ctx, client.ObjectKey{Name: clusterReadiness.Spec.ReadinessName}, readiness); err != nil {
+		result.status.Message = fmt.Sprintf("unable to get readiness %s: %s", clusterReadiness.Spec.ReadinessName, err.Error())
+		return result
+	}
+
+	result.status.Ready = readiness.Status.Ready
+	result.checks = readiness.Status.CheckStatus
+	if result.checks == nil {
+		result.checks = []corev1alpha2.CheckStatus{}
+	}
+	return result
+}
+
+// aggregate computes the ClusterReadiness status from the Readiness collected from each cluster.
+// A check is reported as not ready in a cluster whose Readiness could not be collected or does not have the check.
+func aggregate(checks []string, collected []collectedReadiness) corev1alpha2.ClusterReadinessStatus {
+	if len(checks) == 0 {
+		names := map[string]struct{}{}
+		for i := range collected {
+			for _, check := range collected[i].checks {
+				names[check.Name] = struct{}{}
+			}
+		}
+		for name := range names {
+			checks = append(checks, name)
+		}
+		sort.Strings(checks)
+	}
+
+	status := corev1alpha2.ClusterReadinessStatus{
+		TotalClusters: int32(len(collected)),
+		Clusters:      make([]corev1alpha2.WorkloadClusterReadiness, 0, len(collected)),
+		Checks:        make([]corev1alpha2.ClusterCheckStatus, len(checks)),
+	}
+	for i, name := range checks {
+		status.Checks[i].Name = name
+	}
+
+	for i := range collected {
+		cluster := collected[i].status
+		if cluster.Ready {
+			status.ReadyClusters++
+		}
+
+		ready := make(map[string]bool, len(collected[i].checks))
+		for _, check := range collected[i].checks {
+			ready[check.Name] = check.Ready
+		}
+		for j, name := range checks {
+			if ready[name] {
+				status.Checks[j].ReadyClusters++
+				continue
+			}
+			status.Checks[j].NotReadyClusters = append(status.Checks[j].NotReadyClusters, cluster.Name)
+			if collected[i].checks != nil {
+				cluster.NotReadyChecks = append(cluster.NotReadyChecks, name)
+			}
+		}
+		status.Clusters = append(status.Clusters, cluster)
+	}
+
+	return status
+}
+
+// NewRemoteClient returns a client for the workload cluster with the given kubeconfig.
+// The client only reads Readiness resources, so its RESTMapper is static and no discovery is done.
+func NewRemoteClient(kubeconfig []byte, scheme *runtime.Scheme) (client.Client, error) {
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1alpha2.GroupVersion.WithKind("Readiness"), meta.RESTScopeRoot)
+	return client.New(cfg, client.Options{Scheme: scheme, Mapper: mapper})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// The workload clusters are not watched; their Readiness is collected at the resync interval.
+func (r *ClusterReadinessReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1alpha2.ClusterReadiness{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
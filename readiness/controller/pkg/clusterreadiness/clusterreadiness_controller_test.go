@@ -0,0 +1,196 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterreadiness
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+func testScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := corev1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return scheme
+}
+
+func cluster(name string, labels map[string]string) *unstructured.Unstructured {
+	c := &unstructured.Unstructured{}
+	c.SetAPIVersion("cluster.x-k8s.io/v1beta1")
+	c.SetKind("Cluster")
+	c.SetNamespace("default")
+	c.SetName(name)
+	c.SetLabels(labels)
+	return c
+}
+
+func kubeconfigSecret(clusterName string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: clusterName + kubeconfigSecretSuffix},
+		Data:       map[string][]byte{kubeconfigSecretKey: []byte(clusterName)},
+	}
+}
+
+func workloadReadiness(ready bool, checks ...corev1alpha2.CheckStatus) *corev1alpha2.Readiness {
+	return &corev1alpha2.Readiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+		Status:     corev1alpha2.ReadinessStatus{Ready: ready, CheckStatus: checks},
+	}
+}
+
+// cachedClient stands for the cached client of the manager, which must not be used to read Secrets
+type cachedClient struct {
+	client.Client
+	t *testing.T
+}
+
+func (c *cachedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if _, ok := obj.(*corev1.Secret); ok {
+		c.t.Errorf("secret %s read with the cached client", key)
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func TestReconcileAggregatesWorkloadClusters(t *testing.T) {
+	scheme := testScheme(t)
+
+	// The kubeconfig of each workload cluster is its name, which selects its fake client
+	workloadClients := map[string]client.Client{
+		"wc1": fake.NewClientBuilder().WithScheme(scheme).WithObjects(workloadReadiness(true,
+			corev1alpha2.CheckStatus{Name: "networking", Ready: true},
+			corev1alpha2.CheckStatus{Name: "storage", Ready: true},
+		)).Build(),
+		"wc2": fake.NewClientBuilder().WithScheme(scheme).WithObjects(workloadReadiness(false,
+			corev1alpha2.CheckStatus{Name: "networking", Ready: true},
+			corev1alpha2.CheckStatus{Name: "storage", Ready: false},
+		)).Build(),
+		"wc3": fake.NewClientBuilder().WithScheme(scheme).Build(),
+	}
+	remoteClient := func(kubeconfig []byte, _ *runtime.Scheme) (client.Client, error) {
+		c, ok := workloadClients[string(kubeconfig)]
+		if !ok {
+			return nil, fmt.Errorf("unknown cluster %s", kubeconfig)
+		}
+		return c, nil
+	}
+
+	testCases := []struct {
+		description       string
+		selector          *metav1.LabelSelector
+		checks            []string
+		wantReadyClusters int32
+		wantClusters      []corev1alpha2.WorkloadClusterReadiness
+		wantChecks        []corev1alpha2.ClusterCheckStatus
+	}{
+		{
+			description:       "All the clusters and checks",
+			wantReadyClusters: 1,
+			wantClusters: []corev1alpha2.WorkloadClusterReadiness{
+				{Name: "wc1", Ready: true},
+				{Name: "wc2", NotReadyChecks: []string{"storage"}},
+				{Name: "wc3", Message: "unable to get readiness baseline: readinesses.core.tanzu.vmware.com \"baseline\" not found"},
+				{Name: "wc4", Message: "unable to get kubeconfig: secrets \"wc4-kubeconfig\" not found"},
+			},
+			wantChecks: []corev1alpha2.ClusterCheckStatus{
+				{Name: "networking", ReadyClusters: 2, NotReadyClusters: []string{"wc3", "wc4"}},
+				{Name: "storage", ReadyClusters: 1, NotReadyClusters: []string{"wc2", "wc3", "wc4"}},
+			},
+		},
+		{
+			description:       "Selected clusters and checks",
+			selector:          &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			checks:            []string{"networking"},
+			wantReadyClusters: 1,
+			wantClusters: []corev1alpha2.WorkloadClusterReadiness{
+				{Name: "wc1", Ready: true},
+				{Name: "wc2"},
+			},
+			wantChecks: []corev1alpha2.ClusterCheckStatus{
+				{Name: "networking", ReadyClusters: 2},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			clusterReadiness := &corev1alpha2.ClusterReadiness{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "fleet"},
+				Spec: corev1alpha2.ClusterReadinessSpec{
+					ReadinessName:   "baseline",
+					ClusterSelector: tc.selector,
+					Checks:          tc.checks,
+					ResyncInterval:  &metav1.Duration{Duration: time.Minute},
+				},
+			}
+			prod := map[string]string{"env": "prod"}
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(
+					clusterReadiness,
+					cluster("wc1", prod), cluster("wc2", prod), cluster("wc3", nil), cluster("wc4", nil),
+				).
+				Build()
+			// The kubeconfig Secrets are only served by the uncached reader
+			apiReader := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(kubeconfigSecret("wc1"), kubeconfigSecret("wc2"), kubeconfigSecret("wc3")).
+				Build()
+
+			r := &ClusterReadinessReconciler{
+				Client:       &cachedClient{Client: k8sClient, t: t},
+				APIReader:    apiReader,
+				Log:          ctrl.Log,
+				Scheme:       scheme,
+				RemoteClient: remoteClient,
+			}
+			result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "fleet"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.RequeueAfter != time.Minute {
+				t.Errorf("expected requeue after %s, got %s", time.Minute, result.RequeueAfter)
+			}
+
+			got := &corev1alpha2.ClusterReadiness{}
+			if err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(clusterReadiness), got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Status.ReadyClusters != tc.wantReadyClusters || got.Status.TotalClusters != int32(len(tc.wantClusters)) {
+				t.Errorf("expected %d/%d ready clusters, got %d/%d",
+					tc.wantReadyClusters, len(tc.wantClusters), got.Status.ReadyClusters, got.Status.TotalClusters)
+			}
+			for i := range got.Status.Clusters {
+				if got.Status.Clusters[i].LastCollectedTime == nil {
+					t.Errorf("expected the collection time of cluster %s", got.Status.Clusters[i].Name)
+				}
+				got.Status.Clusters[i].LastCollectedTime = nil
+			}
+			if !reflect.DeepEqual(got.Status.Clusters, tc.wantClusters) {
+				t.Errorf("expected clusters %+v, got %+v", tc.wantClusters, got.Status.Clusters)
+			}
+			if !reflect.DeepEqual(got.Status.Checks, tc.wantChecks) {
+				t.Errorf("expected checks %+v, got %+v", tc.wantChecks, got.Status.Checks)
+			}
+		})
+	}
+}
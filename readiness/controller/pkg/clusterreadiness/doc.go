@@ -0,0 +1,5 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package clusterreadiness has the controller for ClusterReadiness in core API group.
+package clusterreadiness
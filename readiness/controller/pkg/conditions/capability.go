@@ -0,0 +1,118 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package conditions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	capabilitiesdiscovery "github.com/vmware-tanzu/tanzu-framework/capabilities/client/pkg/discovery"
+)
+
+// EvaluateCapabilityCondition evaluates a CapabilityCondition
+func EvaluateCapabilityCondition(ctx context.Context, clients *Clients, condition *corev1alpha2.ReadinessProviderCondition) (corev1alpha2.ReadinessConditionState, string) {
+	c := condition.CapabilityCondition
+	if c == nil {
+		return corev1alpha2.ConditionFailureState, "capabilityCondition is not defined"
+	}
+
+	u, err := clients.DynamicClient.Resource(corev1alpha2.GroupVersion.WithResource("capabilities")).Namespace(c.Namespace).Get(ctx, c.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return corev1alpha2.ConditionFailureState, "capability not found"
+		}
+		return corev1alpha2.ConditionFailureState, err.Error()
+	}
+
+	capability := &corev1alpha2.Capability{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, capability); err != nil {
+		return corev1alpha2.ConditionFailureState, err.Error()
+	}
+
+	return capabilityResultsState(capability, c.QueryName)
+}
+
+// EvaluateQueryCondition evaluates a QueryCondition by running its queries with the cluster query client
+func EvaluateQueryCondition(_ context.Context, clients *Clients, condition *corev1alpha2.ReadinessProviderCondition) (corev1alpha2.ReadinessConditionState, string) {
+	q := condition.QueryCondition
+	if q == nil {
+		return corev1alpha2.ConditionFailureState, "queryCondition is not defined"
+	}
+
+	queryTargets := capabilitiesdiscovery.QueryToQueryTargets(q)
+	if len(queryTargets) == 0 {
+		return corev1alpha2.ConditionFailureState, fmt.Sprintf("query %s does not define any queries", q.Name)
+	}
+
+	query := clients.QueryClient.Query(queryTargets...)
+	found, err := query.Execute()
+	if err != nil {
+		return corev1alpha2.ConditionFailureState, err.Error()
+	}
+	if !found {
+		var failed []string
+		for name, result := range query.Results() {
+			if !result.Found {
+				failed = append(failed, fmt.Sprintf("%s/%s: %s", q.Name, name, result.NotFoundReason))
+			}
+		}
+		sort.Strings(failed)
+		return corev1alpha2.ConditionFailureState, fmt.Sprintf("queries not satisfied: %s", strings.Join(failed, "; "))
+	}
+
+	return corev1alpha2.ConditionSuccessState, "all query results found"
+}
+
+// capabilityResultsState computes the condition state from the results of the given query, or all queries if queryName is empty
+func capabilityResultsState(capability *corev1alpha2.Capability, queryName string) (corev1alpha2.ReadinessConditionState, string) {
+	results := make(map[string]*corev1alpha2.Result, len(capability.Status.Results))
+	for i := range capability.Status.Results {
+		results[capability.Status.Results[i].Name] = &capability.Status.Results[i]
+	}
+
+	queryNames := []string{}
+	for i := range capability.Spec.Queries {
+		if queryName == "" || capability.Spec.Queries[i].Name == queryName {
+			queryNames = append(queryNames, capability.Spec.Queries[i].Name)
+		}
+	}
+	if len(queryNames) == 0 {
+		return corev1alpha2.ConditionFailureState, fmt.Sprintf("query %s not found in capability %s", queryName, capability.Name)
+	}
+
+	var pending, failed []string
+	for _, name := range queryNames {
+		result, ok := results[name]
+		if !ok {
+			pending = append(pending, name)
+			continue
+		}
+
+		for _, queryResults := range [][]corev1alpha2.QueryResult{result.GroupVersionResources, result.Objects, result.PartialSchemas} {
+			for _, queryResult := range queryResults {
+				if queryResult.Error {
+					failed = append(failed, fmt.Sprintf("%s/%s: %s", name, queryResult.Name, queryResult.ErrorDetail))
+				} else if !queryResult.Found {
+					failed = append(failed, fmt.Sprintf("%s/%s: %s", name, queryResult.Name, queryResult.NotFoundReason))
+				}
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return corev1alpha2.ConditionFailureState, fmt.Sprintf("queries not satisfied: %s", strings.Join(failed, "; "))
+	}
+	if len(pending) > 0 {
+		return corev1alpha2.ConditionInProgressState, fmt.Sprintf("waiting for results of queries: %s", strings.Join(pending, ", "))
+	}
+
+	return corev1alpha2.ConditionSuccessState, "all query results found"
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package conditions
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	k8stesting "k8s.io/client-go/testing"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	capabilitiesdiscovery "github.com/vmware-tanzu/tanzu-framework/capabilities/client/pkg/discovery"
+)
+
+// Clients holds the clients used by the evaluators to evaluate conditions
+type Clients struct {
+	// QueryClient is used to run capability discovery queries
+	QueryClient *capabilitiesdiscovery.ClusterQueryClient
+
+	// DynamicClient is used to fetch the resources referenced by conditions
+	DynamicClient dynamic.Interface
+
+	// RESTMapper is used to map the kinds referenced by conditions to resources
+	RESTMapper meta.RESTMapper
+}
+
+// NewClientsForConfig returns the Clients for evaluating conditions with the given REST config
+func NewClientsForConfig(config *rest.Config) (*Clients, error) {
+	queryClient, err := capabilitiesdiscovery.NewClusterQueryClientForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create ClusterQueryClient: %w", err)
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create dynamic client: %w", err)
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create discovery client: %w", err)
+	}
+
+	return &Clients{
+		QueryClient:   queryClient,
+		DynamicClient: dynamicClient,
+		RESTMapper:    restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+	}, nil
+}
+
+// NewClientsForObjects returns the Clients for evaluating conditions offline, against the given objects only.
+// The kinds of the objects are the only known kinds, and a kind is namespaced if its objects have a namespace.
+// Capability queries are answered from the objects; no OpenAPI schema is available to partial schema queries.
+func NewClientsForObjects(objs ...*unstructured.Unstructured) (*Clients, error) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	listKinds := map[schema.GroupVersionResource]string{}
+	resourceLists := map[schema.GroupVersion]*metav1.APIResourceList{}
+	runtimeObjs := make([]runtime.Object, 0, len(objs))
+
+	for _, obj := range objs {
+		gvk := obj.GroupVersionKind()
+		if gvk.Kind == "" || gvk.Version == "" {
+			return nil, fmt.Errorf("object %q does not have an apiVersion and a kind", obj.GetName())
+		}
+		runtimeObjs = append(runtimeObjs, obj)
+
+		plural, singular := meta.UnsafeGuessKindToResource(gvk)
+		if _, ok := listKinds[plural]; ok {
+			continue
+		}
+		listKinds[plural] = gvk.Kind + "List"
+
+		scope := meta.RESTScopeRoot
+		if obj.GetNamespace() != "" {
+			scope = meta.RESTScopeNamespace
+		}
+		mapper.AddSpecific(gvk, plural, singular, scope)
+
+		resourceList, ok := resourceLists[gvk.GroupVersion()]
+		if !ok {
+			resourceList = &metav1.APIResourceList{GroupVersion: gvk.GroupVersion().String()}
+			resourceLists[gvk.GroupVersion()] = resourceList
+		}
+		resourceList.APIResources = append(resourceList.APIResources, metav1.APIResource{
+			Name:         plural.Resource,
+			SingularName: singular.Resource,
+			Namespaced:   scope == meta.RESTScopeNamespace,
+			Kind:         gvk.Kind,
+			Verbs:        metav1.Verbs{"get", "list"},
+		})
+	}
+
+	resources := make([]*metav1.APIResourceList, 0, len(resourceLists))
+	for _, resourceList := range resourceLists {
+		resources = append(resources, resourceList)
+	}
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, runtimeObjs...)
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{Resources: resources}}
+	queryClient, err := capabilitiesdiscovery.NewClusterQueryClient(dynamicClient, discoveryClient)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create ClusterQueryClient: %w", err)
+	}
+
+	return &Clients{
+		QueryClient:   queryClient,
+		DynamicClient: dynamicClient,
+		RESTMapper:    mapper,
+	}, nil
+}
+
+// restMapping maps the kind to its resource. The clients of service accounts are cached, along with the discovery
+// cache of their RESTMapper, so a kind that is not found may have been installed since the cache was populated: the
+// RESTMapper is then reset from discovery, and the kind mapped again once.
+func restMapping(mapper meta.RESTMapper, gk schema.GroupKind, version string) (*meta.RESTMapping, error) {
+	mapping, err := mapper.RESTMapping(gk, version)
+	if meta.IsNoMatchError(err) {
+		if resettable, ok := mapper.(meta.ResettableRESTMapper); ok {
+			resettable.Reset()
+			return mapper.RESTMapping(gk, version)
+		}
+	}
+	return mapping, err
+}
+
+// getResource fetches the resource identified by the given reference
+func getResource(ctx context.Context, clients *Clients, ref *corev1alpha2.ResourceReference) (*unstructured.Unstructured, error) {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping, err := restMapping(clients.RESTMapper, gv.WithKind(ref.Kind).GroupKind(), gv.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if ref.Namespace == nil {
+			return nil, fmt.Errorf("namespace is required for namespaced kind %s", ref.Kind)
+		}
+		return clients.DynamicClient.Resource(mapping.Resource).Namespace(*ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	}
+
+	return clients.DynamicClient.Resource(mapping.Resource).Get(ctx, ref.Name, metav1.GetOptions{})
+}
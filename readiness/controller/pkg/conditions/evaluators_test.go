@@ -0,0 +1,542 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package conditions
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	capabilitiesdiscovery "github.com/vmware-tanzu/tanzu-framework/capabilities/client/pkg/discovery"
+)
+
+func newFakeClients(t *testing.T, objects ...runtime.Object) *Clients {
+	t.Helper()
+
+	s := runtime.NewScheme()
+	if err := corev1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := appsv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(appsv1.SchemeGroupVersion.WithKind("Deployment"), meta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+
+	return &Clients{
+		DynamicClient: dynamicfake.NewSimpleDynamicClient(s, objects...),
+		RESTMapper:    mapper,
+	}
+}
+
+func newDeployment(name string, generation int64, status appsv1.DeploymentStatus) *appsv1.Deployment {
+	replicas := int32(2)
+	return &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Generation: generation},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     status,
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestRegistryEvaluate(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(corev1alpha2.ResourceExistenceConditionType, EvaluatorFunc(
+		func(context.Context, *Clients, *corev1alpha2.ReadinessProviderCondition) (corev1alpha2.ReadinessConditionState, string) {
+			return corev1alpha2.ConditionSuccessState, "registered"
+		}))
+
+	testCases := []struct {
+		description string
+		condition   corev1alpha2.ReadinessProviderCondition
+		want        corev1alpha2.ReadinessConditionState
+	}{
+		{
+			description: "condition with a registered type",
+			condition:   corev1alpha2.ReadinessProviderCondition{ResourceExistenceCondition: &corev1alpha2.ResourceExistenceCondition{}},
+			want:        corev1alpha2.ConditionSuccessState,
+		},
+		{
+			description: "condition with an unregistered type",
+			condition:   corev1alpha2.ReadinessProviderCondition{RolloutCondition: &corev1alpha2.RolloutCondition{}},
+			want:        corev1alpha2.ConditionFailureState,
+		},
+		{
+			description: "condition with no type",
+			condition:   corev1alpha2.ReadinessProviderCondition{},
+			want:        corev1alpha2.ConditionFailureState,
+		},
+		{
+			description: "condition with more than one type",
+			condition: corev1alpha2.ReadinessProviderCondition{
+				ResourceExistenceCondition: &corev1alpha2.ResourceExistenceCondition{},
+				RolloutCondition:           &corev1alpha2.RolloutCondition{},
+			},
+			want: corev1alpha2.ConditionFailureState,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got, _ := registry.Evaluate(context.Background(), &Clients{}, &tc.condition)
+			if got != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateResourceStatusCondition(t *testing.T) {
+	deployment := newDeployment("foo", 1, appsv1.DeploymentStatus{
+		Conditions: []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+			{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse},
+		},
+	})
+	clients := newFakeClients(t, deployment)
+
+	testCases := []struct {
+		description string
+		condition   *corev1alpha2.ResourceStatusCondition
+		want        corev1alpha2.ReadinessConditionState
+	}{
+		{
+			description: "status condition has the expected status",
+			condition: &corev1alpha2.ResourceStatusCondition{
+				ResourceReference: corev1alpha2.ResourceReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: stringPtr("default"), Name: "foo"},
+				ConditionType:     "Available",
+			},
+			want: corev1alpha2.ConditionSuccessState,
+		},
+		{
+			description: "status condition does not have the expected status",
+			condition: &corev1alpha2.ResourceStatusCondition{
+				ResourceReference: corev1alpha2.ResourceReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: stringPtr("default"), Name: "foo"},
+				ConditionType:     "Progressing",
+			},
+			want: corev1alpha2.ConditionFailureState,
+		},
+		{
+			description: "status condition is compared with a custom status",
+			condition: &corev1alpha2.ResourceStatusCondition{
+				ResourceReference: corev1alpha2.ResourceReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: stringPtr("default"), Name: "foo"},
+				ConditionType:     "Progressing",
+				Status:            metav1.ConditionFalse,
+			},
+			want: corev1alpha2.ConditionSuccessState,
+		},
+		{
+			description: "default status condition type is missing",
+			condition: &corev1alpha2.ResourceStatusCondition{
+				ResourceReference: corev1alpha2.ResourceReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: stringPtr("default"), Name: "foo"},
+			},
+			want: corev1alpha2.ConditionFailureState,
+		},
+		{
+			description: "resource does not exist",
+			condition: &corev1alpha2.ResourceStatusCondition{
+				ResourceReference: corev1alpha2.ResourceReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: stringPtr("default"), Name: "bar"},
+			},
+			want: corev1alpha2.ConditionFailureState,
+		},
+		{
+			description: "namespace is missing for a namespaced resource",
+			condition: &corev1alpha2.ResourceStatusCondition{
+				ResourceReference: corev1alpha2.ResourceReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "foo"},
+				ConditionType:     "Available",
+			},
+			want: corev1alpha2.ConditionFailureState,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got, message := EvaluateResourceStatusCondition(context.Background(), clients, &corev1alpha2.ReadinessProviderCondition{Name: "test", ResourceStatusCondition: tc.condition})
+			if got != tc.want {
+				t.Errorf("got %s (%s), want %s", got, message, tc.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateFieldComparisonCondition(t *testing.T) {
+	clients := newFakeClients(t, newDeployment("foo", 1, appsv1.DeploymentStatus{ReadyReplicas: 2}))
+	ref := corev1alpha2.ResourceReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: stringPtr("default"), Name: "foo"}
+
+	testCases := []struct {
+		description string
+		condition   *corev1alpha2.FieldComparisonCondition
+		want        corev1alpha2.ReadinessConditionState
+	}{
+		{
+			description: "field equals the value",
+			condition:   &corev1alpha2.FieldComparisonCondition{ResourceReference: ref, JSONPath: "{.status.readyReplicas}", Value: "2"},
+			want:        corev1alpha2.ConditionSuccessState,
+		},
+		{
+			description: "field does not equal the value",
+			condition:   &corev1alpha2.FieldComparisonCondition{ResourceReference: ref, JSONPath: "{.status.readyReplicas}", Operator: corev1alpha2.FieldEqualsOperator, Value: "3"},
+			want:        corev1alpha2.ConditionFailureState,
+		},
+		{
+			description: "field is not equal to the value",
+			condition:   &corev1alpha2.FieldComparisonCondition{ResourceReference: ref, JSONPath: "{.status.readyReplicas}", Operator: corev1alpha2.FieldNotEqualsOperator, Value: "0"},
+			want:        corev1alpha2.ConditionSuccessState,
+		},
+		{
+			description: "field exists",
+			condition:   &corev1alpha2.FieldComparisonCondition{ResourceReference: ref, JSONPath: "{.spec.replicas}", Operator: corev1alpha2.FieldExistsOperator},
+			want:        corev1alpha2.ConditionSuccessState,
+		},
+		{
+			description: "field does not exist",
+			condition:   &corev1alpha2.FieldComparisonCondition{ResourceReference: ref, JSONPath: "{.status.unknownField}", Operator: corev1alpha2.FieldExistsOperator},
+			want:        corev1alpha2.ConditionFailureState,
+		},
+		{
+			description: "invalid jsonPath",
+			condition:   &corev1alpha2.FieldComparisonCondition{ResourceReference: ref, JSONPath: "{.status[", Value: "2"},
+			want:        corev1alpha2.ConditionFailureState,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got, message := EvaluateFieldComparisonCondition(context.Background(), clients, &corev1alpha2.ReadinessProviderCondition{Name: "test", FieldComparisonCondition: tc.condition})
+			if got != tc.want {
+				t.Errorf("got %s (%s), want %s", got, message, tc.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateRolloutCondition(t *testing.T) {
+	clients := newFakeClients(t,
+		newDeployment("complete", 2, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2}),
+		newDeployment("unobserved", 3, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2}),
+		newDeployment("updating", 2, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 2}),
+		newDeployment("stuck", 2, appsv1.DeploymentStatus{ObservedGeneration: 2, Conditions: []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded"},
+		}}),
+	)
+
+	testCases := []struct {
+		description string
+		condition   *corev1alpha2.RolloutCondition
+		want        corev1alpha2.ReadinessConditionState
+	}{
+		{
+			description: "rollout is complete",
+			condition:   &corev1alpha2.RolloutCondition{Kind: "Deployment", Namespace: "default", Name: "complete"},
+			want:        corev1alpha2.ConditionSuccessState,
+		},
+		{
+			description: "spec update is not observed",
+			condition:   &corev1alpha2.RolloutCondition{Kind: "Deployment", Namespace: "default", Name: "unobserved"},
+			want:        corev1alpha2.ConditionInProgressState,
+		},
+		{
+			description: "replicas are being updated",
+			condition:   &corev1alpha2.RolloutCondition{Kind: "Deployment", Namespace: "default", Name: "updating"},
+			want:        corev1alpha2.ConditionInProgressState,
+		},
+		{
+			description: "rollout has exceeded its progress deadline",
+			condition:   &corev1alpha2.RolloutCondition{Kind: "Deployment", Namespace: "default", Name: "stuck"},
+			want:        corev1alpha2.ConditionFailureState,
+		},
+		{
+			description: "workload does not exist",
+			condition:   &corev1alpha2.RolloutCondition{Kind: "StatefulSet", Namespace: "default", Name: "complete"},
+			want:        corev1alpha2.ConditionFailureState,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got, message := EvaluateRolloutCondition(context.Background(), clients, &corev1alpha2.ReadinessProviderCondition{Name: "test", RolloutCondition: tc.condition})
+			if got != tc.want {
+				t.Errorf("got %s (%s), want %s", got, message, tc.want)
+			}
+		})
+	}
+}
+
+func TestStatefulSetRolloutState(t *testing.T) {
+	replicas := int32(3)
+	testCases := []struct {
+		description string
+		status      appsv1.StatefulSetStatus
+		want        corev1alpha2.ReadinessConditionState
+	}{
+		{
+			description: "rollout is complete",
+			status:      appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 3, UpdatedReplicas: 3, CurrentRevision: "r1", UpdateRevision: "r1"},
+			want:        corev1alpha2.ConditionSuccessState,
+		},
+		{
+			description: "pods are not ready",
+			status:      appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 2, CurrentRevision: "r1", UpdateRevision: "r1"},
+			want:        corev1alpha2.ConditionInProgressState,
+		},
+		{
+			description: "pods are being updated to a new revision",
+			status:      appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 3, UpdatedReplicas: 1, CurrentRevision: "r1", UpdateRevision: "r2"},
+			want:        corev1alpha2.ConditionInProgressState,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			statefulSet := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 1},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas:       &replicas,
+					UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType},
+				},
+				Status: tc.status,
+			}
+			got, message := statefulSetRolloutState(statefulSet)
+			if got != tc.want {
+				t.Errorf("got %s (%s), want %s", got, message, tc.want)
+			}
+		})
+	}
+}
+
+func TestServiceProbeEvaluator(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	clients := newFakeClients(t, &corev1.Service{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+	})
+
+	var dialed string
+	evaluator := &ServiceProbeEvaluator{
+		DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+			dialed = address
+			if address != "foo.default.svc:8080" {
+				return nil, errors.New("connection refused")
+			}
+			return (&net.Dialer{}).DialContext(ctx, network, listener.Addr().String())
+		},
+	}
+
+	testCases := []struct {
+		description string
+		condition   *corev1alpha2.ServiceProbeCondition
+		want        corev1alpha2.ReadinessConditionState
+	}{
+		{
+			description: "service accepts TCP connections",
+			condition:   &corev1alpha2.ServiceProbeCondition{Namespace: "default", Name: "foo", Port: 8080, Protocol: corev1alpha2.TCPServiceProbe},
+			want:        corev1alpha2.ConditionSuccessState,
+		},
+		{
+			description: "service refuses TCP connections",
+			condition:   &corev1alpha2.ServiceProbeCondition{Namespace: "default", Name: "foo", Port: 9090},
+			want:        corev1alpha2.ConditionFailureState,
+		},
+		{
+			description: "service does not exist",
+			condition:   &corev1alpha2.ServiceProbeCondition{Namespace: "default", Name: "bar", Port: 8080},
+			want:        corev1alpha2.ConditionFailureState,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got, message := evaluator.Evaluate(context.Background(), clients, &corev1alpha2.ReadinessProviderCondition{Name: "test", ServiceProbeCondition: tc.condition})
+			if got != tc.want {
+				t.Errorf("got %s (%s), want %s; dialed %s", got, message, tc.want, dialed)
+			}
+		})
+	}
+}
+
+func TestCapabilityResultsState(t *testing.T) {
+	capability := &corev1alpha2.Capability{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: corev1alpha2.CapabilitySpec{
+			Queries: []corev1alpha2.Query{{Name: "q1"}, {Name: "q2"}, {Name: "q3"}},
+		},
+		Status: corev1alpha2.CapabilityStatus{
+			Results: []corev1alpha2.Result{
+				{Name: "q1", GroupVersionResources: []corev1alpha2.QueryResult{{Name: "gvr", Found: true}}},
+				{Name: "q2", Objects: []corev1alpha2.QueryResult{{Name: "obj", Found: false, NotFoundReason: "not found"}}},
+			},
+		},
+	}
+
+	testCases := []struct {
+		description string
+		queryName   string
+		want        corev1alpha2.ReadinessConditionState
+	}{
+		{description: "query results are found", queryName: "q1", want: corev1alpha2.ConditionSuccessState},
+		{description: "query results are not found", queryName: "q2", want: corev1alpha2.ConditionFailureState},
+		{description: "query results are not available yet", queryName: "q3", want: corev1alpha2.ConditionInProgressState},
+		{description: "query does not exist", queryName: "q4", want: corev1alpha2.ConditionFailureState},
+		{description: "results of all queries", want: corev1alpha2.ConditionFailureState},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got, message := capabilityResultsState(capability, tc.queryName)
+			if got != tc.want {
+				t.Errorf("got %s (%s), want %s", got, message, tc.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateCapabilityCondition(t *testing.T) {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(corev1alpha2.GroupVersion.String())
+	u.SetKind("Capability")
+	u.SetName("foo")
+	u.SetNamespace("default")
+	_ = unstructured.SetNestedSlice(u.Object, []interface{}{map[string]interface{}{"name": "q1"}}, "spec", "queries")
+	_ = unstructured.SetNestedSlice(u.Object, []interface{}{map[string]interface{}{
+		"name":    "q1",
+		"objects": []interface{}{map[string]interface{}{"name": "obj", "found": true}},
+	}}, "status", "results")
+
+	clients := newFakeClients(t, u)
+
+	got, message := EvaluateCapabilityCondition(context.Background(), clients, &corev1alpha2.ReadinessProviderCondition{
+		Name:                "test",
+		CapabilityCondition: &corev1alpha2.CapabilityCondition{Namespace: "default", Name: "foo"},
+	})
+	if got != corev1alpha2.ConditionSuccessState {
+		t.Errorf("got %s (%s), want %s", got, message, corev1alpha2.ConditionSuccessState)
+	}
+
+	got, message = EvaluateCapabilityCondition(context.Background(), clients, &corev1alpha2.ReadinessProviderCondition{
+		Name:                "test",
+		CapabilityCondition: &corev1alpha2.CapabilityCondition{Namespace: "default", Name: "bar"},
+	})
+	if got != corev1alpha2.ConditionFailureState {
+		t.Errorf("got %s (%s), want %s", got, message, corev1alpha2.ConditionFailureState)
+	}
+}
+
+func TestEvaluateQueryCondition(t *testing.T) {
+	resources := []*metav1.APIResourceList{
+		{
+			GroupVersion: appsv1.SchemeGroupVersion.String(),
+			APIResources: []metav1.APIResource{{Name: "deployments", Kind: "Deployment", Namespaced: true}},
+		},
+	}
+	queryClient, err := capabilitiesdiscovery.NewFakeClusterQueryClient(resources, runtime.NewScheme(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clients := &Clients{QueryClient: queryClient}
+
+	testCases := []struct {
+		description string
+		query       *corev1alpha2.Query
+		want        corev1alpha2.ReadinessConditionState
+	}{
+		{
+			description: "served resource",
+			query: &corev1alpha2.Query{
+				Name:                  "apps",
+				GroupVersionResources: []corev1alpha2.QueryGVR{{Name: "deployments", Group: "apps", Versions: []string{"v1"}, Resource: "deployments"}},
+			},
+			want: corev1alpha2.ConditionSuccessState,
+		},
+		{
+			description: "resource that is not served",
+			query: &corev1alpha2.Query{
+				Name:                  "apps",
+				GroupVersionResources: []corev1alpha2.QueryGVR{{Name: "replicasets", Group: "apps", Versions: []string{"v1"}, Resource: "replicasets"}},
+			},
+			want: corev1alpha2.ConditionFailureState,
+		},
+		{
+			description: "query without queries",
+			query:       &corev1alpha2.Query{Name: "empty"},
+			want:        corev1alpha2.ConditionFailureState,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got, message := EvaluateQueryCondition(context.Background(), clients, &corev1alpha2.ReadinessProviderCondition{
+				Name:           "test",
+				QueryCondition: tc.query,
+			})
+			if got != tc.want {
+				t.Errorf("got %s (%s), want %s", got, message, tc.want)
+			}
+		})
+	}
+}
+
+// resettableRESTMapper only maps the kinds added to it once it is reset, as a discovery RESTMapper does for the kinds
+// installed after its cache was populated
+type resettableRESTMapper struct {
+	*meta.DefaultRESTMapper
+	installed []schema.GroupVersionKind
+	resets    int
+}
+
+func (m *resettableRESTMapper) Reset() {
+	m.resets++
+	for _, gvk := range m.installed {
+		m.Add(gvk, meta.RESTScopeNamespace)
+	}
+}
+
+func TestGetResourceOfKindInstalledAfterDiscovery(t *testing.T) {
+	clients := newFakeClients(t, newDeployment("foo", 1, appsv1.DeploymentStatus{}))
+	mapper := &resettableRESTMapper{
+		DefaultRESTMapper: meta.NewDefaultRESTMapper(nil),
+		installed:         []schema.GroupVersionKind{appsv1.SchemeGroupVersion.WithKind("Deployment")},
+	}
+	clients.RESTMapper = mapper
+
+	ref := &corev1alpha2.ResourceReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: stringPtr("default"), Name: "foo"}
+	if _, err := getResource(context.Background(), clients, ref); err != nil {
+		t.Fatalf("expected the kind to be mapped once the RESTMapper is reset: %v", err)
+	}
+	if mapper.resets != 1 {
+		t.Errorf("got %d resets of the RESTMapper, want 1", mapper.resets)
+	}
+
+	ref = &corev1alpha2.ResourceReference{APIVersion: "example.com/v1", Kind: "Missing", Namespace: stringPtr("default"), Name: "foo"}
+	if _, err := getResource(context.Background(), clients, ref); !meta.IsNoMatchError(err) {
+		t.Errorf("got error %v, want a no match error", err)
+	}
+	if mapper.resets != 2 {
+		t.Errorf("got %d resets of the RESTMapper, want 2", mapper.resets)
+	}
+}
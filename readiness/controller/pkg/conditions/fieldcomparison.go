@@ -0,0 +1,74 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package conditions
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/jsonpath"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// EvaluateFieldComparisonCondition evaluates a FieldComparisonCondition
+func EvaluateFieldComparisonCondition(ctx context.Context, clients *Clients, condition *corev1alpha2.ReadinessProviderCondition) (corev1alpha2.ReadinessConditionState, string) {
+	c := condition.FieldComparisonCondition
+	if c == nil {
+		return corev1alpha2.ConditionFailureState, "fieldComparisonCondition is not defined"
+	}
+
+	parser := jsonpath.New(condition.Name)
+	if err := parser.Parse(c.JSONPath); err != nil {
+		return corev1alpha2.ConditionFailureState, fmt.Sprintf("invalid jsonPath %q: %s", c.JSONPath, err.Error())
+	}
+
+	u, err := getResource(ctx, clients, &c.ResourceReference)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return corev1alpha2.ConditionFailureState, "resource not found"
+		}
+		return corev1alpha2.ConditionFailureState, err.Error()
+	}
+
+	results, err := parser.FindResults(u.Object)
+	found := err == nil && len(results) > 0 && len(results[0]) > 0
+
+	var value string
+	if found {
+		buf := &bytes.Buffer{}
+		if err := parser.PrintResults(buf, results[0]); err != nil {
+			return corev1alpha2.ConditionFailureState, err.Error()
+		}
+		value = buf.String()
+	}
+
+	switch c.Operator {
+	case corev1alpha2.FieldExistsOperator:
+		if found {
+			return corev1alpha2.ConditionSuccessState, fmt.Sprintf("field %s exists", c.JSONPath)
+		}
+		return corev1alpha2.ConditionFailureState, fmt.Sprintf("field %s does not exist", c.JSONPath)
+	case corev1alpha2.FieldNotEqualsOperator:
+		if !found {
+			return corev1alpha2.ConditionFailureState, fmt.Sprintf("field %s does not exist", c.JSONPath)
+		}
+		if value != c.Value {
+			return corev1alpha2.ConditionSuccessState, fmt.Sprintf("field %s is %q", c.JSONPath, value)
+		}
+		return corev1alpha2.ConditionFailureState, fmt.Sprintf("field %s is %q", c.JSONPath, value)
+	case corev1alpha2.FieldEqualsOperator, "":
+		if !found {
+			return corev1alpha2.ConditionFailureState, fmt.Sprintf("field %s does not exist", c.JSONPath)
+		}
+		if value == c.Value {
+			return corev1alpha2.ConditionSuccessState, fmt.Sprintf("field %s is %q", c.JSONPath, value)
+		}
+		return corev1alpha2.ConditionFailureState, fmt.Sprintf("field %s is %q, expected %q", c.JSONPath, value, c.Value)
+	default:
+		return corev1alpha2.ConditionFailureState, fmt.Sprintf("unsupported operator %s", c.Operator)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package conditions
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// ReferencedObjects returns the objects whose changes may change the state of the condition.
+// Conditions whose state does not depend on objects in the cluster return no references.
+func ReferencedObjects(condition *corev1alpha2.ReadinessProviderCondition) []corev1.ObjectReference {
+	var refs []corev1.ObjectReference
+
+	if c := condition.ResourceExistenceCondition; c != nil {
+		refs = append(refs, objectReference(c.APIVersion, c.Kind, c.Namespace, c.Name))
+	}
+
+	if c := condition.ResourceStatusCondition; c != nil {
+		refs = append(refs, objectReference(c.APIVersion, c.Kind, c.Namespace, c.Name))
+	}
+
+	if c := condition.FieldComparisonCondition; c != nil {
+		refs = append(refs, objectReference(c.APIVersion, c.Kind, c.Namespace, c.Name))
+	}
+
+	if c := condition.RolloutCondition; c != nil {
+		refs = append(refs, corev1.ObjectReference{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: c.Kind, Namespace: c.Namespace, Name: c.Name})
+	}
+
+	if c := condition.ServiceProbeCondition; c != nil {
+		// Endpoints share the name of their Service and change whenever the backing pods change
+		refs = append(refs,
+			corev1.ObjectReference{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "Service", Namespace: c.Namespace, Name: c.Name},
+			corev1.ObjectReference{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "Endpoints", Namespace: c.Namespace, Name: c.Name})
+	}
+
+	if c := condition.CapabilityCondition; c != nil {
+		refs = append(refs, corev1.ObjectReference{APIVersion: corev1alpha2.GroupVersion.String(), Kind: "Capability", Namespace: c.Namespace, Name: c.Name})
+	}
+
+	// Only object queries reference objects; the other queries depend on the APIs served by the cluster
+	if c := condition.QueryCondition; c != nil {
+		for i := range c.Objects {
+			refs = append(refs, c.Objects[i].ObjectReference)
+		}
+	}
+
+	return refs
+}
+
+func objectReference(apiVersion, kind string, namespace *string, name string) corev1.ObjectReference {
+	ref := corev1.ObjectReference{APIVersion: apiVersion, Kind: kind, Name: name}
+	if namespace != nil {
+		ref.Namespace = *namespace
+	}
+	return ref
+}
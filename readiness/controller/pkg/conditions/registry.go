@@ -0,0 +1,85 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package conditions
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// Evaluator evaluates one type of readiness provider condition
+type Evaluator interface {
+	// Evaluate returns the state of the condition along with a message describing the state
+	Evaluate(ctx context.Context, clients *Clients, condition *corev1alpha2.ReadinessProviderCondition) (corev1alpha2.ReadinessConditionState, string)
+}
+
+// EvaluatorFunc is an adapter that allows the use of ordinary functions as an Evaluator
+type EvaluatorFunc func(context.Context, *Clients, *corev1alpha2.ReadinessProviderCondition) (corev1alpha2.ReadinessConditionState, string)
+
+// Evaluate calls f(ctx, clients, condition)
+func (f EvaluatorFunc) Evaluate(ctx context.Context, clients *Clients, condition *corev1alpha2.ReadinessProviderCondition) (corev1alpha2.ReadinessConditionState, string) {
+	return f(ctx, clients, condition)
+}
+
+// Registry holds the evaluators for each type of readiness provider condition
+type Registry struct {
+	lock       sync.RWMutex
+	evaluators map[corev1alpha2.ReadinessProviderConditionType]Evaluator
+}
+
+// NewRegistry returns an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		evaluators: make(map[corev1alpha2.ReadinessProviderConditionType]Evaluator),
+	}
+}
+
+// NewDefaultRegistry returns a Registry with evaluators for all the condition types supported by the readiness framework
+func NewDefaultRegistry() *Registry {
+	registry := NewRegistry()
+
+	resourceExistenceCondition := NewResourceExistenceConditionFunc()
+	registry.Register(corev1alpha2.ResourceExistenceConditionType, EvaluatorFunc(
+		func(ctx context.Context, clients *Clients, condition *corev1alpha2.ReadinessProviderCondition) (corev1alpha2.ReadinessConditionState, string) {
+			return resourceExistenceCondition(ctx, clients.QueryClient, condition.ResourceExistenceCondition, condition.Name)
+		}))
+	registry.Register(corev1alpha2.ResourceStatusConditionType, EvaluatorFunc(EvaluateResourceStatusCondition))
+	registry.Register(corev1alpha2.FieldComparisonConditionType, EvaluatorFunc(EvaluateFieldComparisonCondition))
+	registry.Register(corev1alpha2.RolloutConditionType, EvaluatorFunc(EvaluateRolloutCondition))
+	registry.Register(corev1alpha2.ServiceProbeConditionType, NewServiceProbeEvaluator())
+	registry.Register(corev1alpha2.CapabilityConditionType, EvaluatorFunc(EvaluateCapabilityCondition))
+	registry.Register(corev1alpha2.QueryConditionType, EvaluatorFunc(EvaluateQueryCondition))
+
+	return registry
+}
+
+// Register sets the evaluator for the given condition type, replacing any evaluator registered earlier
+func (r *Registry) Register(conditionType corev1alpha2.ReadinessProviderConditionType, evaluator Evaluator) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.evaluators[conditionType] = evaluator
+}
+
+// Evaluate evaluates the condition with the evaluator registered for its type.
+// The condition fails if it does not define exactly one type or if no evaluator is registered for its type.
+func (r *Registry) Evaluate(ctx context.Context, clients *Clients, condition *corev1alpha2.ReadinessProviderCondition) (corev1alpha2.ReadinessConditionState, string) {
+	types := condition.DefinedTypes()
+	if len(types) != 1 {
+		return corev1alpha2.ConditionFailureState, fmt.Sprintf("expected exactly one condition type to be defined, found %d", len(types))
+	}
+
+	r.lock.RLock()
+	evaluator, ok := r.evaluators[types[0]]
+	r.lock.RUnlock()
+
+	if !ok {
+		return corev1alpha2.ConditionFailureState, fmt.Sprintf("no evaluator registered for condition type %s", types[0])
+	}
+
+	return evaluator.Evaluate(ctx, clients, condition)
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package conditions
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+const defaultStatusConditionType = "Ready"
+
+// EvaluateResourceStatusCondition evaluates a ResourceStatusCondition
+func EvaluateResourceStatusCondition(ctx context.Context, clients *Clients, condition *corev1alpha2.ReadinessProviderCondition) (corev1alpha2.ReadinessConditionState, string) {
+	c := condition.ResourceStatusCondition
+	if c == nil {
+		return corev1alpha2.ConditionFailureState, "resourceStatusCondition is not defined"
+	}
+
+	conditionType := c.ConditionType
+	if conditionType == "" {
+		conditionType = defaultStatusConditionType
+	}
+
+	expectedStatus := c.Status
+	if expectedStatus == "" {
+		expectedStatus = metav1.ConditionTrue
+	}
+
+	u, err := getResource(ctx, clients, &c.ResourceReference)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return corev1alpha2.ConditionFailureState, "resource not found"
+		}
+		return corev1alpha2.ConditionFailureState, err.Error()
+	}
+
+	statusConditions, found, err := unstructured.NestedSlice(u.Object, "status", "conditions")
+	if err != nil {
+		return corev1alpha2.ConditionFailureState, err.Error()
+	}
+	if !found {
+		return corev1alpha2.ConditionFailureState, "resource does not have status conditions"
+	}
+
+	for _, sc := range statusConditions {
+		statusCondition, ok := sc.(map[string]interface{})
+		if !ok || statusCondition["type"] != conditionType {
+			continue
+		}
+
+		if statusCondition["status"] == string(expectedStatus) {
+			return corev1alpha2.ConditionSuccessState, fmt.Sprintf("status condition %s is %s", conditionType, expectedStatus)
+		}
+		return corev1alpha2.ConditionFailureState, fmt.Sprintf("status condition %s is %v, expected %s", conditionType, statusCondition["status"], expectedStatus)
+	}
+
+	return corev1alpha2.ConditionFailureState, fmt.Sprintf("status condition %s not found", conditionType)
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package conditions
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+const (
+	deploymentKind  = "Deployment"
+	statefulSetKind = "StatefulSet"
+)
+
+// EvaluateRolloutCondition evaluates a RolloutCondition
+func EvaluateRolloutCondition(ctx context.Context, clients *Clients, condition *corev1alpha2.ReadinessProviderCondition) (corev1alpha2.ReadinessConditionState, string) {
+	c := condition.RolloutCondition
+	if c == nil {
+		return corev1alpha2.ConditionFailureState, "rolloutCondition is not defined"
+	}
+
+	var resource string
+	switch c.Kind {
+	case deploymentKind:
+		resource = "deployments"
+	case statefulSetKind:
+		resource = "statefulsets"
+	default:
+		return corev1alpha2.ConditionFailureState, fmt.Sprintf("unsupported kind %s", c.Kind)
+	}
+
+	u, err := clients.DynamicClient.Resource(appsv1.SchemeGroupVersion.WithResource(resource)).Namespace(c.Namespace).Get(ctx, c.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return corev1alpha2.ConditionFailureState, "resource not found"
+		}
+		return corev1alpha2.ConditionFailureState, err.Error()
+	}
+
+	if c.Kind == deploymentKind {
+		deployment := &appsv1.Deployment{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, deployment); err != nil {
+			return corev1alpha2.ConditionFailureState, err.Error()
+		}
+		return deploymentRolloutState(deployment)
+	}
+
+	statefulSet := &appsv1.StatefulSet{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, statefulSet); err != nil {
+		return corev1alpha2.ConditionFailureState, err.Error()
+	}
+	return statefulSetRolloutState(statefulSet)
+}
+
+// deploymentRolloutState follows the same rules as `kubectl rollout status` for deployments
+func deploymentRolloutState(deployment *appsv1.Deployment) (corev1alpha2.ReadinessConditionState, string) {
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		return corev1alpha2.ConditionInProgressState, "waiting for the deployment spec update to be observed"
+	}
+
+	for _, cond := range deployment.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Reason == "ProgressDeadlineExceeded" {
+			return corev1alpha2.ConditionFailureState, fmt.Sprintf("deployment %s has exceeded its progress deadline", deployment.Name)
+		}
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	if deployment.Status.UpdatedReplicas < replicas {
+		return corev1alpha2.ConditionInProgressState, fmt.Sprintf("%d out of %d new replicas have been updated", deployment.Status.UpdatedReplicas, replicas)
+	}
+	if deployment.Status.Replicas > deployment.Status.UpdatedReplicas {
+		return corev1alpha2.ConditionInProgressState, fmt.Sprintf("%d old replicas are pending termination", deployment.Status.Replicas-deployment.Status.UpdatedReplicas)
+	}
+	if deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas {
+		return corev1alpha2.ConditionInProgressState, fmt.Sprintf("%d of %d updated replicas are available", deployment.Status.AvailableReplicas, deployment.Status.UpdatedReplicas)
+	}
+
+	return corev1alpha2.ConditionSuccessState, "rollout complete"
+}
+
+// statefulSetRolloutState follows the same rules as `kubectl rollout status` for statefulsets
+func statefulSetRolloutState(statefulSet *appsv1.StatefulSet) (corev1alpha2.ReadinessConditionState, string) {
+	if statefulSet.Status.ObservedGeneration == 0 || statefulSet.Generation > statefulSet.Status.ObservedGeneration {
+		return corev1alpha2.ConditionInProgressState, "waiting for the statefulset spec update to be observed"
+	}
+
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+
+	if statefulSet.Status.ReadyReplicas < replicas {
+		return corev1alpha2.ConditionInProgressState, fmt.Sprintf("%d of %d pods are ready", statefulSet.Status.ReadyReplicas, replicas)
+	}
+
+	if statefulSet.Spec.UpdateStrategy.Type == appsv1.RollingUpdateStatefulSetStrategyType {
+		if statefulSet.Spec.UpdateStrategy.RollingUpdate != nil && statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition != nil {
+			partition := *statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition
+			if statefulSet.Status.UpdatedReplicas < replicas-partition {
+				return corev1alpha2.ConditionInProgressState, fmt.Sprintf("%d of %d pods updated in partitioned rollout", statefulSet.Status.UpdatedReplicas, replicas-partition)
+			}
+			return corev1alpha2.ConditionSuccessState, "partitioned rollout complete"
+		}
+		if statefulSet.Status.UpdateRevision != statefulSet.Status.CurrentRevision {
+			return corev1alpha2.ConditionInProgressState, fmt.Sprintf("%d pods at revision %s", statefulSet.Status.UpdatedReplicas, statefulSet.Status.UpdateRevision)
+		}
+	}
+
+	return corev1alpha2.ConditionSuccessState, "rollout complete"
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package conditions
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+const defaultProbeTimeout = 5 * time.Second
+
+// ServiceProbeEvaluator evaluates a ServiceProbeCondition
+type ServiceProbeEvaluator struct {
+	// DialContext is used to open connections to the probed services
+	DialContext func(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// NewServiceProbeEvaluator returns a ServiceProbeEvaluator that connects to services through their cluster DNS names
+func NewServiceProbeEvaluator() *ServiceProbeEvaluator {
+	return &ServiceProbeEvaluator{
+		DialContext: (&net.Dialer{}).DialContext,
+	}
+}
+
+// Evaluate probes the service referenced by the ServiceProbeCondition
+func (e *ServiceProbeEvaluator) Evaluate(ctx context.Context, clients *Clients, condition *corev1alpha2.ReadinessProviderCondition) (corev1alpha2.ReadinessConditionState, string) {
+	c := condition.ServiceProbeCondition
+	if c == nil {
+		return corev1alpha2.ConditionFailureState, "serviceProbeCondition is not defined"
+	}
+
+	// Reading the service first ensures that the service account used for evaluation has access to it
+	_, err := clients.DynamicClient.Resource(corev1.SchemeGroupVersion.WithResource("services")).Namespace(c.Namespace).Get(ctx, c.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return corev1alpha2.ConditionFailureState, "service not found"
+		}
+		return corev1alpha2.ConditionFailureState, err.Error()
+	}
+
+	timeout := defaultProbeTimeout
+	if c.TimeoutSeconds > 0 {
+		timeout = time.Duration(c.TimeoutSeconds) * time.Second
+	}
+	probeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	address := net.JoinHostPort(fmt.Sprintf("%s.%s.svc", c.Name, c.Namespace), strconv.Itoa(int(c.Port)))
+
+	switch c.Protocol {
+	case corev1alpha2.TCPServiceProbe, "":
+		conn, err := e.DialContext(probeCtx, "tcp", address)
+		if err != nil {
+			return corev1alpha2.ConditionFailureState, err.Error()
+		}
+		_ = conn.Close()
+		return corev1alpha2.ConditionSuccessState, fmt.Sprintf("connected to %s", address)
+	case corev1alpha2.HTTPServiceProbe, corev1alpha2.HTTPSServiceProbe:
+		return e.probeHTTP(probeCtx, c, address)
+	default:
+		return corev1alpha2.ConditionFailureState, fmt.Sprintf("unsupported protocol %s", c.Protocol)
+	}
+}
+
+func (e *ServiceProbeEvaluator) probeHTTP(ctx context.Context, c *corev1alpha2.ServiceProbeCondition, address string) (corev1alpha2.ReadinessConditionState, string) {
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			DialContext: e.DialContext,
+			// Like kubelet HTTPS probes, the certificate presented by the service is not verified
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+			DisableKeepAlives: true,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	url := fmt.Sprintf("%s://%s/%s", strings.ToLower(string(c.Protocol)), address, strings.TrimPrefix(c.Path, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return corev1alpha2.ConditionFailureState, err.Error()
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return corev1alpha2.ConditionFailureState, err.Error()
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusBadRequest {
+		return corev1alpha2.ConditionSuccessState, fmt.Sprintf("GET %s returned %d", url, resp.StatusCode)
+	}
+	return corev1alpha2.ConditionFailureState, fmt.Sprintf("GET %s returned %d", url, resp.StatusCode)
+}
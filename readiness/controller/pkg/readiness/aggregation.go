@@ -0,0 +1,70 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readiness
+
+import (
+	"fmt"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// defaultProviderWeight is the weight of providers that do not set one
+const defaultProviderWeight = int32(1)
+
+// providerWeight returns the weight of the provider in weighted checks
+func providerWeight(provider *corev1alpha2.ReadinessProvider) int32 {
+	if provider.Spec.Weight == nil {
+		return defaultProviderWeight
+	}
+	return *provider.Spec.Weight
+}
+
+// aggregateProviders computes the ready state of a basic check from the states of its providers according to the
+// aggregation policy of the check, and marks the providers that counted towards the result.
+// Providers count towards the result when they are active, except for providers without weight in weighted checks.
+func aggregateProviders(policy *corev1alpha2.AggregationPolicy, providers []corev1alpha2.Provider) (ready bool, message string) {
+	policyType := corev1alpha2.AnyAggregationPolicy
+	if policy != nil {
+		policyType = policy.Type
+	}
+
+	active := 0
+	activeWeight := int32(0)
+	for i := range providers {
+		if !providers[i].IsActive {
+			continue
+		}
+		if policyType == corev1alpha2.WeightedAggregationPolicy {
+			if providers[i].Weight == nil || *providers[i].Weight == 0 {
+				continue
+			}
+			activeWeight += *providers[i].Weight
+		}
+		providers[i].Counted = true
+		active++
+	}
+
+	switch policyType {
+	case corev1alpha2.AllAggregationPolicy:
+		return len(providers) > 0 && active == len(providers),
+			fmt.Sprintf("%d of %d provider(s) active; all required", active, len(providers))
+	case corev1alpha2.MinCountAggregationPolicy:
+		minCount := int32(1)
+		if policy.MinCount != nil {
+			minCount = *policy.MinCount
+		}
+		return int32(active) >= minCount,
+			fmt.Sprintf("%d of %d provider(s) active; %d required", active, len(providers), minCount)
+	case corev1alpha2.WeightedAggregationPolicy:
+		threshold := int32(1)
+		if policy.Threshold != nil {
+			threshold = *policy.Threshold
+		}
+		return activeWeight >= threshold,
+			fmt.Sprintf("weight of active provider(s) is %d; %d required", activeWeight, threshold)
+	default:
+		return active > 0,
+			fmt.Sprintf("%d of %d provider(s) active; at least 1 required", active, len(providers))
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readiness
+
+import (
+	"testing"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func TestAggregateProviders(t *testing.T) {
+	testCases := []struct {
+		description string
+		policy      *corev1alpha2.AggregationPolicy
+		providers   []corev1alpha2.Provider
+		wantReady   bool
+		wantCounted []bool
+	}{
+		{
+			description: "Default policy with one active provider",
+			providers:   []corev1alpha2.Provider{{Name: "a", IsActive: false}, {Name: "b", IsActive: true}},
+			wantReady:   true,
+			wantCounted: []bool{false, true},
+		},
+		{
+			description: "Any policy without providers",
+			policy:      &corev1alpha2.AggregationPolicy{Type: corev1alpha2.AnyAggregationPolicy},
+			wantReady:   false,
+		},
+		{
+			description: "All policy with an inactive provider",
+			policy:      &corev1alpha2.AggregationPolicy{Type: corev1alpha2.AllAggregationPolicy},
+			providers:   []corev1alpha2.Provider{{Name: "a", IsActive: true}, {Name: "b", IsActive: false}},
+			wantReady:   false,
+			wantCounted: []bool{true, false},
+		},
+		{
+			description: "All policy with active providers",
+			policy:      &corev1alpha2.AggregationPolicy{Type: corev1alpha2.AllAggregationPolicy},
+			providers:   []corev1alpha2.Provider{{Name: "a", IsActive: true}, {Name: "b", IsActive: true}},
+			wantReady:   true,
+			wantCounted: []bool{true, true},
+		},
+		{
+			description: "All policy without providers",
+			policy:      &corev1alpha2.AggregationPolicy{Type: corev1alpha2.AllAggregationPolicy},
+			wantReady:   false,
+		},
+		{
+			description: "MinCount policy satisfied by 2 of 3 providers",
+			policy:      &corev1alpha2.AggregationPolicy{Type: corev1alpha2.MinCountAggregationPolicy, MinCount: int32Ptr(2)},
+			providers:   []corev1alpha2.Provider{{Name: "a", IsActive: true}, {Name: "b", IsActive: false}, {Name: "c", IsActive: true}},
+			wantReady:   true,
+			wantCounted: []bool{true, false, true},
+		},
+		{
+			description: "MinCount policy not satisfied",
+			policy:      &corev1alpha2.AggregationPolicy{Type: corev1alpha2.MinCountAggregationPolicy, MinCount: int32Ptr(2)},
+			providers:   []corev1alpha2.Provider{{Name: "a", IsActive: true}, {Name: "b", IsActive: false}},
+			wantReady:   false,
+			wantCounted: []bool{true, false},
+		},
+		{
+			description: "Weighted policy reaching the threshold",
+			policy:      &corev1alpha2.AggregationPolicy{Type: corev1alpha2.WeightedAggregationPolicy, Threshold: int32Ptr(5)},
+			providers: []corev1alpha2.Provider{
+				{Name: "a", IsActive: true, Weight: int32Ptr(3)},
+				{Name: "b", IsActive: true, Weight: int32Ptr(2)},
+				{Name: "c", IsActive: false, Weight: int32Ptr(4)},
+			},
+			wantReady:   true,
+			wantCounted: []bool{true, true, false},
+		},
+		{
+			description: "Weighted policy below the threshold ignores providers without weight",
+			policy:      &corev1alpha2.AggregationPolicy{Type: corev1alpha2.WeightedAggregationPolicy, Threshold: int32Ptr(5)},
+			providers: []corev1alpha2.Provider{
+				{Name: "a", IsActive: true, Weight: int32Ptr(3)},
+				{Name: "b", IsActive: true, Weight: int32Ptr(0)},
+			},
+			wantReady:   false,
+			wantCounted: []bool{true, false},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			ready, message := aggregateProviders(tc.policy, tc.providers)
+			if ready != tc.wantReady {
+				t.Errorf("got ready %t, want %t (%s)", ready, tc.wantReady, message)
+			}
+			for i := range tc.wantCounted {
+				if tc.providers[i].Counted != tc.wantCounted[i] {
+					t.Errorf("provider %s: got counted %t, want %t", tc.providers[i].Name, tc.providers[i].Counted, tc.wantCounted[i])
+				}
+			}
+		})
+	}
+}
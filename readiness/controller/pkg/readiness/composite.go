@@ -0,0 +1,126 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readiness
+
+import (
+	"fmt"
+	"strings"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// visit states used while walking the composite check graph
+const (
+	unvisited = iota
+	visiting
+	visited
+)
+
+// compositeEvaluator evaluates composite checks of a Readiness by walking the graph of check references
+type compositeEvaluator struct {
+	checks   map[string]corev1alpha2.Check
+	statuses map[string]*corev1alpha2.CheckStatus
+	state    map[string]int
+	stack    []string
+	cycles   map[string][]string
+}
+
+// evaluateCompositeChecks updates the status of all composite checks based on the status of the checks they reference.
+// The statuses of basic checks must already be computed. Readiness is propagated transitively through composite checks
+// that reference other composite checks; checks that are part of a reference cycle are never ready.
+func evaluateCompositeChecks(checks []corev1alpha2.Check, checkStatuses []corev1alpha2.CheckStatus) {
+	e := &compositeEvaluator{
+		checks:   make(map[string]corev1alpha2.Check, len(checks)),
+		statuses: make(map[string]*corev1alpha2.CheckStatus, len(checkStatuses)),
+		state:    make(map[string]int, len(checks)),
+		cycles:   make(map[string][]string),
+	}
+
+	for _, check := range checks {
+		e.checks[check.Name] = check
+	}
+
+	for i := range checkStatuses {
+		e.statuses[checkStatuses[i].Name] = &checkStatuses[i]
+	}
+
+	for _, check := range checks {
+		e.evaluate(check.Name)
+	}
+
+	for name, cycle := range e.cycles {
+		status := e.statuses[name]
+		status.Ready = false
+		status.Message = fmt.Sprintf("circular dependency between checks: %s", strings.Join(cycle, " -> "))
+	}
+}
+
+// evaluate returns the readiness of the named check, computing it first if the check is composite
+func (e *compositeEvaluator) evaluate(name string) bool {
+	check := e.checks[name]
+	status := e.statuses[name]
+
+	if check.Type != corev1alpha2.CompositeReadinessCheck {
+		return status.Ready
+	}
+
+	switch e.state[name] {
+	case visited:
+		return status.Ready
+	case visiting:
+		e.recordCycle(name)
+		return false
+	}
+
+	e.state[name] = visiting
+	e.stack = append(e.stack, name)
+
+	status.Ready = true
+	status.BlockingChecks = []string{}
+	var missing []string
+
+	for _, ref := range check.CheckRefs {
+		if _, ok := e.checks[ref]; !ok {
+			missing = append(missing, ref)
+			status.BlockingChecks = append(status.BlockingChecks, ref)
+			status.Ready = false
+			continue
+		}
+
+		if !e.evaluate(ref) {
+			status.BlockingChecks = append(status.BlockingChecks, ref)
+			status.Ready = false
+		}
+	}
+
+	switch {
+	case len(missing) > 0:
+		status.Message = fmt.Sprintf("referenced check(s) not defined: %s", strings.Join(missing, ", "))
+	case !status.Ready:
+		status.Message = "one or more referenced check(s) are not ready"
+	default:
+		status.Message = "all referenced check(s) are ready"
+	}
+
+	e.stack = e.stack[:len(e.stack)-1]
+	e.state[name] = visited
+
+	return status.Ready
+}
+
+// recordCycle marks every check on the current path starting from name as part of a cycle
+func (e *compositeEvaluator) recordCycle(name string) {
+	start := 0
+	for i, n := range e.stack {
+		if n == name {
+			start = i
+			break
+		}
+	}
+
+	cycle := append(append([]string{}, e.stack[start:]...), name)
+	for _, n := range e.stack[start:] {
+		e.cycles[n] = cycle
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readiness
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// applyCheckDeadlines marks the checks that are not ready after their progress deadline, counting from their last
+// transition. It returns the names of the checks that exceeded their deadline and the time left before the next
+// pending deadline expires, or zero if there is no deadline pending.
+// The checks must have their transition times recorded already.
+func applyCheckDeadlines(checks []corev1alpha2.Check, checkStatuses []corev1alpha2.CheckStatus, now metav1.Time) (exceeded []string, nextDeadline time.Duration) {
+	deadlines := make(map[string]*int32, len(checks))
+	for i := range checks {
+		deadlines[checks[i].Name] = checks[i].ProgressDeadlineSeconds
+	}
+
+	for i := range checkStatuses {
+		status := &checkStatuses[i]
+		seconds := deadlines[status.Name]
+		if seconds == nil || status.Ready || status.LastTransitionTime == nil {
+			continue
+		}
+
+		deadline := time.Duration(*seconds) * time.Second
+		elapsed := now.Sub(status.LastTransitionTime.Time)
+		if elapsed < deadline {
+			if remaining := deadline - elapsed; nextDeadline == 0 || remaining < nextDeadline {
+				nextDeadline = remaining
+			}
+			continue
+		}
+
+		status.DeadlineExceeded = true
+		status.Message = fmt.Sprintf("progress deadline of %s exceeded: %s", deadline, status.Message)
+		exceeded = append(exceeded, status.Name)
+	}
+
+	return exceeded, nextDeadline
+}
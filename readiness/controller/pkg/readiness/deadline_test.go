@@ -0,0 +1,47 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readiness
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+func TestApplyCheckDeadlines(t *testing.T) {
+	now := metav1.NewTime(time.Date(2023, 1, 1, 0, 10, 0, 0, time.UTC))
+	twoMinutesAgo := metav1.NewTime(now.Add(-2 * time.Minute))
+	sixMinutesAgo := metav1.NewTime(now.Add(-6 * time.Minute))
+	fiveMinutes := int32(300)
+
+	checks := []corev1alpha2.Check{
+		{Name: "expired", ProgressDeadlineSeconds: &fiveMinutes},
+		{Name: "pending", ProgressDeadlineSeconds: &fiveMinutes},
+		{Name: "ready", ProgressDeadlineSeconds: &fiveMinutes},
+		{Name: "unbounded"},
+	}
+	statuses := []corev1alpha2.CheckStatus{
+		{Name: "expired", LastTransitionTime: &sixMinutesAgo},
+		{Name: "pending", LastTransitionTime: &twoMinutesAgo},
+		{Name: "ready", Ready: true, LastTransitionTime: &sixMinutesAgo},
+		{Name: "unbounded", LastTransitionTime: &sixMinutesAgo},
+	}
+
+	exceeded, nextDeadline := applyCheckDeadlines(checks, statuses, now)
+
+	if len(exceeded) != 1 || exceeded[0] != "expired" {
+		t.Errorf("expected only the expired check to exceed its deadline, got %v", exceeded)
+	}
+	if nextDeadline != 3*time.Minute {
+		t.Errorf("got next deadline %s, want %s", nextDeadline, 3*time.Minute)
+	}
+	for _, status := range statuses {
+		if status.DeadlineExceeded != (status.Name == "expired") {
+			t.Errorf("check %s: unexpected deadlineExceeded %t", status.Name, status.DeadlineExceeded)
+		}
+	}
+}
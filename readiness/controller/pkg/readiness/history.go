@@ -0,0 +1,80 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readiness
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// checkTransition describes a change of the ready state of a check
+type checkTransition struct {
+	name    string
+	ready   bool
+	message string
+
+	// duration is the time the check spent in its previous state
+	duration time.Duration
+}
+
+// recordCheckTransitions carries the transition times and history of the previous check statuses over to the
+// current ones, and records a transition for every check whose ready state changed.
+// Checks that have no previous status are considered not ready since the creation of the readiness, so only the
+// new checks that are already ready are reported as transitions.
+func recordCheckTransitions(current, previous []corev1alpha2.CheckStatus, created, now metav1.Time) []checkTransition {
+	previousByName := make(map[string]*corev1alpha2.CheckStatus, len(previous))
+	for i := range previous {
+		previousByName[previous[i].Name] = &previous[i]
+	}
+
+	var transitions []checkTransition
+	for i := range current {
+		status := &current[i]
+
+		wasReady := false
+		since := created
+		if prev, ok := previousByName[status.Name]; ok {
+			wasReady = prev.Ready
+			status.History = prev.History
+			status.LastTransitionTime = prev.LastTransitionTime
+			if prev.LastTransitionTime != nil {
+				since = *prev.LastTransitionTime
+			}
+		}
+
+		if status.LastTransitionTime != nil && wasReady == status.Ready {
+			continue
+		}
+
+		status.LastTransitionTime = now.DeepCopy()
+		status.History = appendCheckTransition(status.History, corev1alpha2.CheckTransition{
+			Ready:   status.Ready,
+			Time:    now,
+			Message: status.Message,
+		})
+
+		if wasReady != status.Ready {
+			transitions = append(transitions, checkTransition{
+				name:     status.Name,
+				ready:    status.Ready,
+				message:  status.Message,
+				duration: now.Sub(since.Time),
+			})
+		}
+	}
+
+	return transitions
+}
+
+// appendCheckTransition appends the transition to the history, dropping the oldest transitions beyond the limit
+func appendCheckTransition(history []corev1alpha2.CheckTransition, transition corev1alpha2.CheckTransition) []corev1alpha2.CheckTransition {
+	history = append(history, transition)
+	if len(history) > corev1alpha2.MaxCheckHistory {
+		history = history[len(history)-corev1alpha2.MaxCheckHistory:]
+	}
+	return history
+}
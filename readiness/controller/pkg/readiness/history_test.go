@@ -0,0 +1,67 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readiness
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+func TestRecordCheckTransitions(t *testing.T) {
+	created := metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	lastTransition := metav1.NewTime(created.Add(time.Minute))
+	now := metav1.NewTime(created.Add(10 * time.Minute))
+
+	previous := []corev1alpha2.CheckStatus{
+		{Name: "unchanged", Ready: true, LastTransitionTime: &lastTransition, History: []corev1alpha2.CheckTransition{{Ready: true, Time: lastTransition}}},
+		{Name: "flipped", Ready: true, LastTransitionTime: &lastTransition, History: []corev1alpha2.CheckTransition{{Ready: true, Time: lastTransition}}},
+		{Name: "removed", Ready: true, LastTransitionTime: &lastTransition},
+	}
+	current := []corev1alpha2.CheckStatus{
+		{Name: "unchanged", Ready: true},
+		{Name: "flipped", Ready: false, Message: "provider failed"},
+		{Name: "newReady", Ready: true},
+		{Name: "newNotReady", Ready: false},
+	}
+
+	transitions := recordCheckTransitions(current, previous, created, now)
+
+	if len(transitions) != 2 {
+		t.Fatalf("expected 2 transitions, got %+v", transitions)
+	}
+	if tr := transitions[0]; tr.name != "flipped" || tr.ready || tr.duration != 9*time.Minute || tr.message != "provider failed" {
+		t.Errorf("unexpected transition for flipped check: %+v", tr)
+	}
+	if tr := transitions[1]; tr.name != "newReady" || !tr.ready || tr.duration != 10*time.Minute {
+		t.Errorf("unexpected transition for new ready check: %+v", tr)
+	}
+
+	if !current[0].LastTransitionTime.Equal(&lastTransition) || len(current[0].History) != 1 {
+		t.Errorf("expected unchanged check to keep its transition time and history, got %+v", current[0])
+	}
+	if !current[1].LastTransitionTime.Equal(&now) || len(current[1].History) != 2 || current[1].History[1].Ready {
+		t.Errorf("expected flipped check to record a transition, got %+v", current[1])
+	}
+	if !current[3].LastTransitionTime.Equal(&now) || len(current[3].History) != 1 {
+		t.Errorf("expected new check to record its initial state, got %+v", current[3])
+	}
+}
+
+func TestAppendCheckTransitionIsBounded(t *testing.T) {
+	var history []corev1alpha2.CheckTransition
+	for i := 0; i < corev1alpha2.MaxCheckHistory+5; i++ {
+		history = appendCheckTransition(history, corev1alpha2.CheckTransition{Ready: i%2 == 0, Message: string(rune('a' + i))})
+	}
+
+	if len(history) != corev1alpha2.MaxCheckHistory {
+		t.Fatalf("expected %d transitions, got %d", corev1alpha2.MaxCheckHistory, len(history))
+	}
+	if history[len(history)-1].Message != string(rune('a'+corev1alpha2.MaxCheckHistory+4)) {
+		t.Errorf("expected the most recent transition to be kept last, got %+v", history[len(history)-1])
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readiness
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// providersByCheck maps the names of the basic checks of the readiness to the providers that may satisfy them,
+// in a single pass over the providers. A provider may satisfy a check if it references the check, matches the
+// providerSelector of the check and selects the readiness with its readinessSelector.
+func providersByCheck(readiness *corev1alpha2.Readiness, providers []corev1alpha2.ReadinessProvider) map[string][]*corev1alpha2.ReadinessProvider {
+	checkSelectors := make(map[string]labels.Selector, len(readiness.Spec.Checks))
+	for i := range readiness.Spec.Checks {
+		if readiness.Spec.Checks[i].Type == corev1alpha2.CompositeReadinessCheck {
+			continue
+		}
+		checkSelectors[readiness.Spec.Checks[i].Name] = parseSelector(readiness.Spec.Checks[i].ProviderSelector)
+	}
+
+	readinessLabels := labels.Set(readiness.Labels)
+	result := make(map[string][]*corev1alpha2.ReadinessProvider, len(checkSelectors))
+
+	for i := range providers {
+		provider := &providers[i]
+		if !parseSelector(provider.Spec.ReadinessSelector).Matches(readinessLabels) {
+			continue
+		}
+
+		seen := make(map[string]bool, len(provider.Spec.CheckRefs))
+		for _, checkRef := range provider.Spec.CheckRefs {
+			selector, ok := checkSelectors[checkRef]
+			if !ok || seen[checkRef] || !selector.Matches(labels.Set(provider.Labels)) {
+				continue
+			}
+			seen[checkRef] = true
+			result[checkRef] = append(result[checkRef], provider)
+		}
+	}
+
+	return result
+}
+
+// affectedReadinesses returns the requests for the readinesses whose checks may be satisfied by any of the providers,
+// in a single pass over the readinesses
+func affectedReadinesses(readinesses []corev1alpha2.Readiness, providers ...*corev1alpha2.ReadinessProvider) []reconcile.Request {
+	checkRefs := make([]map[string]bool, len(providers))
+	readinessSelectors := make([]labels.Selector, len(providers))
+	for i, provider := range providers {
+		checkRefs[i] = make(map[string]bool, len(provider.Spec.CheckRefs))
+		for _, checkRef := range provider.Spec.CheckRefs {
+			checkRefs[i][checkRef] = true
+		}
+		readinessSelectors[i] = parseSelector(provider.Spec.ReadinessSelector)
+	}
+
+	requests := []reconcile.Request{}
+	for i := range readinesses {
+		if readinessReferencesProviders(&readinesses[i], checkRefs, readinessSelectors) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: readinesses[i].Name},
+			})
+		}
+	}
+	return requests
+}
+
+func readinessReferencesProviders(readiness *corev1alpha2.Readiness, checkRefs []map[string]bool, readinessSelectors []labels.Selector) bool {
+	readinessLabels := labels.Set(readiness.Labels)
+	for i := range checkRefs {
+		if !readinessSelectors[i].Matches(readinessLabels) {
+			continue
+		}
+		for _, check := range readiness.Spec.Checks {
+			if checkRefs[i][check.Name] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// providerChangeAffectsReadiness returns true if the update of the provider may change the status of a readiness
+func providerChangeAffectsReadiness(oldProvider, newProvider *corev1alpha2.ReadinessProvider) bool {
+	return oldProvider.Status.State != newProvider.Status.State ||
+		!equality.Semantic.DeepEqual(oldProvider.Labels, newProvider.Labels) ||
+		!equality.Semantic.DeepEqual(oldProvider.Spec.CheckRefs, newProvider.Spec.CheckRefs) ||
+		!equality.Semantic.DeepEqual(oldProvider.Spec.ReadinessSelector, newProvider.Spec.ReadinessSelector) ||
+		!equality.Semantic.DeepEqual(oldProvider.Spec.Weight, newProvider.Spec.Weight)
+}
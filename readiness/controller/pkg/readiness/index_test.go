@@ -0,0 +1,152 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readiness
+
+import (
+	"sort"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+func testProvider(name string, providerLabels map[string]string, readinessSelector *metav1.LabelSelector, checkRefs ...string) corev1alpha2.ReadinessProvider {
+	return corev1alpha2.ReadinessProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: providerLabels},
+		Spec:       corev1alpha2.ReadinessProviderSpec{CheckRefs: checkRefs, ReadinessSelector: readinessSelector},
+	}
+}
+
+func TestProvidersByCheck(t *testing.T) {
+	teamA := map[string]string{"team": "a"}
+
+	readiness := &corev1alpha2.Readiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "readiness", Labels: teamA},
+		Spec: corev1alpha2.ReadinessSpec{Checks: []corev1alpha2.Check{
+			{Name: "check1", Type: corev1alpha2.BasicReadinessCheck},
+			{Name: "check2", Type: corev1alpha2.BasicReadinessCheck, ProviderSelector: &metav1.LabelSelector{MatchLabels: teamA}},
+			{Name: "composite", Type: corev1alpha2.CompositeReadinessCheck, CheckRefs: []string{"check1"}},
+		}},
+	}
+
+	providers := []corev1alpha2.ReadinessProvider{
+		testProvider("both", teamA, nil, "check1", "check2", "check1"),
+		testProvider("unlabelled", nil, nil, "check1", "check2"),
+		testProvider("other-readiness", teamA, &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}}, "check1"),
+		testProvider("composite", nil, nil, "composite"),
+		testProvider("unrelated", nil, nil, "check3"),
+	}
+
+	got := providersByCheck(readiness, providers)
+
+	want := map[string][]string{
+		"check1": {"both", "unlabelled"},
+		"check2": {"both"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got providers for %d checks, want %d: %v", len(got), len(want), got)
+	}
+	for check, wantNames := range want {
+		var names []string
+		for _, provider := range got[check] {
+			names = append(names, provider.Name)
+		}
+		if len(names) != len(wantNames) {
+			t.Errorf("check %s: got providers %v, want %v", check, names, wantNames)
+			continue
+		}
+		for i := range names {
+			if names[i] != wantNames[i] {
+				t.Errorf("check %s: got providers %v, want %v", check, names, wantNames)
+				break
+			}
+		}
+	}
+}
+
+func TestAffectedReadinesses(t *testing.T) {
+	readinesses := []corev1alpha2.Readiness{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}},
+			Spec:       corev1alpha2.ReadinessSpec{Checks: []corev1alpha2.Check{{Name: "check1"}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"team": "b"}},
+			Spec:       corev1alpha2.ReadinessSpec{Checks: []corev1alpha2.Check{{Name: "check1"}, {Name: "check2"}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "unrelated"},
+			Spec:       corev1alpha2.ReadinessSpec{Checks: []corev1alpha2.Check{{Name: "check3"}}},
+		},
+	}
+
+	selectTeamA := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+	oldProvider := testProvider("provider", nil, nil, "check2")
+	newProvider := testProvider("provider", nil, selectTeamA, "check1")
+
+	tests := []struct {
+		description string
+		providers   []*corev1alpha2.ReadinessProvider
+		want        []string
+	}{
+		{
+			description: "provider without selector affects all readinesses with the check",
+			providers:   []*corev1alpha2.ReadinessProvider{&oldProvider},
+			want:        []string{"team-b"},
+		},
+		{
+			description: "provider with selector affects only the selected readinesses",
+			providers:   []*corev1alpha2.ReadinessProvider{&newProvider},
+			want:        []string{"team-a"},
+		},
+		{
+			description: "updated provider affects the readinesses of the old and new versions",
+			providers:   []*corev1alpha2.ReadinessProvider{&oldProvider, &newProvider},
+			want:        []string{"team-a", "team-b"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			var got []string
+			for _, req := range affectedReadinesses(readinesses, tc.providers...) {
+				got = append(got, req.Name)
+			}
+			sort.Strings(got)
+
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("got %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestProviderChangeAffectsReadiness(t *testing.T) {
+	provider := testProvider("provider", nil, nil, "check1")
+	provider.Status.State = corev1alpha2.ProviderInProgressState
+
+	conditionsOnly := provider.DeepCopy()
+	conditionsOnly.Status.Conditions = []corev1alpha2.ReadinessConditionStatus{{Name: "condition", State: corev1alpha2.ConditionInProgressState}}
+	if providerChangeAffectsReadiness(&provider, conditionsOnly) {
+		t.Errorf("expected a change of the condition statuses only not to affect readinesses")
+	}
+
+	stateChanged := provider.DeepCopy()
+	stateChanged.Status.State = corev1alpha2.ProviderSuccessState
+	if !providerChangeAffectsReadiness(&provider, stateChanged) {
+		t.Errorf("expected a change of the state to affect readinesses")
+	}
+
+	labelsChanged := provider.DeepCopy()
+	labelsChanged.Labels = map[string]string{"team": "a"}
+	if !providerChangeAffectsReadiness(&provider, labelsChanged) {
+		t.Errorf("expected a change of the labels to affect readinesses")
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readiness
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	readinessReadyGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "readiness_ready",
+		Help: "Whether the readiness is ready (1) or not (0).",
+	}, []string{"readiness"})
+
+	checkReadyGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "readiness_check_ready",
+		Help: "Whether the check of a readiness is ready (1) or not (0).",
+	}, []string{"readiness", "check"})
+
+	// Buckets range from one second to a little over four hours
+	timeToReadyBuckets = prometheus.ExponentialBuckets(1, 2, 15)
+
+	readinessTimeToReady = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "readiness_time_to_ready_seconds",
+		Help:    "Time it took the readiness to become ready, since its creation or since it last became not ready.",
+		Buckets: timeToReadyBuckets,
+	}, []string{"readiness"})
+
+	checkTimeToReady = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "readiness_check_time_to_ready_seconds",
+		Help:    "Time it took the check of a readiness to become ready, since the creation of the readiness or since the check last became not ready.",
+		Buckets: timeToReadyBuckets,
+	}, []string{"readiness", "check"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(readinessReadyGauge, checkReadyGauge, readinessTimeToReady, checkTimeToReady)
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// deleteReadinessMetrics removes the gauges of a readiness that no longer exists.
+// The histograms are kept, since they describe past transitions.
+func deleteReadinessMetrics(readinessName string) {
+	readinessReadyGauge.DeleteLabelValues(readinessName)
+	checkReadyGauge.DeletePartialMatch(prometheus.Labels{"readiness": readinessName})
+}
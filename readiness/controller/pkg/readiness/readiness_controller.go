@@ -5,16 +5,22 @@ package readiness
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
-	"k8s.io/apimachinery/pkg/fields"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
@@ -22,15 +28,27 @@ import (
 
 const contextTimeout = 30 * time.Second
 
+// Reasons of the Ready condition and of the events emitted on transitions
+const (
+	allChecksReadyReason = "AllChecksReady"
+	checksNotReadyReason = "ChecksNotReady"
+	checkReadyReason     = "CheckReady"
+	checkNotReadyReason  = "CheckNotReady"
+
+	checkDeadlineExceededReason = "CheckDeadlineExceeded"
+)
+
 // ReadinessReconciler reconciles a Readiness object
 type ReadinessReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=core.tanzu.vmware.com,resources=readinesses,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core.tanzu.vmware.com,resources=readinesses/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -41,145 +59,196 @@ func (r *ReadinessReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	readiness := &corev1alpha2.Readiness{}
 	err := r.Client.Get(ctxCancel, req.NamespacedName, readiness)
 	if err != nil {
+		if apierrors.IsNotFound(err) {
+			deleteReadinessMetrics(req.Name)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	if len(readiness.Spec.Checks) == 0 && !readiness.Status.Ready {
-		readiness.Status.Ready = true
-		readiness.Status.CheckStatus = []corev1alpha2.CheckStatus{}
-		return ctrl.Result{}, r.Client.Status().Update(ctxCancel, readiness)
-	}
-
+	previousCheckStatus := readiness.Status.CheckStatus
 	readiness.Status.CheckStatus = []corev1alpha2.CheckStatus{}
-	providersList := &corev1alpha2.ReadinessProviderList{}
-
-	// TODO: Find a better way to index and fetch the providers in a single list call
-	for _, check := range readiness.Spec.Checks {
-		providers := &corev1alpha2.ReadinessProviderList{}
-		err = r.Client.List(ctxCancel, providers, &client.ListOptions{
-			FieldSelector: fields.OneTermEqualSelector("spec.checkRef", check.Name),
-		})
-		if err != nil {
-			return ctrl.Result{}, err
-		}
-
-		providersList.Items = append(providersList.Items, providers.Items...)
-	}
 
-	// uniqueProviders contain all the providers that satisfy at least one of the checks
-	uniqueProviders := make([]corev1alpha2.ReadinessProvider, 0)
-	uniqueProviderNames := make(map[string]bool)
-
-	for i := 0; i < len(providersList.Items); i++ {
-		if _, ok := uniqueProviderNames[providersList.Items[i].Name]; !ok {
-			uniqueProviders = append(uniqueProviders, providersList.Items[i])
-			uniqueProviderNames[providersList.Items[i].Name] = true
-		}
-	}
-
-	// allChecks contain the check names and the list of associated providers
-	allChecks := make(map[string][]int)
-	for i := 0; i < len(uniqueProviders); i++ {
-		for _, checkRef := range uniqueProviders[i].Spec.CheckRefs {
-			allChecks[checkRef] = append(allChecks[checkRef], i)
-		}
+	// The providers are only read, so they are not copied out of the cache
+	providers := &corev1alpha2.ReadinessProviderList{}
+	err = r.Client.List(ctxCancel, providers, client.UnsafeDisableDeepCopy)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
+	checkProviders := providersByCheck(readiness, providers.Items)
 
-	// TODO: Handle Composite checks
-	for _, check := range readiness.Spec.Checks {
+	for i := range readiness.Spec.Checks {
+		check := &readiness.Spec.Checks[i]
 		checkStatusUpdate := corev1alpha2.CheckStatus{
 			Name:      check.Name,
 			Providers: make([]corev1alpha2.Provider, 0),
 			Ready:     false,
 		}
 
-		for _, index := range allChecks[check.Name] {
-			provider := uniqueProviders[index]
-
-			checkStatusUpdate.Ready = checkStatusUpdate.Ready || (provider.Status.State == corev1alpha2.ProviderSuccessState)
+		// Composite checks do not depend on providers; they are evaluated once all the basic checks are evaluated
+		if check.Type == corev1alpha2.CompositeReadinessCheck {
+			readiness.Status.CheckStatus = append(readiness.Status.CheckStatus, checkStatusUpdate)
+			continue
+		}
 
-			checkStatusUpdate.Providers = append(checkStatusUpdate.Providers, corev1alpha2.Provider{
+		for _, provider := range checkProviders[check.Name] {
+			status := corev1alpha2.Provider{
 				Name:     provider.Name,
 				IsActive: provider.Status.State == corev1alpha2.ProviderSuccessState,
-			})
+			}
+			if check.Aggregation != nil && check.Aggregation.Type == corev1alpha2.WeightedAggregationPolicy {
+				weight := providerWeight(provider)
+				status.Weight = &weight
+			}
+			checkStatusUpdate.Providers = append(checkStatusUpdate.Providers, status)
 		}
+
+		checkStatusUpdate.Ready, checkStatusUpdate.Message = aggregateProviders(check.Aggregation, checkStatusUpdate.Providers)
 		readiness.Status.CheckStatus = append(readiness.Status.CheckStatus, checkStatusUpdate)
 	}
 
+	evaluateCompositeChecks(readiness.Spec.Checks, readiness.Status.CheckStatus)
+
 	readiness.Status.Ready = true
 
 	for _, checkStatus := range readiness.Status.CheckStatus {
 		readiness.Status.Ready = readiness.Status.Ready && checkStatus.Ready
 	}
 
-	return ctrl.Result{}, r.Client.Status().Update(ctxCancel, readiness)
-}
+	// Reconcile again when a pending check deadline expires, so that it is reported on time
+	nextDeadline := r.recordTransitions(readiness, previousCheckStatus)
 
-// SetupWithManager sets up the controller with the Manager.
-func (r *ReadinessReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1alpha2.ReadinessProvider{}, "spec.checkRef", func(rawObj client.Object) []string {
-		provider := rawObj.(*corev1alpha2.ReadinessProvider)
-		var indices []string
-		indices = append(indices, provider.Spec.CheckRefs...)
-		return indices
-	})
+	return ctrl.Result{RequeueAfter: nextDeadline}, r.Client.Status().Update(ctxCancel, readiness)
+}
 
-	if err != nil {
-		return err
+// recordTransitions updates the transition times, history, deadlines and Ready condition of the readiness,
+// and emits events and metrics for the checks and the readiness whose ready state changed.
+// It returns the time left before the next pending check deadline expires, or zero if there is none.
+func (r *ReadinessReconciler) recordTransitions(readiness *corev1alpha2.Readiness, previousCheckStatus []corev1alpha2.CheckStatus) time.Duration {
+	now := metav1.Now()
+
+	for _, transition := range recordCheckTransitions(readiness.Status.CheckStatus, previousCheckStatus, readiness.CreationTimestamp, now) {
+		if transition.ready {
+			checkTimeToReady.WithLabelValues(readiness.Name, transition.name).Observe(transition.duration.Seconds())
+			r.Recorder.Eventf(readiness, corev1.EventTypeNormal, checkReadyReason, "Check %s is ready", transition.name)
+		} else {
+			r.Recorder.Eventf(readiness, corev1.EventTypeWarning, checkNotReadyReason, "Check %s is not ready: %s", transition.name, transition.message)
+		}
 	}
 
-	err = mgr.GetFieldIndexer().IndexField(context.Background(), &corev1alpha2.Readiness{}, "spec.checks.name", func(rawObj client.Object) []string {
-		provider := rawObj.(*corev1alpha2.Readiness)
-
-		keys := []string{}
-		for _, check := range provider.Spec.Checks {
-			keys = append(keys, check.Name)
+	previouslyExceeded := make(map[string]bool, len(previousCheckStatus))
+	for _, checkStatus := range previousCheckStatus {
+		previouslyExceeded[checkStatus.Name] = checkStatus.DeadlineExceeded
+	}
+	exceeded, nextDeadline := applyCheckDeadlines(readiness.Spec.Checks, readiness.Status.CheckStatus, now)
+	for _, name := range exceeded {
+		if !previouslyExceeded[name] {
+			r.Recorder.Eventf(readiness, corev1.EventTypeWarning, corev1alpha2.ProgressDeadlineExceededReason, "Check %s did not become ready within its progress deadline", name)
 		}
+	}
 
-		return keys
-	})
+	currentChecks := make(map[string]bool, len(readiness.Status.CheckStatus))
+	for _, checkStatus := range readiness.Status.CheckStatus {
+		currentChecks[checkStatus.Name] = true
+		checkReadyGauge.WithLabelValues(readiness.Name, checkStatus.Name).Set(boolToFloat(checkStatus.Ready))
+	}
+	for _, checkStatus := range previousCheckStatus {
+		if !currentChecks[checkStatus.Name] {
+			checkReadyGauge.DeleteLabelValues(readiness.Name, checkStatus.Name)
+		}
+	}
+	readinessReadyGauge.WithLabelValues(readiness.Name).Set(boolToFloat(readiness.Status.Ready))
+
+	condition := metav1.Condition{
+		Type:               corev1alpha2.ReadyConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             allChecksReadyReason,
+		Message:            "all check(s) are ready",
+		ObservedGeneration: readiness.Generation,
+	}
+	if !readiness.Status.Ready {
+		var notReady []string
+		for _, checkStatus := range readiness.Status.CheckStatus {
+			if !checkStatus.Ready {
+				notReady = append(notReady, checkStatus.Name)
+			}
+		}
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = checksNotReadyReason
+		condition.Message = fmt.Sprintf("check(s) not ready: %s", strings.Join(notReady, ", "))
+		if len(exceeded) > 0 {
+			condition.Reason = checkDeadlineExceededReason
+			condition.Message = fmt.Sprintf("progress deadline exceeded by check(s): %s; %s", strings.Join(exceeded, ", "), condition.Message)
+		}
+	}
 
-	if err != nil {
-		return err
+	previous := meta.FindStatusCondition(readiness.Status.Conditions, corev1alpha2.ReadyConditionType)
+	since := readiness.CreationTimestamp
+	if previous != nil {
+		since = previous.LastTransitionTime
+	}
+	meta.SetStatusCondition(&readiness.Status.Conditions, condition)
+
+	wasReady := previous != nil && previous.Status == metav1.ConditionTrue
+	switch {
+	case readiness.Status.Ready && !wasReady:
+		readinessTimeToReady.WithLabelValues(readiness.Name).Observe(now.Sub(since.Time).Seconds())
+		r.Recorder.Event(readiness, corev1.EventTypeNormal, allChecksReadyReason, condition.Message)
+	case !readiness.Status.Ready && wasReady:
+		r.Recorder.Event(readiness, corev1.EventTypeWarning, checksNotReadyReason, condition.Message)
 	}
 
+	return nextDeadline
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ReadinessReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&corev1alpha2.Readiness{}).
 		Watches(
 			&source.Kind{Type: &corev1alpha2.ReadinessProvider{}},
-			handler.EnqueueRequestsFromMapFunc(r.findObjectsForReadinessProvider),
+			handler.Funcs{
+				CreateFunc: func(e event.CreateEvent, q workqueue.RateLimitingInterface) {
+					r.enqueueAffectedReadinesses(q, e.Object)
+				},
+				UpdateFunc: func(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+					oldProvider, oldOK := e.ObjectOld.(*corev1alpha2.ReadinessProvider)
+					newProvider, newOK := e.ObjectNew.(*corev1alpha2.ReadinessProvider)
+					if oldOK && newOK && !providerChangeAffectsReadiness(oldProvider, newProvider) {
+						return
+					}
+					// Readinesses that the provider no longer satisfies are updated too
+					r.enqueueAffectedReadinesses(q, e.ObjectOld, e.ObjectNew)
+				},
+				DeleteFunc: func(e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+					r.enqueueAffectedReadinesses(q, e.Object)
+				},
+				GenericFunc: func(e event.GenericEvent, q workqueue.RateLimitingInterface) {
+					r.enqueueAffectedReadinesses(q, e.Object)
+				},
+			},
 		).
 		Complete(r)
 }
 
-func (r *ReadinessReconciler) findObjectsForReadinessProvider(readinessProviderObject client.Object) []reconcile.Request {
+// enqueueAffectedReadinesses enqueues the readinesses whose checks may be satisfied by any of the given providers
+func (r *ReadinessReconciler) enqueueAffectedReadinesses(q workqueue.RateLimitingInterface, objects ...client.Object) {
 	ctxCancel, cancel := context.WithTimeout(context.Background(), contextTimeout)
 	defer cancel()
 
-	provider, _ := readinessProviderObject.(*corev1alpha2.ReadinessProvider)
-
-	totalReadinessList := &corev1alpha2.ReadinessList{}
-	for _, checkRef := range provider.Spec.CheckRefs {
-		readinessList := &corev1alpha2.ReadinessList{}
-		err := r.Client.List(ctxCancel, readinessList, &client.ListOptions{
-			FieldSelector: fields.OneTermEqualSelector("spec.checks.name", checkRef),
-		})
-		if err != nil {
-			r.Log.Error(err, "error while updating readiness status")
-			return []reconcile.Request{}
+	providers := make([]*corev1alpha2.ReadinessProvider, 0, len(objects))
+	for _, obj := range objects {
+		if provider, ok := obj.(*corev1alpha2.ReadinessProvider); ok {
+			providers = append(providers, provider)
 		}
-		totalReadinessList.Items = append(totalReadinessList.Items, readinessList.Items...)
 	}
 
-	requests := []reconcile.Request{}
-
-	for i := 0; i < len(totalReadinessList.Items); i++ {
-		requests = append(requests, reconcile.Request{
-			NamespacedName: types.NamespacedName{
-				Name: totalReadinessList.Items[i].Name,
-			},
-		})
+	readinessList := &corev1alpha2.ReadinessList{}
+	if err := r.Client.List(ctxCancel, readinessList, client.UnsafeDisableDeepCopy); err != nil {
+		r.Log.Error(err, "error while listing readinesses affected by provider")
+		return
 	}
 
-	return requests
+	for _, req := range affectedReadinesses(readinessList.Items, providers...) {
+		q.Add(req)
+	}
 }
@@ -6,12 +6,14 @@ package readiness
 import (
 	"context"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -79,9 +81,10 @@ var _ = BeforeSuite(func() {
 	Expect(k8sClient).NotTo(BeNil())
 
 	err = (&ReadinessReconciler{
-		Client: k8sManager.GetClient(),
-		Scheme: k8sManager.GetScheme(),
-		Log:    setupLog,
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Log:      setupLog,
+		Recorder: k8sManager.GetEventRecorderFor("readiness-controller"),
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -406,6 +409,234 @@ var _ = Describe("Readiness controller", func() {
 		}, timeout, interval).Should(BeTrue())
 	})
 
+	It("Readiness with a composite check should be ready when the referenced checks are ready", func() {
+		readiness := getTestReadiness()
+		readiness.Spec.Checks = append(readiness.Spec.Checks, corev1alpha2.Check{
+			Name: "check10",
+			Type: corev1alpha2.BasicReadinessCheck,
+		})
+		readiness.Spec.Checks = append(readiness.Spec.Checks, corev1alpha2.Check{
+			Name:      "composite1",
+			Type:      corev1alpha2.CompositeReadinessCheck,
+			CheckRefs: []string{"check10"},
+		})
+		readiness.Spec.Checks = append(readiness.Spec.Checks, corev1alpha2.Check{
+			Name:      "composite2",
+			Type:      corev1alpha2.CompositeReadinessCheck,
+			CheckRefs: []string{"composite1"},
+		})
+		err := k8sClient.Create(ctx, readiness)
+		Expect(err).To(BeNil())
+
+		// The basic check is not ready, so both composite checks are blocked
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: readiness.Name}, readiness)
+			return err == nil &&
+				!readiness.Status.Ready &&
+				len(readiness.Status.CheckStatus) == 3 &&
+				!readiness.Status.CheckStatus[1].Ready &&
+				len(readiness.Status.CheckStatus[1].BlockingChecks) == 1 &&
+				readiness.Status.CheckStatus[1].BlockingChecks[0] == "check10" &&
+				!readiness.Status.CheckStatus[2].Ready &&
+				len(readiness.Status.CheckStatus[2].BlockingChecks) == 1 &&
+				readiness.Status.CheckStatus[2].BlockingChecks[0] == "composite1"
+		}, timeout, interval).Should(BeTrue())
+
+		provider := getTestReadinessProvider()
+		provider.Spec.CheckRefs = []string{"check10"}
+		err = k8sClient.Create(ctx, provider)
+		Expect(err).To(BeNil())
+
+		provider.Status.State = corev1alpha2.ProviderSuccessState
+		provider.Status.Conditions = []corev1alpha2.ReadinessConditionStatus{}
+		err = k8sClient.Status().Update(ctx, provider)
+		Expect(err).To(BeNil())
+
+		// Readiness is propagated transitively to both composite checks
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: readiness.Name}, readiness)
+			return err == nil &&
+				readiness.Status.Ready &&
+				readiness.Status.CheckStatus[1].Ready &&
+				len(readiness.Status.CheckStatus[1].BlockingChecks) == 0 &&
+				readiness.Status.CheckStatus[2].Ready &&
+				len(readiness.Status.CheckStatus[2].BlockingChecks) == 0
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	It("Readiness with composite checks referencing each other should not be ready", func() {
+		readiness := getTestReadiness()
+		readiness.Spec.Checks = append(readiness.Spec.Checks, corev1alpha2.Check{
+			Name:      "composite3",
+			Type:      corev1alpha2.CompositeReadinessCheck,
+			CheckRefs: []string{"composite4"},
+		})
+		readiness.Spec.Checks = append(readiness.Spec.Checks, corev1alpha2.Check{
+			Name:      "composite4",
+			Type:      corev1alpha2.CompositeReadinessCheck,
+			CheckRefs: []string{"composite3"},
+		})
+		err := k8sClient.Create(ctx, readiness)
+		Expect(err).To(BeNil())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: readiness.Name}, readiness)
+			return err == nil &&
+				!readiness.Status.Ready &&
+				len(readiness.Status.CheckStatus) == 2 &&
+				!readiness.Status.CheckStatus[0].Ready &&
+				!readiness.Status.CheckStatus[1].Ready &&
+				strings.Contains(readiness.Status.CheckStatus[0].Message, "circular dependency")
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	It("Readiness with a composite check referencing an undefined check should not be ready", func() {
+		readiness := getTestReadiness()
+		readiness.Spec.Checks = append(readiness.Spec.Checks, corev1alpha2.Check{
+			Name:      "composite5",
+			Type:      corev1alpha2.CompositeReadinessCheck,
+			CheckRefs: []string{"undefined"},
+		})
+		err := k8sClient.Create(ctx, readiness)
+		Expect(err).To(BeNil())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: readiness.Name}, readiness)
+			return err == nil &&
+				!readiness.Status.Ready &&
+				len(readiness.Status.CheckStatus) == 1 &&
+				len(readiness.Status.CheckStatus[0].BlockingChecks) == 1 &&
+				readiness.Status.CheckStatus[0].BlockingChecks[0] == "undefined"
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	It("Readiness check with a provider selector should only count the selected providers", func() {
+		selected := getTestReadinessProvider()
+		selected.Labels = map[string]string{"team": "a"}
+		selected.Spec.CheckRefs = []string{"check11"}
+		err := k8sClient.Create(ctx, selected)
+		Expect(err).To(BeNil())
+
+		selected.Status.State = corev1alpha2.ProviderFailureState
+		selected.Status.Conditions = []corev1alpha2.ReadinessConditionStatus{}
+		err = k8sClient.Status().Update(ctx, selected)
+		Expect(err).To(BeNil())
+
+		other := getTestReadinessProvider()
+		other.Labels = map[string]string{"team": "b"}
+		other.Spec.CheckRefs = []string{"check11"}
+		err = k8sClient.Create(ctx, other)
+		Expect(err).To(BeNil())
+
+		other.Status.State = corev1alpha2.ProviderSuccessState
+		other.Status.Conditions = []corev1alpha2.ReadinessConditionStatus{}
+		err = k8sClient.Status().Update(ctx, other)
+		Expect(err).To(BeNil())
+
+		readiness := getTestReadiness()
+		readiness.Spec.Checks = append(readiness.Spec.Checks, corev1alpha2.Check{
+			Name:             "check11",
+			Type:             corev1alpha2.BasicReadinessCheck,
+			ProviderSelector: &v1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+		})
+		err = k8sClient.Create(ctx, readiness)
+		Expect(err).To(BeNil())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: readiness.Name}, readiness)
+			return err == nil &&
+				!readiness.Status.Ready &&
+				len(readiness.Status.CheckStatus) == 1 &&
+				len(readiness.Status.CheckStatus[0].Providers) == 1 &&
+				readiness.Status.CheckStatus[0].Providers[0].Name == selected.Name
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	It("Provider with a readiness selector should only satisfy the selected readiness", func() {
+		provider := getTestReadinessProvider()
+		provider.Spec.CheckRefs = []string{"check12"}
+		provider.Spec.ReadinessSelector = &v1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+		err := k8sClient.Create(ctx, provider)
+		Expect(err).To(BeNil())
+
+		provider.Status.State = corev1alpha2.ProviderSuccessState
+		provider.Status.Conditions = []corev1alpha2.ReadinessConditionStatus{}
+		err = k8sClient.Status().Update(ctx, provider)
+		Expect(err).To(BeNil())
+
+		selected := getTestReadiness()
+		selected.Labels = map[string]string{"team": "a"}
+		selected.Spec.Checks = append(selected.Spec.Checks, corev1alpha2.Check{
+			Name: "check12",
+			Type: corev1alpha2.BasicReadinessCheck,
+		})
+		err = k8sClient.Create(ctx, selected)
+		Expect(err).To(BeNil())
+
+		other := getTestReadiness()
+		other.Labels = map[string]string{"team": "b"}
+		other.Spec.Checks = append(other.Spec.Checks, corev1alpha2.Check{
+			Name: "check12",
+			Type: corev1alpha2.BasicReadinessCheck,
+		})
+		err = k8sClient.Create(ctx, other)
+		Expect(err).To(BeNil())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: selected.Name}, selected)
+			return err == nil && selected.Status.Ready
+		}, timeout, interval).Should(BeTrue())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: other.Name}, other)
+			return err == nil &&
+				!other.Status.Ready &&
+				len(other.Status.CheckStatus) == 1 &&
+				len(other.Status.CheckStatus[0].Providers) == 0
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	It("Readiness should record the Ready condition and the transitions of its checks", func() {
+		readiness := getTestReadiness()
+		readiness.Spec.Checks = append(readiness.Spec.Checks, corev1alpha2.Check{
+			Name: "check13",
+			Type: corev1alpha2.BasicReadinessCheck,
+		})
+		err := k8sClient.Create(ctx, readiness)
+		Expect(err).To(BeNil())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: readiness.Name}, readiness)
+			condition := meta.FindStatusCondition(readiness.Status.Conditions, corev1alpha2.ReadyConditionType)
+			return err == nil &&
+				condition != nil &&
+				condition.Status == v1.ConditionFalse &&
+				len(readiness.Status.CheckStatus) == 1 &&
+				readiness.Status.CheckStatus[0].LastTransitionTime != nil &&
+				len(readiness.Status.CheckStatus[0].History) == 1
+		}, timeout, interval).Should(BeTrue())
+
+		provider := getTestReadinessProvider()
+		provider.Spec.CheckRefs = []string{"check13"}
+		err = k8sClient.Create(ctx, provider)
+		Expect(err).To(BeNil())
+
+		provider.Status.State = corev1alpha2.ProviderSuccessState
+		provider.Status.Conditions = []corev1alpha2.ReadinessConditionStatus{}
+		err = k8sClient.Status().Update(ctx, provider)
+		Expect(err).To(BeNil())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: readiness.Name}, readiness)
+			condition := meta.FindStatusCondition(readiness.Status.Conditions, corev1alpha2.ReadyConditionType)
+			return err == nil &&
+				condition != nil &&
+				condition.Status == v1.ConditionTrue &&
+				len(readiness.Status.CheckStatus) == 1 &&
+				len(readiness.Status.CheckStatus[0].History) == 2 &&
+				readiness.Status.CheckStatus[0].History[1].Ready
+		}, timeout, interval).Should(BeTrue())
+	})
 })
 
 func getTestReadiness() *corev1alpha2.Readiness {
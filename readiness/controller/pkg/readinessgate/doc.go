@@ -0,0 +1,5 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package readinessgate has the webhook and the controller that hold workloads until a Readiness is ready.
+package readinessgate
@@ -0,0 +1,152 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readinessgate
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// RequiresAnnotation is set on a workload to the name of the Readiness that must be ready before the workload starts
+	RequiresAnnotation = "readiness.tanzu.vmware.com/requires"
+
+	// HeldByLabel is set on a held workload to the name of the Readiness that it waits for
+	HeldByLabel = "readiness.tanzu.vmware.com/held-by"
+
+	// HeldValueAnnotation keeps the value of the held field, which is restored when the workload is released
+	HeldValueAnnotation = "readiness.tanzu.vmware.com/held-value"
+)
+
+// gatedKind describes how a kind of workload is held
+type gatedKind struct {
+	gvk schema.GroupVersionKind
+
+	// fields is the path of the field that holds the workload
+	fields []string
+
+	// heldValue is the value of the field while the workload is held
+	heldValue interface{}
+
+	// defaultValue is the value of the field when it is not set
+	defaultValue interface{}
+}
+
+// gatedKinds are the kinds of workloads that can be held. Deployments are scaled to zero,
+// Jobs are suspended and PackageInstalls are paused.
+var gatedKinds = []gatedKind{
+	{
+		gvk:          schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		fields:       []string{"spec", "replicas"},
+		heldValue:    int64(0),
+		defaultValue: int64(1),
+	},
+	{
+		gvk:          schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"},
+		fields:       []string{"spec", "suspend"},
+		heldValue:    true,
+		defaultValue: false,
+	},
+	{
+		gvk:          schema.GroupVersionKind{Group: "packaging.carvel.dev", Version: "v1alpha1", Kind: "PackageInstall"},
+		fields:       []string{"spec", "paused"},
+		heldValue:    true,
+		defaultValue: false,
+	},
+}
+
+// gatedKindFor returns the gated kind of the object, if any
+func gatedKindFor(gvk schema.GroupVersionKind) (gatedKind, bool) {
+	for _, kind := range gatedKinds {
+		if kind.gvk.GroupKind() == gvk.GroupKind() {
+			return kind, true
+		}
+	}
+	return gatedKind{}, false
+}
+
+// requiredReadiness returns the name of the Readiness that the object requires
+func requiredReadiness(obj *unstructured.Unstructured) string {
+	return obj.GetAnnotations()[RequiresAnnotation]
+}
+
+// isHeld returns true if the object is held
+func isHeld(obj *unstructured.Unstructured) bool {
+	_, ok := obj.GetLabels()[HeldByLabel]
+	return ok
+}
+
+// hold sets the field of the object to the held value and keeps the current value in an annotation.
+// It returns false if the object was already held.
+func (k gatedKind) hold(obj *unstructured.Unstructured) (bool, error) {
+	current, found, err := unstructured.NestedFieldCopy(obj.Object, k.fields...)
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		current = k.defaultValue
+	}
+
+	if isHeld(obj) && reflect.DeepEqual(current, k.heldValue) {
+		return false, nil
+	}
+
+	// The value is only kept when the workload is not held yet or when it was changed while held,
+	// so that releasing the workload restores the latest value requested for it
+	value, err := json.Marshal(current)
+	if err != nil {
+		return false, err
+	}
+	if err := unstructured.SetNestedField(obj.Object, k.heldValue, k.fields...); err != nil {
+		return false, err
+	}
+
+	setAnnotation(obj, HeldValueAnnotation, string(value))
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[HeldByLabel] = requiredReadiness(obj)
+	obj.SetLabels(labels)
+	return true, nil
+}
+
+// release restores the value of the field that was kept when the object was held
+func (k gatedKind) release(obj *unstructured.Unstructured) error {
+	value := k.defaultValue
+	if held, ok := obj.GetAnnotations()[HeldValueAnnotation]; ok {
+		if err := json.Unmarshal([]byte(held), &value); err != nil {
+			return fmt.Errorf("unable to parse annotation %s: %w", HeldValueAnnotation, err)
+		}
+	}
+
+	// JSON numbers are decoded as float64, which unstructured objects do not support
+	if f, ok := value.(float64); ok {
+		value = int64(f)
+	}
+	if err := unstructured.SetNestedField(obj.Object, value, k.fields...); err != nil {
+		return err
+	}
+
+	annotations := obj.GetAnnotations()
+	delete(annotations, HeldValueAnnotation)
+	obj.SetAnnotations(annotations)
+	labels := obj.GetLabels()
+	delete(labels, HeldByLabel)
+	obj.SetLabels(labels)
+	return nil
+}
+
+func setAnnotation(obj *unstructured.Unstructured, key, value string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[key] = value
+	obj.SetAnnotations(annotations)
+}
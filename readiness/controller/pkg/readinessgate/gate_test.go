@@ -0,0 +1,136 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readinessgate
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func gatedObject(kind gatedKind, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetGroupVersionKind(kind.gvk)
+	obj.SetNamespace("default")
+	obj.SetName("workload")
+	obj.SetAnnotations(map[string]string{RequiresAnnotation: "baseline"})
+	return obj
+}
+
+func TestHoldAndRelease(t *testing.T) {
+	testCases := []struct {
+		description string
+		kind        string
+		spec        map[string]interface{}
+		field       string
+		heldValue   interface{}
+		wantValue   interface{}
+	}{
+		{
+			description: "Deployment is scaled to zero",
+			kind:        "Deployment",
+			spec:        map[string]interface{}{"replicas": int64(3)},
+			field:       "replicas",
+			heldValue:   int64(0),
+			wantValue:   int64(3),
+		},
+		{
+			description: "Deployment without replicas is released with the default",
+			kind:        "Deployment",
+			spec:        map[string]interface{}{},
+			field:       "replicas",
+			heldValue:   int64(0),
+			wantValue:   int64(1),
+		},
+		{
+			description: "Job is suspended",
+			kind:        "Job",
+			spec:        map[string]interface{}{},
+			field:       "suspend",
+			heldValue:   true,
+			wantValue:   false,
+		},
+		{
+			description: "Suspended Job stays suspended",
+			kind:        "Job",
+			spec:        map[string]interface{}{"suspend": true},
+			field:       "suspend",
+			heldValue:   true,
+			wantValue:   true,
+		},
+		{
+			description: "PackageInstall is paused",
+			kind:        "PackageInstall",
+			spec:        map[string]interface{}{"paused": false},
+			field:       "paused",
+			heldValue:   true,
+			wantValue:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var kind gatedKind
+			for _, k := range gatedKinds {
+				if k.gvk.Kind == tc.kind {
+					kind = k
+				}
+			}
+			obj := gatedObject(kind, tc.spec)
+
+			held, err := kind.hold(obj)
+			if err != nil || !held {
+				t.Fatalf("expected the object to be held, got %t, %v", held, err)
+			}
+			if value := obj.Object["spec"].(map[string]interface{})[tc.field]; !reflect.DeepEqual(value, tc.heldValue) {
+				t.Errorf("expected held value %v, got %v", tc.heldValue, value)
+			}
+			if obj.GetLabels()[HeldByLabel] != "baseline" {
+				t.Errorf("expected the object to be labeled with the readiness name, got %v", obj.GetLabels())
+			}
+
+			if held, _ := kind.hold(obj); held {
+				t.Errorf("expected an object that is already held not to be held again")
+			}
+
+			if err := kind.release(obj); err != nil {
+				t.Fatal(err)
+			}
+			if value := obj.Object["spec"].(map[string]interface{})[tc.field]; !reflect.DeepEqual(value, tc.wantValue) {
+				t.Errorf("expected released value %v, got %v", tc.wantValue, value)
+			}
+			if isHeld(obj) {
+				t.Errorf("expected the object not to be held after it is released")
+			}
+			if _, ok := obj.GetAnnotations()[HeldValueAnnotation]; ok {
+				t.Errorf("expected the held value annotation to be removed")
+			}
+		})
+	}
+}
+
+func TestHoldKeepsValueChangedWhileHeld(t *testing.T) {
+	kind, _ := gatedKindFor(gatedKinds[0].gvk)
+	obj := gatedObject(kind, map[string]interface{}{"replicas": int64(2)})
+	if _, err := kind.hold(obj); err != nil {
+		t.Fatal(err)
+	}
+
+	// An update of the workload while it is held requests a new number of replicas
+	if err := unstructured.SetNestedField(obj.Object, int64(5), "spec", "replicas"); err != nil {
+		t.Fatal(err)
+	}
+	held, err := kind.hold(obj)
+	if err != nil || !held {
+		t.Fatalf("expected the object to be held again, got %t, %v", held, err)
+	}
+
+	if err := kind.release(obj); err != nil {
+		t.Fatal(err)
+	}
+	if replicas, _, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas"); replicas != 5 {
+		t.Errorf("expected 5 replicas, got %d", replicas)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readinessgate
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+const releasedReason = "ReadinessGateReleased"
+
+// ReadinessGateReconciler releases the workloads held by a Readiness once it is ready
+type ReadinessGateReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=core.tanzu.vmware.com,resources=readinesses,verbs=get;list;watch
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups=packaging.carvel.dev,resources=packageinstalls,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile releases the workloads that are held by the Readiness when it is ready
+func (r *ReadinessGateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("readiness", req.Name)
+
+	// Workloads stay held while the Readiness that they require does not exist
+	readiness := &corev1alpha2.Readiness{}
+	if err := r.Client.Get(ctx, req.NamespacedName, readiness); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !readiness.Status.Ready {
+		return ctrl.Result{}, nil
+	}
+
+	for _, kind := range gatedKinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(kind.gvk.GroupVersion().WithKind(kind.gvk.Kind + "List"))
+		err := r.Client.List(ctx, list, client.MatchingLabels{HeldByLabel: readiness.Name})
+		if meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if err := kind.release(obj); err != nil {
+				return ctrl.Result{}, err
+			}
+			if err := r.Client.Update(ctx, obj); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			log.Info("released workload", "kind", kind.gvk.Kind, "namespace", obj.GetNamespace(), "name", obj.GetName())
+			r.Recorder.Eventf(obj, corev1.EventTypeNormal, releasedReason, "Readiness %s is ready", readiness.Name)
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// Held workloads of the kinds that are served when the controller starts are watched,
+// so that they are released even when they are held right before the Readiness becomes ready.
+func (r *ReadinessGateReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named("readinessgate").
+		For(&corev1alpha2.Readiness{})
+
+	isHeldObject := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		_, ok := obj.GetLabels()[HeldByLabel]
+		return ok
+	})
+
+	for _, kind := range gatedKinds {
+		if _, err := mgr.GetRESTMapper().RESTMapping(kind.gvk.GroupKind(), kind.gvk.Version); err != nil {
+			if meta.IsNoMatchError(err) {
+				r.Log.Info("not watching held workloads of a kind that is not served", "kind", kind.gvk)
+				continue
+			}
+			return err
+		}
+
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(kind.gvk)
+		b = b.Watches(&source.Kind{Type: obj}, handler.EnqueueRequestsFromMapFunc(heldByReadiness), builder.OnlyMetadata, builder.WithPredicates(isHeldObject))
+	}
+
+	return b.Complete(r)
+}
+
+// heldByReadiness returns the reconcile request for the Readiness that holds the object
+func heldByReadiness(obj client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: obj.GetLabels()[HeldByLabel]}}}
+}
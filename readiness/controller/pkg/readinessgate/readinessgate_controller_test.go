@@ -0,0 +1,101 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readinessgate
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+func testScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := corev1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return scheme
+}
+
+func heldDeployment(name, readiness string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        name,
+			Labels:      map[string]string{HeldByLabel: readiness},
+			Annotations: map[string]string{RequiresAnnotation: readiness, HeldValueAnnotation: "3"},
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: pointer.Int32(0)},
+	}
+}
+
+func TestReconcileReleasesHeldWorkloads(t *testing.T) {
+	testCases := []struct {
+		description  string
+		ready        bool
+		wantReplicas int32
+	}{
+		{
+			description:  "Workloads are released when the readiness is ready",
+			ready:        true,
+			wantReplicas: 3,
+		},
+		{
+			description:  "Workloads stay held while the readiness is not ready",
+			ready:        false,
+			wantReplicas: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			readiness := &corev1alpha2.Readiness{
+				ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+				Status:     corev1alpha2.ReadinessStatus{Ready: tc.ready},
+			}
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(testScheme(t)).
+				WithObjects(readiness, heldDeployment("app", "baseline"), heldDeployment("other", "other")).
+				Build()
+
+			r := &ReadinessGateReconciler{Client: k8sClient, Log: ctrl.Log, Recorder: record.NewFakeRecorder(10)}
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "baseline"}}); err != nil {
+				t.Fatal(err)
+			}
+
+			deployment := &appsv1.Deployment{}
+			if err := k8sClient.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "app"}, deployment); err != nil {
+				t.Fatal(err)
+			}
+			if *deployment.Spec.Replicas != tc.wantReplicas {
+				t.Errorf("expected %d replicas, got %d", tc.wantReplicas, *deployment.Spec.Replicas)
+			}
+			if _, held := deployment.Labels[HeldByLabel]; held == tc.ready {
+				t.Errorf("expected the deployment to be held: %t, got labels %v", !tc.ready, deployment.Labels)
+			}
+
+			other := &appsv1.Deployment{}
+			if err := k8sClient.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "other"}, other); err != nil {
+				t.Fatal(err)
+			}
+			if *other.Spec.Replicas != 0 {
+				t.Errorf("expected the deployment held by another readiness to stay held")
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readinessgate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// WebhookPath is the path at which the readiness gate webhook is served
+const WebhookPath = "/mutate-readiness-gate"
+
+// Webhook holds the workloads that require a Readiness which is not ready when they are created, or when the
+// requirement is added to them
+type Webhook struct {
+	Client client.Client
+	Log    logr.Logger
+}
+
+var _ admission.Handler = &Webhook{}
+
+// Handle implements admission.Handler
+func (w *Webhook) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	kind, ok := gatedKindFor(schema.GroupVersionKind{Group: req.Kind.Group, Version: req.Kind.Version, Kind: req.Kind.Kind})
+	if !ok {
+		return admission.Allowed("")
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(req.Object.Raw); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	name := requiredReadiness(obj)
+	if name == "" {
+		return admission.Allowed("")
+	}
+	if errs := validation.IsValidLabelValue(name); len(errs) != 0 {
+		return admission.Denied(fmt.Sprintf("invalid value for annotation %s: %s", RequiresAnnotation, strings.Join(errs, "; ")))
+	}
+
+	// Workloads are only held until the Readiness is ready for the first time. A workload that already required the
+	// Readiness and is not held was released or started while the Readiness was ready, so it is not held again.
+	if req.Operation == admissionv1.Update {
+		oldObj := &unstructured.Unstructured{}
+		if err := oldObj.UnmarshalJSON(req.OldObject.Raw); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if requiredReadiness(oldObj) == name && !isHeld(oldObj) {
+			return admission.Allowed("")
+		}
+	}
+
+	ready, err := w.isReady(ctx, name)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if ready {
+		return admission.Allowed("")
+	}
+
+	held, err := kind.hold(obj)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if !held {
+		return admission.Allowed("")
+	}
+
+	w.Log.Info("holding workload until readiness is ready", "kind", kind.gvk.Kind, "namespace", req.Namespace, "name", obj.GetName(), "readiness", name)
+	marshaled, err := obj.MarshalJSON()
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+// isReady returns true if the Readiness exists and is ready
+func (w *Webhook) isReady(ctx context.Context, name string) (bool, error) {
+	readiness := &corev1alpha2.Readiness{}
+	if err := w.Client.Get(ctx, client.ObjectKey{Name: name}, readiness); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return readiness.Status.Ready, nil
+}
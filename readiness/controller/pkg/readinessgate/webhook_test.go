@@ -0,0 +1,157 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readinessgate
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+func rawJob(t *testing.T, annotations, labels map[string]string) []byte {
+	job := &batchv1.Job{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "batch/v1", Kind: "Job"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "job", Annotations: annotations, Labels: labels},
+	}
+	raw, err := json.Marshal(job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func jobRequest(t *testing.T, annotations map[string]string) admission.Request {
+	return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: admissionv1.Create,
+		Kind:      metav1.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"},
+		Namespace: "default",
+		Object:    runtime.RawExtension{Raw: rawJob(t, annotations, nil)},
+	}}
+}
+
+func jobUpdateRequest(t *testing.T, oldAnnotations, oldLabels, annotations map[string]string) admission.Request {
+	return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: admissionv1.Update,
+		Kind:      metav1.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"},
+		Namespace: "default",
+		Object:    runtime.RawExtension{Raw: rawJob(t, annotations, nil)},
+		OldObject: runtime.RawExtension{Raw: rawJob(t, oldAnnotations, oldLabels)},
+	}}
+}
+
+func TestWebhookHoldsWorkloads(t *testing.T) {
+	ready := &corev1alpha2.Readiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "ready"},
+		Status:     corev1alpha2.ReadinessStatus{Ready: true},
+	}
+	notReady := &corev1alpha2.Readiness{ObjectMeta: metav1.ObjectMeta{Name: "not-ready"}}
+	w := &Webhook{
+		Client: fake.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(ready, notReady).Build(),
+		Log:    ctrl.Log,
+	}
+
+	testCases := []struct {
+		description string
+		annotations map[string]string
+		wantAllowed bool
+		wantPatches bool
+	}{
+		{
+			description: "Workload without the annotation is not held",
+			wantAllowed: true,
+		},
+		{
+			description: "Workload requiring a ready readiness is not held",
+			annotations: map[string]string{RequiresAnnotation: "ready"},
+			wantAllowed: true,
+		},
+		{
+			description: "Workload requiring a readiness that is not ready is held",
+			annotations: map[string]string{RequiresAnnotation: "not-ready"},
+			wantAllowed: true,
+			wantPatches: true,
+		},
+		{
+			description: "Workload requiring a readiness that does not exist is held",
+			annotations: map[string]string{RequiresAnnotation: "missing"},
+			wantAllowed: true,
+			wantPatches: true,
+		},
+		{
+			description: "Workload requiring a readiness whose name is not a valid label value is denied",
+			annotations: map[string]string{RequiresAnnotation: "invalid/name"},
+			wantAllowed: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			resp := w.Handle(context.Background(), jobRequest(t, tc.annotations))
+			if resp.Allowed != tc.wantAllowed {
+				t.Errorf("expected allowed %t, got %t: %v", tc.wantAllowed, resp.Allowed, resp.Result)
+			}
+			if (len(resp.Patches) != 0) != tc.wantPatches {
+				t.Errorf("expected patches %t, got %v", tc.wantPatches, resp.Patches)
+			}
+		})
+	}
+}
+
+func TestWebhookHoldsUpdatedWorkloads(t *testing.T) {
+	notReady := &corev1alpha2.Readiness{ObjectMeta: metav1.ObjectMeta{Name: "not-ready"}}
+	w := &Webhook{
+		Client: fake.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(notReady).Build(),
+		Log:    ctrl.Log,
+	}
+	requires := map[string]string{RequiresAnnotation: "not-ready"}
+	heldValue := map[string]string{RequiresAnnotation: "not-ready", HeldValueAnnotation: "false"}
+
+	testCases := []struct {
+		description string
+		req         admission.Request
+		wantPatches bool
+	}{
+		{
+			description: "Released workload is not held again",
+			req:         jobUpdateRequest(t, requires, nil, requires),
+		},
+		{
+			description: "Held workload stays held when the update drops the held field",
+			req:         jobUpdateRequest(t, heldValue, map[string]string{HeldByLabel: "not-ready"}, requires),
+			wantPatches: true,
+		},
+		{
+			description: "Workload is held when the requirement is added",
+			req:         jobUpdateRequest(t, nil, nil, requires),
+			wantPatches: true,
+		},
+		{
+			description: "Workload is held when it requires another readiness",
+			req:         jobUpdateRequest(t, map[string]string{RequiresAnnotation: "ready"}, nil, requires),
+			wantPatches: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			resp := w.Handle(context.Background(), tc.req)
+			if !resp.Allowed {
+				t.Errorf("expected the update to be allowed: %v", resp.Result)
+			}
+			if (len(resp.Patches) != 0) != tc.wantPatches {
+				t.Errorf("expected patches %t, got %v", tc.wantPatches, resp.Patches)
+			}
+		})
+	}
+}
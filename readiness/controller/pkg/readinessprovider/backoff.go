@@ -0,0 +1,49 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readinessprovider
+
+import (
+	"sync"
+	"time"
+)
+
+const initialFailureBackoff = 5 * time.Second
+
+// failureBackoff computes the requeue interval of providers that are not successful,
+// doubling it with every consecutive unsuccessful evaluation
+type failureBackoff struct {
+	lock     sync.Mutex
+	failures map[string]int
+}
+
+func newFailureBackoff() *failureBackoff {
+	return &failureBackoff{
+		failures: make(map[string]int),
+	}
+}
+
+// next records an unsuccessful evaluation of the provider and returns the interval after which it should be retried
+func (b *failureBackoff) next(providerName string, maxInterval time.Duration) time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	interval := initialFailureBackoff
+	for i := 0; i < b.failures[providerName] && interval < maxInterval; i++ {
+		interval *= 2
+	}
+	b.failures[providerName]++
+
+	if interval > maxInterval {
+		return maxInterval
+	}
+	return interval
+}
+
+// reset forgets the unsuccessful evaluations of the provider
+func (b *failureBackoff) reset(providerName string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	delete(b.failures, providerName)
+}
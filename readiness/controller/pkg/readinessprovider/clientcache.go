@@ -0,0 +1,234 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readinessprovider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/oauth2"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/transport"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/vmware-tanzu/tanzu-framework/readiness/controller/pkg/conditions"
+)
+
+// tokenRefreshRatio is the fraction of the lifetime of a token after which it is refreshed
+const tokenRefreshRatio = 0.8
+
+var (
+	clientCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "readinessprovider_client_cache_requests_total",
+		Help: "Number of lookups of service account clients, by result (hit or miss).",
+	}, []string{"result"})
+
+	clientCacheEntries = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "readinessprovider_client_cache_entries",
+		Help: "Number of service accounts with cached clients.",
+	})
+
+	tokenRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "readinessprovider_service_account_token_requests_total",
+		Help: "Number of tokens requested for service accounts, by result (success or error).",
+	}, []string{"result"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(clientCacheRequests, clientCacheEntries, tokenRequests)
+}
+
+// clientCache holds the clients for evaluating conditions with each service account.
+// The clients, and their discovery caches, are reused across evaluations; only the token is refreshed.
+// The clients of a service account are evicted once no provider uses it.
+type clientCache struct {
+	lock    sync.Mutex
+	entries map[types.NamespacedName]*conditions.Clients
+
+	// users maps each service account to the names of the providers that use its clients
+	users map[types.NamespacedName]map[string]struct{}
+	// accounts maps each provider name to the service account whose clients it uses
+	accounts map[string]types.NamespacedName
+
+	clientset kubernetes.Interface
+	config    *rest.Config
+}
+
+func newClientCache(clientset kubernetes.Interface, config *rest.Config) *clientCache {
+	return &clientCache{
+		entries:   make(map[types.NamespacedName]*conditions.Clients),
+		users:     make(map[types.NamespacedName]map[string]struct{}),
+		accounts:  make(map[string]types.NamespacedName),
+		clientset: clientset,
+		config:    config,
+	}
+}
+
+// get returns the clients of the service account used by the provider, creating them if they are not cached.
+// The clients of the service account that the provider used before are evicted if no other provider uses them.
+// The clients are created without holding the lock, so that requesting a token does not block the lookups of other
+// service accounts.
+func (c *clientCache) get(ctx context.Context, providerName, namespace, name string) (*conditions.Clients, error) {
+	key := types.NamespacedName{Namespace: namespace, Name: name}
+
+	if clients, ok := c.lookup(providerName, key); ok {
+		clientCacheRequests.WithLabelValues("hit").Inc()
+		return clients, nil
+	}
+	clientCacheRequests.WithLabelValues("miss").Inc()
+
+	clients, err := c.newClients(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// The clients may have been created concurrently for another provider using the same service account
+	if cached, ok := c.entries[key]; ok {
+		clients = cached
+	} else {
+		c.entries[key] = clients
+		clientCacheEntries.Set(float64(len(c.entries)))
+	}
+	if previous, ok := c.accounts[providerName]; ok && previous != key {
+		c.releaseLocked(providerName)
+	}
+	c.useLocked(providerName, key)
+	return clients, nil
+}
+
+// lookup returns the cached clients of the service account, and records that the provider uses them. The clients of
+// the service account that the provider used before are evicted if no other provider uses them.
+func (c *clientCache) lookup(providerName string, key types.NamespacedName) (*conditions.Clients, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if previous, ok := c.accounts[providerName]; ok && previous != key {
+		c.releaseLocked(providerName)
+	}
+
+	clients, ok := c.entries[key]
+	if ok {
+		c.useLocked(providerName, key)
+	}
+	return clients, ok
+}
+
+// newClients creates the clients of the service account
+func (c *clientCache) newClients(ctx context.Context, namespace, name string) (*conditions.Clients, error) {
+	// The first token is requested right away, so that a service account that cannot be used is reported immediately
+	tokenSource := newServiceAccountTokenSource(c.clientset, namespace, name)
+	if _, err := tokenSource.token(ctx); err != nil {
+		return nil, err
+	}
+
+	cfg := &rest.Config{
+		Host:            c.config.Host,
+		TLSClientConfig: c.config.TLSClientConfig,
+		WrapTransport:   transport.TokenSourceWrapTransport(tokenSource),
+	}
+	return conditions.NewClientsForConfig(cfg)
+}
+
+// release records that the provider no longer uses a service account, and evicts the clients of the service account
+// if no other provider uses them
+func (c *clientCache) release(providerName string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.releaseLocked(providerName)
+}
+
+func (c *clientCache) useLocked(providerName string, key types.NamespacedName) {
+	if _, ok := c.users[key]; !ok {
+		c.users[key] = make(map[string]struct{})
+	}
+	c.users[key][providerName] = struct{}{}
+	c.accounts[providerName] = key
+}
+
+func (c *clientCache) releaseLocked(providerName string) {
+	key, ok := c.accounts[providerName]
+	if !ok {
+		return
+	}
+	delete(c.accounts, providerName)
+	delete(c.users[key], providerName)
+	if len(c.users[key]) == 0 {
+		delete(c.users, key)
+		delete(c.entries, key)
+		clientCacheEntries.Set(float64(len(c.entries)))
+	}
+}
+
+// evict removes the clients of the service account
+func (c *clientCache) evict(namespace, name string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.entries, types.NamespacedName{Namespace: namespace, Name: name})
+	clientCacheEntries.Set(float64(len(c.entries)))
+}
+
+// serviceAccountTokenSource requests tokens for a service account with the TokenRequest API.
+// A token is refreshed once tokenRefreshRatio of its lifetime has elapsed, before it expires. The returned tokens
+// expire at that time, so that token sources that cache them until their expiry also refresh them early.
+type serviceAccountTokenSource struct {
+	lock      sync.Mutex
+	clientset kubernetes.Interface
+	namespace string
+	name      string
+
+	accessToken string
+	refreshAt   time.Time
+
+	now func() time.Time
+}
+
+var _ oauth2.TokenSource = &serviceAccountTokenSource{}
+
+func newServiceAccountTokenSource(clientset kubernetes.Interface, namespace, name string) *serviceAccountTokenSource {
+	return &serviceAccountTokenSource{
+		clientset: clientset,
+		namespace: namespace,
+		name:      name,
+		now:       time.Now,
+	}
+}
+
+// Token implements oauth2.TokenSource
+func (s *serviceAccountTokenSource) Token() (*oauth2.Token, error) {
+	return s.token(context.Background())
+}
+
+func (s *serviceAccountTokenSource) token(ctx context.Context) (*oauth2.Token, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.now()
+	if s.accessToken != "" && now.Before(s.refreshAt) {
+		return &oauth2.Token{AccessToken: s.accessToken, TokenType: "Bearer", Expiry: s.refreshAt}, nil
+	}
+
+	treq, err := s.clientset.CoreV1().ServiceAccounts(s.namespace).CreateToken(ctx, s.name, &authenticationv1.TokenRequest{}, metav1.CreateOptions{})
+	if err != nil {
+		tokenRequests.WithLabelValues("error").Inc()
+		return nil, fmt.Errorf("failed to retrieve token from service account. %s", err.Error())
+	}
+	tokenRequests.WithLabelValues("success").Inc()
+
+	s.accessToken = treq.Status.Token
+	lifetime := treq.Status.ExpirationTimestamp.Time.Sub(now)
+	s.refreshAt = now.Add(time.Duration(float64(lifetime) * tokenRefreshRatio))
+	return &oauth2.Token{AccessToken: s.accessToken, TokenType: "Bearer", Expiry: s.refreshAt}, nil
+}
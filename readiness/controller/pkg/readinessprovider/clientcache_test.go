@@ -0,0 +1,240 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readinessprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/transport"
+)
+
+// fakeTokenClientset returns a clientset that issues tokens valid for an hour from the given time,
+// counting the tokens that it issues
+func fakeTokenClientset(now func() time.Time, issued *int) *fake.Clientset {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		if action.GetNamespace() != "default" {
+			return true, nil, errors.New("service account not found")
+		}
+		*issued++
+		return true, &authenticationv1.TokenRequest{Status: authenticationv1.TokenRequestStatus{
+			Token:               fmt.Sprintf("token-%d", *issued),
+			ExpirationTimestamp: metav1.NewTime(now().Add(time.Hour)),
+		}}, nil
+	})
+	return clientset
+}
+
+func TestServiceAccountTokenSource(t *testing.T) {
+	now := time.Now()
+	clock := func() time.Time { return now }
+	issued := 0
+
+	tokenSource := newServiceAccountTokenSource(fakeTokenClientset(clock, &issued), "default", "sa")
+	tokenSource.now = clock
+
+	token, err := tokenSource.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "token-1" {
+		t.Errorf("expected token-1, got %s", token.AccessToken)
+	}
+	// The token is reported to expire when it is refreshed, so that caching token sources refresh it too
+	if want := now.Add(48 * time.Minute); !token.Expiry.Equal(want) {
+		t.Errorf("expected the token to expire at %s, got %s", want, token.Expiry)
+	}
+
+	// The token is reused until most of its lifetime has elapsed
+	now = now.Add(40 * time.Minute)
+	if token, _ = tokenSource.Token(); token.AccessToken != "token-1" {
+		t.Errorf("expected token-1 to be reused, got %s", token.AccessToken)
+	}
+
+	// The token is refreshed before it expires
+	now = now.Add(10 * time.Minute)
+	if token, _ = tokenSource.Token(); token.AccessToken != "token-2" {
+		t.Errorf("expected token-2 to be requested, got %s", token.AccessToken)
+	}
+	if !token.Expiry.After(now) {
+		t.Errorf("expected the refreshed token to expire after %s, got %s", now, token.Expiry)
+	}
+	if issued != 2 {
+		t.Errorf("expected 2 tokens to be issued, got %d", issued)
+	}
+}
+
+func TestServiceAccountTokenTransport(t *testing.T) {
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	clock := func() time.Time { return now }
+	issued := 0
+	tokenSource := newServiceAccountTokenSource(fakeTokenClientset(clock, &issued), "default", "sa")
+	tokenSource.now = clock
+
+	httpClient := &http.Client{Transport: transport.TokenSourceWrapTransport(tokenSource)(http.DefaultTransport)}
+	get := func() {
+		resp, err := httpClient.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	get()
+	now = now.Add(40 * time.Minute)
+	get()
+	now = now.Add(9 * time.Minute)
+	get()
+
+	want := []string{"Bearer token-1", "Bearer token-1", "Bearer token-2"}
+	if len(authorizations) != len(want) {
+		t.Fatalf("expected %d requests, got %v", len(want), authorizations)
+	}
+	for i := range want {
+		if authorizations[i] != want[i] {
+			t.Errorf("request %d: expected %q, got %q", i, want[i], authorizations[i])
+		}
+	}
+}
+
+func TestClientCacheEvictsUnusedServiceAccounts(t *testing.T) {
+	issued := 0
+	cache := newClientCache(fakeTokenClientset(time.Now, &issued), &rest.Config{Host: "https://localhost:6443"})
+	sa := types.NamespacedName{Namespace: "default", Name: "sa"}
+	other := types.NamespacedName{Namespace: "default", Name: "other"}
+
+	for _, provider := range []string{"provider1", "provider2"} {
+		if _, err := cache.get(context.Background(), provider, sa.Namespace, sa.Name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// provider1 switches to another service account, which is still used by provider2
+	if _, err := cache.get(context.Background(), "provider1", other.Namespace, other.Name); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.entries[sa]; !ok {
+		t.Errorf("expected the clients of %s to be kept while provider2 uses them", sa)
+	}
+
+	cache.release("provider2")
+	if _, ok := cache.entries[sa]; ok {
+		t.Errorf("expected the clients of %s to be evicted once no provider uses them", sa)
+	}
+	if _, ok := cache.entries[other]; !ok {
+		t.Errorf("expected the clients of %s to be kept", other)
+	}
+
+	cache.release("provider1")
+	if len(cache.entries) != 0 || len(cache.users) != 0 || len(cache.accounts) != 0 {
+		t.Errorf("expected the cache to be empty, got %d entries", len(cache.entries))
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	issued := 0
+	cache := newClientCache(fakeTokenClientset(time.Now, &issued), &rest.Config{Host: "https://localhost:6443"})
+
+	clients, err := cache.get(context.Background(), "provider", "default", "sa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cached, err := cache.get(context.Background(), "provider", "default", "sa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clients != cached {
+		t.Errorf("expected the clients to be cached")
+	}
+	if issued != 1 {
+		t.Errorf("expected 1 token to be issued, got %d", issued)
+	}
+
+	cache.evict("default", "sa")
+	evicted, err := cache.get(context.Background(), "provider", "default", "sa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if evicted == clients {
+		t.Errorf("expected new clients after the service account is evicted")
+	}
+
+	if _, err := cache.get(context.Background(), "provider", "other", "sa"); err == nil {
+		t.Errorf("expected an error for a service account whose token cannot be requested")
+	}
+	if _, ok := cache.entries[types.NamespacedName{Namespace: "other", Name: "sa"}]; ok {
+		t.Errorf("expected clients not to be cached when the token cannot be requested")
+	}
+}
+
+func TestClientCacheDoesNotBlockOnTokenRequests(t *testing.T) {
+	issued := 0
+	clientset := fakeTokenClientset(time.Now, &issued)
+	requested, unblock := make(chan struct{}), make(chan struct{})
+	clientset.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.(k8stesting.CreateActionImpl).Name == "slow" {
+			close(requested)
+			<-unblock
+		}
+		return false, nil, nil
+	})
+	cache := newClientCache(clientset, &rest.Config{Host: "https://localhost:6443"})
+
+	clients, err := cache.get(context.Background(), "provider1", "default", "sa")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slow := make(chan error)
+	go func() {
+		_, err := cache.get(context.Background(), "provider2", "default", "slow")
+		slow <- err
+	}()
+	<-requested
+
+	// The token of the slow service account is being requested, which does not block the cached service accounts
+	done := make(chan struct{})
+	go func() {
+		cached, err := cache.get(context.Background(), "provider1", "default", "sa")
+		if err != nil || cached != clients {
+			t.Errorf("expected the cached clients, got error %v", err)
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Errorf("expected the lookup of a cached service account not to wait for a token request")
+	}
+
+	close(unblock)
+	if err := <-slow; err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.entries[types.NamespacedName{Namespace: "default", Name: "slow"}]; !ok {
+		t.Errorf("expected the clients of the slow service account to be cached")
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readinessprovider
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// applyProgressDeadline maps the evaluated state of a condition to the state reported for it, given its progress
+// deadline and its previous status. It also returns the time left before the deadline expires, or zero if there is
+// no deadline pending.
+func applyProgressDeadline(condition *corev1alpha2.ReadinessProviderCondition, previous *corev1alpha2.ReadinessConditionStatus,
+	status *corev1alpha2.ReadinessConditionStatus, now metav1.Time) time.Duration {
+	if condition.ProgressDeadlineSeconds == nil || status.State == corev1alpha2.ConditionSuccessState {
+		return 0
+	}
+
+	deadline := time.Duration(*condition.ProgressDeadlineSeconds) * time.Second
+
+	// The deadline started when the condition first reported inprogress
+	start := now
+	if previous != nil && previous.LastTransitionTime != nil {
+		switch {
+		case previous.State == corev1alpha2.ConditionInProgressState:
+			start = *previous.LastTransitionTime
+		case previous.State == corev1alpha2.ConditionFailureState && previous.Reason == corev1alpha2.ProgressDeadlineExceededReason:
+			start = metav1.NewTime(now.Add(-deadline))
+		}
+	}
+
+	elapsed := now.Sub(start.Time)
+	if elapsed >= deadline {
+		status.State = corev1alpha2.ConditionFailureState
+		status.Reason = corev1alpha2.ProgressDeadlineExceededReason
+		status.Message = fmt.Sprintf("progress deadline of %s exceeded: %s", deadline, status.Message)
+		return 0
+	}
+
+	status.State = corev1alpha2.ConditionInProgressState
+	return deadline - elapsed
+}
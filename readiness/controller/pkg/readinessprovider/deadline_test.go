@@ -0,0 +1,96 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readinessprovider
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+func TestApplyProgressDeadline(t *testing.T) {
+	now := metav1.NewTime(time.Date(2023, 1, 1, 0, 10, 0, 0, time.UTC))
+	twoMinutesAgo := metav1.NewTime(now.Add(-2 * time.Minute))
+	sixMinutesAgo := metav1.NewTime(now.Add(-6 * time.Minute))
+	fiveMinutes := int32(300)
+
+	testCases := []struct {
+		description   string
+		deadline      *int32
+		previous      *corev1alpha2.ReadinessConditionStatus
+		state         corev1alpha2.ReadinessConditionState
+		wantState     corev1alpha2.ReadinessConditionState
+		wantReason    string
+		wantRemaining time.Duration
+	}{
+		{
+			description: "No deadline keeps the evaluated state",
+			state:       corev1alpha2.ConditionFailureState,
+			wantState:   corev1alpha2.ConditionFailureState,
+		},
+		{
+			description: "Success is not affected by the deadline",
+			deadline:    &fiveMinutes,
+			state:       corev1alpha2.ConditionSuccessState,
+			wantState:   corev1alpha2.ConditionSuccessState,
+		},
+		{
+			description:   "First failure starts the deadline",
+			deadline:      &fiveMinutes,
+			state:         corev1alpha2.ConditionFailureState,
+			wantState:     corev1alpha2.ConditionInProgressState,
+			wantRemaining: 5 * time.Minute,
+		},
+		{
+			description:   "Failure after success starts the deadline",
+			deadline:      &fiveMinutes,
+			previous:      &corev1alpha2.ReadinessConditionStatus{State: corev1alpha2.ConditionSuccessState, LastTransitionTime: &sixMinutesAgo},
+			state:         corev1alpha2.ConditionFailureState,
+			wantState:     corev1alpha2.ConditionInProgressState,
+			wantRemaining: 5 * time.Minute,
+		},
+		{
+			description:   "Failure within the deadline is in progress",
+			deadline:      &fiveMinutes,
+			previous:      &corev1alpha2.ReadinessConditionStatus{State: corev1alpha2.ConditionInProgressState, LastTransitionTime: &twoMinutesAgo},
+			state:         corev1alpha2.ConditionFailureState,
+			wantState:     corev1alpha2.ConditionInProgressState,
+			wantRemaining: 3 * time.Minute,
+		},
+		{
+			description: "Failure after the deadline is reported",
+			deadline:    &fiveMinutes,
+			previous:    &corev1alpha2.ReadinessConditionStatus{State: corev1alpha2.ConditionInProgressState, LastTransitionTime: &sixMinutesAgo},
+			state:       corev1alpha2.ConditionInProgressState,
+			wantState:   corev1alpha2.ConditionFailureState,
+			wantReason:  corev1alpha2.ProgressDeadlineExceededReason,
+		},
+		{
+			description: "Exceeded deadline stays exceeded",
+			deadline:    &fiveMinutes,
+			previous: &corev1alpha2.ReadinessConditionStatus{State: corev1alpha2.ConditionFailureState, Reason: corev1alpha2.ProgressDeadlineExceededReason,
+				LastTransitionTime: &twoMinutesAgo},
+			state:      corev1alpha2.ConditionFailureState,
+			wantState:  corev1alpha2.ConditionFailureState,
+			wantReason: corev1alpha2.ProgressDeadlineExceededReason,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			condition := &corev1alpha2.ReadinessProviderCondition{Name: "condition", ProgressDeadlineSeconds: tc.deadline}
+			status := &corev1alpha2.ReadinessConditionStatus{Name: "condition", State: tc.state, Message: "message"}
+
+			remaining := applyProgressDeadline(condition, tc.previous, status, now)
+
+			if status.State != tc.wantState || status.Reason != tc.wantReason || remaining != tc.wantRemaining {
+				t.Errorf("got state %s, reason %q and remaining %s; want state %s, reason %q and remaining %s",
+					status.State, status.Reason, remaining, tc.wantState, tc.wantReason, tc.wantRemaining)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readinessprovider
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/readiness/controller/pkg/conditions"
+)
+
+func TestEvaluateProviderOffline(t *testing.T) {
+	configMap := &unstructured.Unstructured{}
+	configMap.SetAPIVersion("v1")
+	configMap.SetKind("ConfigMap")
+	configMap.SetNamespace("default")
+	configMap.SetName("settings")
+
+	clients, err := conditions.NewClientsForObjects(configMap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	namespace := "default"
+	existence := func(name, kind, resourceName string) corev1alpha2.ReadinessProviderCondition {
+		return corev1alpha2.ReadinessProviderCondition{
+			Name: name,
+			ResourceExistenceCondition: &corev1alpha2.ResourceExistenceCondition{
+				APIVersion: "v1", Kind: kind, Namespace: &namespace, Name: resourceName,
+			},
+		}
+	}
+
+	testCases := []struct {
+		description    string
+		conditions     []corev1alpha2.ReadinessProviderCondition
+		wantState      corev1alpha2.ReadinessProviderState
+		wantConditions []corev1alpha2.ReadinessConditionState
+	}{
+		{
+			description:    "Objects in the bundle",
+			conditions:     []corev1alpha2.ReadinessProviderCondition{existence("settings", "ConfigMap", "settings")},
+			wantState:      corev1alpha2.ProviderSuccessState,
+			wantConditions: []corev1alpha2.ReadinessConditionState{corev1alpha2.ConditionSuccessState},
+		},
+		{
+			description: "Objects and kinds missing from the bundle",
+			conditions: []corev1alpha2.ReadinessProviderCondition{
+				existence("settings", "ConfigMap", "settings"),
+				existence("other", "ConfigMap", "other"),
+				existence("secret", "Secret", "credentials"),
+			},
+			wantState: corev1alpha2.ProviderFailureState,
+			wantConditions: []corev1alpha2.ReadinessConditionState{
+				corev1alpha2.ConditionSuccessState, corev1alpha2.ConditionFailureState, corev1alpha2.ConditionFailureState,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			provider := &corev1alpha2.ReadinessProvider{
+				Spec: corev1alpha2.ReadinessProviderSpec{Conditions: tc.conditions},
+			}
+
+			status, nextDeadline := EvaluateProvider(context.Background(), ctrl.Log, conditions.NewDefaultRegistry(), clients, provider)
+			if status.State != tc.wantState {
+				t.Errorf("expected state %s, got %s: %s", tc.wantState, status.State, status.Message)
+			}
+			if nextDeadline != 0 {
+				t.Errorf("expected no deadline, got %s", nextDeadline)
+			}
+			if len(status.Conditions) != len(tc.wantConditions) {
+				t.Fatalf("expected %d conditions, got %d", len(tc.wantConditions), len(status.Conditions))
+			}
+			for i, want := range tc.wantConditions {
+				if status.Conditions[i].State != want {
+					t.Errorf("expected condition %s to be %s, got %s: %s",
+						status.Conditions[i].Name, want, status.Conditions[i].State, status.Conditions[i].Message)
+				}
+			}
+			if len(provider.Status.Conditions) != 0 {
+				t.Errorf("expected the provider not to be modified")
+			}
+		})
+	}
+}
+
+func TestEvaluateProviderWithoutProgressDeadlines(t *testing.T) {
+	clients, err := conditions.NewClientsForObjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	namespace := "default"
+	deadline := int32(300)
+	provider := &corev1alpha2.ReadinessProvider{
+		Spec: corev1alpha2.ReadinessProviderSpec{Conditions: []corev1alpha2.ReadinessProviderCondition{{
+			Name:                    "settings",
+			ProgressDeadlineSeconds: &deadline,
+			ResourceExistenceCondition: &corev1alpha2.ResourceExistenceCondition{
+				APIVersion: "v1", Kind: "ConfigMap", Namespace: &namespace, Name: "settings",
+			},
+		}}},
+	}
+
+	testCases := []struct {
+		description  string
+		opts         []EvaluateOption
+		wantState    corev1alpha2.ReadinessConditionState
+		wantDeadline bool
+	}{
+		{
+			description:  "Failing condition within its progress deadline",
+			wantState:    corev1alpha2.ConditionInProgressState,
+			wantDeadline: true,
+		},
+		{
+			description: "Failing condition without progress deadlines",
+			opts:        []EvaluateOption{WithoutProgressDeadlines()},
+			wantState:   corev1alpha2.ConditionFailureState,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			status, nextDeadline := EvaluateProvider(context.Background(), ctrl.Log, conditions.NewDefaultRegistry(), clients, provider, tc.opts...)
+			if status.Conditions[0].State != tc.wantState {
+				t.Errorf("expected condition to be %s, got %s: %s", tc.wantState, status.Conditions[0].State, status.Conditions[0].Message)
+			}
+			if (nextDeadline > 0) != tc.wantDeadline {
+				t.Errorf("expected a pending deadline to be %t, got %s", tc.wantDeadline, nextDeadline)
+			}
+		})
+	}
+}
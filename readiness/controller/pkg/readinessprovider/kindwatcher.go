@@ -0,0 +1,122 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readinessprovider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// kindWatcher runs a metadata informer for each watched kind, and calls the handler with every object of the kind
+// that is added, updated or deleted. Unlike watches added to a controller, informers can be stopped once the kind is
+// no longer referenced. It implements manager.Runnable so that the informers are stopped with the manager.
+type kindWatcher struct {
+	lock sync.Mutex
+
+	client  metadata.Interface
+	handler func(groupKind schema.GroupKind, obj metav1.Object)
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// stops maps each watched kind to the function stopping its informer
+	stops map[schema.GroupVersionKind]context.CancelFunc
+}
+
+func newKindWatcher(client metadata.Interface, handler func(schema.GroupKind, metav1.Object)) *kindWatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &kindWatcher{
+		client:  client,
+		handler: handler,
+		ctx:     ctx,
+		cancel:  cancel,
+		stops:   make(map[schema.GroupVersionKind]context.CancelFunc),
+	}
+}
+
+// Start blocks until the context is done, and then stops all informers.
+func (k *kindWatcher) Start(ctx context.Context) error {
+	<-ctx.Done()
+	k.cancel()
+	return nil
+}
+
+// watch starts an informer for the kind, served as the given resource, in all namespaces
+func (k *kindWatcher) watch(gvk schema.GroupVersionKind, gvr schema.GroupVersionResource) error {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if _, ok := k.stops[gvk]; ok {
+		return nil
+	}
+
+	groupKind := gvk.GroupKind()
+	informer := metadatainformer.NewFilteredMetadataInformer(k.client, gvr, metav1.NamespaceAll, 0, cache.Indexers{}, nil).Informer()
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { k.handle(groupKind, obj) },
+		UpdateFunc: func(_, obj interface{}) { k.handle(groupKind, obj) },
+		DeleteFunc: func(obj interface{}) { k.handle(groupKind, obj) },
+	})
+	if err != nil {
+		return fmt.Errorf("unable to add event handler: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(k.ctx)
+	k.stops[gvk] = cancel
+	go informer.Run(ctx.Done())
+	return nil
+}
+
+// unwatch stops the informer of the kind
+func (k *kindWatcher) unwatch(gvk schema.GroupVersionKind) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if stop, ok := k.stops[gvk]; ok {
+		stop()
+		delete(k.stops, gvk)
+	}
+}
+
+func (k *kindWatcher) handle(groupKind schema.GroupKind, obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if o, ok := obj.(metav1.Object); ok {
+		k.handler(groupKind, o)
+	}
+}
+
+// canListAndWatch checks with SelfSubjectAccessReviews whether the controller may list and watch the resource in all
+// namespaces, which its informer needs.
+func canListAndWatch(ctx context.Context, reviews authorizationv1client.SelfSubjectAccessReviewInterface, gvr schema.GroupVersionResource) (bool, error) {
+	for _, verb := range []string{"list", "watch"} {
+		review, err := reviews.Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Verb:     verb,
+					Group:    gvr.Group,
+					Version:  gvr.Version,
+					Resource: gvr.Resource,
+				},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return false, err
+		}
+		if !review.Status.Allowed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
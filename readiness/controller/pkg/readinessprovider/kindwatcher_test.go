@@ -0,0 +1,108 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readinessprovider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCanListAndWatch(t *testing.T) {
+	tests := []struct {
+		description string
+		allowed     map[string]bool
+		want        bool
+	}{
+		{
+			description: "allowed to list and watch",
+			allowed:     map[string]bool{"list": true, "watch": true},
+			want:        true,
+		},
+		{
+			description: "allowed to list but not to watch",
+			allowed:     map[string]bool{"list": true},
+			want:        false,
+		},
+		{
+			description: "not allowed to list",
+			allowed:     map[string]bool{"watch": true},
+			want:        false,
+		},
+	}
+
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+			clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+				attributes := review.Spec.ResourceAttributes
+				if attributes.Group != gvr.Group || attributes.Resource != gvr.Resource || attributes.Namespace != "" {
+					t.Errorf("unexpected resource attributes %+v", attributes)
+				}
+				review.Status.Allowed = tc.allowed[attributes.Verb]
+				return true, review, nil
+			})
+
+			got, err := canListAndWatch(context.Background(), clientset.AuthorizationV1().SelfSubjectAccessReviews(), gvr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestKindWatcher(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+	deployment := &metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"},
+	}
+	scheme := metadatafake.NewTestScheme()
+	if err := metav1.AddMetaToScheme(scheme); err != nil {
+		t.Fatalf("unable to add meta to scheme: %v", err)
+	}
+	client := metadatafake.NewSimpleMetadataClient(scheme, deployment)
+
+	changed := make(chan string, 10)
+	w := newKindWatcher(client, func(groupKind schema.GroupKind, obj metav1.Object) {
+		changed <- groupKind.String() + "/" + obj.GetNamespace() + "/" + obj.GetName()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = w.Start(ctx) }()
+
+	if err := w.watch(gvk, gvr); err != nil {
+		t.Fatalf("unable to watch kind: %v", err)
+	}
+	select {
+	case got := <-changed:
+		if got != "Deployment.apps/default/foo" {
+			t.Errorf("got change for %s", got)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the watched object")
+	}
+
+	w.unwatch(gvk)
+	if len(w.stops) != 0 {
+		t.Errorf("expected the informer to be stopped, got %v", w.stops)
+	}
+}
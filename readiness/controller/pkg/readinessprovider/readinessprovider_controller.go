@@ -9,35 +9,67 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
 	capabilitiesdiscovery "github.com/vmware-tanzu/tanzu-framework/capabilities/client/pkg/discovery"
-	"github.com/vmware-tanzu/tanzu-framework/util/kubeclient"
+	"github.com/vmware-tanzu/tanzu-framework/readiness/controller/pkg/conditions"
 )
 
 const (
+	// requeueInterval is the default resync interval of providers
 	requeueInterval = 60 * time.Second
 	contextTimeout  = 60 * time.Second
+	// eventBufferSize is the number of pending events for providers referencing changed objects
+	eventBufferSize = 1024
 )
 
 // ReadinessProviderReconciler reconciles a ReadinessProvider object
 type ReadinessProviderReconciler struct {
 	client.Client
-	Clientset                  *kubernetes.Clientset
-	Log                        logr.Logger
-	Scheme                     *runtime.Scheme
-	ResourceExistenceCondition func(context.Context, *capabilitiesdiscovery.ClusterQueryClient, *corev1alpha2.ResourceExistenceCondition, string) (corev1alpha2.ReadinessConditionState, string)
-	RestConfig                 *rest.Config
-	DefaultQueryClient         *capabilitiesdiscovery.ClusterQueryClient
+	Clientset           *kubernetes.Clientset
+	Log                 logr.Logger
+	Scheme              *runtime.Scheme
+	ConditionEvaluators *conditions.Registry
+	RestConfig          *rest.Config
+	DefaultQueryClient  *capabilitiesdiscovery.ClusterQueryClient
+	Recorder            record.EventRecorder
+
+	defaultClients *conditions.Clients
+	clientCache    *clientCache
+	restMapper     meta.RESTMapper
+	watches        *watchIndex
+	kindWatcher    *kindWatcher
+	events         chan event.GenericEvent
+	backoff        *failureBackoff
 }
 
 //+kubebuilder:rbac:groups=core.tanzu.vmware.com,resources=readinessproviders,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core.tanzu.vmware.com,resources=readinessproviders/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=serviceaccounts/token,verbs=create
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=selfsubjectaccessreviews,verbs=create
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -49,53 +81,132 @@ func (r *ReadinessProviderReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	log.Info("starting reconcile")
 
 	readinessProvider := corev1alpha2.ReadinessProvider{}
-	result := ctrl.Result{
-		RequeueAfter: requeueInterval,
-	}
 
 	if err := r.Client.Get(ctxCancel, req.NamespacedName, &readinessProvider); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("ReadinessProvider not found; removing watches")
+			r.watches.remove(req.Name)
+			r.unwatchStaleKinds(log)
+			r.clientCache.release(req.Name)
+			r.backoff.reset(req.Name)
+			return ctrl.Result{}, nil
+		}
 		log.Error(err, "unable to fetch ReadinessProvider")
-		return result, client.IgnoreNotFound(err)
+		return ctrl.Result{}, err
 	}
 
-	var clusterQueryClient *capabilitiesdiscovery.ClusterQueryClient
+	resyncInterval := requeueInterval
+	if readinessProvider.Spec.ResyncInterval != nil && readinessProvider.Spec.ResyncInterval.Duration > 0 {
+		resyncInterval = readinessProvider.Spec.ResyncInterval.Duration
+	}
+	result := ctrl.Result{
+		RequeueAfter: resyncInterval,
+	}
+
+	r.watchReferencedObjects(ctxCancel, log, &readinessProvider)
+
+	previousStatus := readinessProvider.Status.DeepCopy()
+
+	var clients *conditions.Clients
 
 	// If provided in the spec, use the serviceAccount for evaluating conditions
 	if readinessProvider.Spec.ServiceAccountRef != nil {
-		cfg, err := kubeclient.GetConfigForServiceAccount(ctx, r.Clientset, r.RestConfig, readinessProvider.Spec.ServiceAccountRef.Namespace, readinessProvider.Spec.ServiceAccountRef.Name)
+		var err error
+		clients, err = r.clientCache.get(ctxCancel, readinessProvider.Name, readinessProvider.Spec.ServiceAccountRef.Namespace, readinessProvider.Spec.ServiceAccountRef.Name)
 		if err != nil {
 			readinessProvider.Status.Message = err.Error()
 			readinessProvider.Status.State = corev1alpha2.ProviderFailureState
 			readinessProvider.Status.Conditions = []corev1alpha2.ReadinessConditionStatus{}
+			result.RequeueAfter = r.backoff.next(readinessProvider.Name, resyncInterval)
+			r.recordTransitions(&readinessProvider, previousStatus)
 			return result, r.Status().Update(ctxCancel, &readinessProvider)
 		}
-		clusterQueryClient, err = capabilitiesdiscovery.NewClusterQueryClientForConfig(cfg)
-		if err != nil {
-			return ctrl.Result{}, fmt.Errorf("unable to create ClusterQueryClient: %w", err)
-		}
 	} else {
-		clusterQueryClient = r.DefaultQueryClient
+		r.clientCache.release(readinessProvider.Name)
+		clients = r.defaultClients
 	}
 
-	// Evaluate provider conditions
-	readinessProvider.Status.Conditions = make([]corev1alpha2.ReadinessConditionStatus, len(readinessProvider.Spec.Conditions))
+	status, nextDeadline := EvaluateProvider(ctxCancel, log, r.ConditionEvaluators, clients, &readinessProvider)
+	readinessProvider.Status.Conditions = status.Conditions
+	readinessProvider.Status.State = status.State
+	readinessProvider.Status.Message = status.Message
 
-	for i, condition := range readinessProvider.Spec.Conditions {
-		readinessProvider.Status.Conditions[i].Name = condition.Name
-		var state corev1alpha2.ReadinessConditionState
-		var message string
-		state, message = r.ResourceExistenceCondition(ctxCancel, clusterQueryClient, condition.ResourceExistenceCondition, condition.Name)
-		readinessProvider.Status.Conditions[i].State = state
-		readinessProvider.Status.Conditions[i].Message = message
+	if readinessProvider.Status.State == corev1alpha2.ProviderSuccessState {
+		r.backoff.reset(readinessProvider.Name)
+	} else {
+		result.RequeueAfter = r.backoff.next(readinessProvider.Name, resyncInterval)
 	}
 
-	readinessProvider.Status.State, readinessProvider.Status.Message = determineProviderStatus(log, readinessProvider.Status.Conditions)
+	// Re-evaluate when a pending deadline expires, so that the failure is reported on time
+	if nextDeadline > 0 && nextDeadline < result.RequeueAfter {
+		result.RequeueAfter = nextDeadline
+	}
+
+	r.recordTransitions(&readinessProvider, previousStatus)
 
 	log.Info("Successfully reconciled")
 
 	return result, r.Status().Update(ctxCancel, &readinessProvider)
 }
 
+// evaluateOptions holds the options of EvaluateProvider
+type evaluateOptions struct {
+	skipProgressDeadlines bool
+}
+
+// EvaluateOption configures how EvaluateProvider evaluates a provider
+type EvaluateOption func(*evaluateOptions)
+
+// WithoutProgressDeadlines reports the states of the conditions as they are evaluated, without applying their
+// progress deadlines. A failing condition is then reported as failing even if its deadline has not expired, which
+// suits evaluations that have no previous status to measure the deadlines from.
+func WithoutProgressDeadlines() EvaluateOption {
+	return func(o *evaluateOptions) {
+		o.skipProgressDeadlines = true
+	}
+}
+
+// EvaluateProvider evaluates the conditions of the provider with the given clients and returns its computed
+// state, message and condition statuses. Nothing is created or updated, so a provider can be tried out without
+// applying it. Progress deadlines are measured from the current status of the provider; the returned duration
+// is the time until the earliest pending deadline, or 0 if there is none.
+func EvaluateProvider(ctx context.Context, log logr.Logger, registry *conditions.Registry, clients *conditions.Clients,
+	readinessProvider *corev1alpha2.ReadinessProvider, opts ...EvaluateOption) (corev1alpha2.ReadinessProviderStatus, time.Duration) {
+	options := &evaluateOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	status := corev1alpha2.ReadinessProviderStatus{
+		Conditions: make([]corev1alpha2.ReadinessConditionStatus, len(readinessProvider.Spec.Conditions)),
+	}
+
+	previousConditions := make(map[string]*corev1alpha2.ReadinessConditionStatus, len(readinessProvider.Status.Conditions))
+	for i := range readinessProvider.Status.Conditions {
+		previousConditions[readinessProvider.Status.Conditions[i].Name] = &readinessProvider.Status.Conditions[i]
+	}
+
+	now := metav1.Now()
+	var nextDeadline time.Duration
+
+	for i := range readinessProvider.Spec.Conditions {
+		condition := &readinessProvider.Spec.Conditions[i]
+		status.Conditions[i].Name = condition.Name
+		status.Conditions[i].State, status.Conditions[i].Message = registry.Evaluate(ctx, clients, condition)
+
+		if options.skipProgressDeadlines {
+			continue
+		}
+		remaining := applyProgressDeadline(condition, previousConditions[condition.Name], &status.Conditions[i], now)
+		if remaining > 0 && (nextDeadline == 0 || remaining < nextDeadline) {
+			nextDeadline = remaining
+		}
+	}
+
+	status.State, status.Message = determineProviderStatus(log, status.Conditions)
+	return status, nextDeadline
+}
+
 // Evaluate and return cumulative state of ReadinessProvider based on ReadinessConditionStatus values
 func determineProviderStatus(log logr.Logger, conditionStatusList []corev1alpha2.ReadinessConditionStatus) (state corev1alpha2.ReadinessProviderState, message string) {
 	inProgress := false
@@ -116,7 +227,111 @@ func determineProviderStatus(log logr.Logger, conditionStatusList []corev1alpha2
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ReadinessProviderReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	dynamicClient, err := dynamic.NewForConfig(r.RestConfig)
+	if err != nil {
+		return fmt.Errorf("unable to create dynamic client: %w", err)
+	}
+
+	r.defaultClients = &conditions.Clients{
+		QueryClient:   r.DefaultQueryClient,
+		DynamicClient: dynamicClient,
+		RESTMapper:    mgr.GetRESTMapper(),
+	}
+	r.restMapper = mgr.GetRESTMapper()
+	r.watches = newWatchIndex()
+	r.backoff = newFailureBackoff()
+	r.clientCache = newClientCache(r.Clientset, r.RestConfig)
+
+	metadataClient, err := metadata.NewForConfig(r.RestConfig)
+	if err != nil {
+		return fmt.Errorf("unable to create metadata client: %w", err)
+	}
+	r.events = make(chan event.GenericEvent, eventBufferSize)
+	r.kindWatcher = newKindWatcher(metadataClient, r.referencedObjectChanged)
+	if err := mgr.Add(r.kindWatcher); err != nil {
+		return fmt.Errorf("unable to add watcher of referenced kinds: %w", err)
+	}
+
+	// Status updates made by the controller itself must not trigger another evaluation
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&corev1alpha2.ReadinessProvider{}).
+		For(&corev1alpha2.ReadinessProvider{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &corev1.ServiceAccount{}}, handler.Funcs{DeleteFunc: r.serviceAccountDeleted}, builder.OnlyMetadata).
+		Watches(&source.Channel{Source: r.events}, &handler.EnqueueRequestForObject{}).
 		Complete(r)
 }
+
+// referencedObjectChanged enqueues the providers that reference the changed object
+func (r *ReadinessProviderReconciler) referencedObjectChanged(groupKind schema.GroupKind, obj metav1.Object) {
+	for _, req := range r.watches.requestsFor(groupKind, obj) {
+		r.events <- event.GenericEvent{Object: &corev1alpha2.ReadinessProvider{ObjectMeta: metav1.ObjectMeta{Name: req.Name}}}
+	}
+}
+
+// serviceAccountDeleted evicts the clients of a deleted service account
+// and enqueues the providers that use it, so that they report the failure
+func (r *ReadinessProviderReconciler) serviceAccountDeleted(e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	namespace, name := e.Object.GetNamespace(), e.Object.GetName()
+	r.clientCache.evict(namespace, name)
+
+	providers := &corev1alpha2.ReadinessProviderList{}
+	if err := r.Client.List(context.Background(), providers, client.UnsafeDisableDeepCopy); err != nil {
+		r.Log.Error(err, "unable to list providers using deleted service account", "namespace", namespace, "name", name)
+		return
+	}
+	for i := range providers.Items {
+		saRef := providers.Items[i].Spec.ServiceAccountRef
+		if saRef != nil && saRef.Namespace == namespace && saRef.Name == name {
+			q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: providers.Items[i].Name}})
+		}
+	}
+}
+
+// watchReferencedObjects starts watching the kinds of the objects referenced by the conditions of the provider,
+// so that changes to those objects trigger an evaluation of the provider without waiting for the resync interval.
+// Only object metadata is cached for the watched kinds. Kinds that the controller is not allowed to list and watch
+// in all namespaces are only evaluated every resync interval, and watches of kinds that are no longer referenced
+// are stopped.
+func (r *ReadinessProviderReconciler) watchReferencedObjects(ctx context.Context, log logr.Logger, readinessProvider *corev1alpha2.ReadinessProvider) {
+	var refs []corev1.ObjectReference
+	for i := range readinessProvider.Spec.Conditions {
+		refs = append(refs, conditions.ReferencedObjects(&readinessProvider.Spec.Conditions[i])...)
+	}
+
+	for _, gvk := range r.watches.update(readinessProvider.Name, refs) {
+		// Kinds that are not served yet are retried on the next evaluation
+		mapping, err := r.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			log.V(1).Info("unable to watch kind", "gvk", gvk.String(), "reason", err.Error())
+			continue
+		}
+
+		allowed, err := canListAndWatch(ctx, r.Clientset.AuthorizationV1().SelfSubjectAccessReviews(), mapping.Resource)
+		if err != nil {
+			log.Error(err, "unable to check access to kind", "gvk", gvk.String())
+			continue
+		}
+		if !allowed {
+			log.Info("not allowed to list and watch kind referenced by conditions; it is only evaluated every resync interval", "gvk", gvk.String())
+			r.watches.markDenied(gvk)
+			continue
+		}
+
+		if err := r.kindWatcher.watch(gvk, mapping.Resource); err != nil {
+			log.Error(err, "unable to watch kind", "gvk", gvk.String())
+			continue
+		}
+
+		log.Info("watching kind referenced by conditions", "gvk", gvk.String())
+		r.watches.markWatched(gvk)
+	}
+
+	r.unwatchStaleKinds(log)
+}
+
+// unwatchStaleKinds stops the watches of the kinds that no provider references any more
+func (r *ReadinessProviderReconciler) unwatchStaleKinds(log logr.Logger) {
+	for _, gvk := range r.watches.unwatchStale() {
+		r.kindWatcher.unwatch(gvk)
+		log.Info("stopped watching kind no longer referenced by conditions", "gvk", gvk.String())
+	}
+}
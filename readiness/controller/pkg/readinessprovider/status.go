@@ -0,0 +1,72 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readinessprovider
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// Reasons of the Ready condition and of the events emitted on state changes
+const (
+	succeededReason  = "Succeeded"
+	failedReason     = "Failed"
+	inProgressReason = "InProgress"
+)
+
+// recordTransitions carries the transition times of the previous condition statuses over to the current ones,
+// sets the Ready status condition of the provider and emits an event if the state of the provider changed
+func (r *ReadinessProviderReconciler) recordTransitions(readinessProvider *corev1alpha2.ReadinessProvider, previous *corev1alpha2.ReadinessProviderStatus) {
+	now := metav1.Now()
+
+	previousConditions := make(map[string]*corev1alpha2.ReadinessConditionStatus, len(previous.Conditions))
+	for i := range previous.Conditions {
+		previousConditions[previous.Conditions[i].Name] = &previous.Conditions[i]
+	}
+
+	for i := range readinessProvider.Status.Conditions {
+		status := &readinessProvider.Status.Conditions[i]
+		if prev, ok := previousConditions[status.Name]; ok && prev.State == status.State && prev.LastTransitionTime != nil {
+			status.LastTransitionTime = prev.LastTransitionTime
+			continue
+		}
+		status.LastTransitionTime = now.DeepCopy()
+	}
+
+	condition := metav1.Condition{
+		Type:               corev1alpha2.ReadyConditionType,
+		Message:            readinessProvider.Status.Message,
+		ObservedGeneration: readinessProvider.Generation,
+	}
+	eventType := corev1.EventTypeNormal
+
+	switch readinessProvider.Status.State {
+	case corev1alpha2.ProviderSuccessState:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = succeededReason
+	case corev1alpha2.ProviderFailureState:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = failedReason
+		for i := range readinessProvider.Status.Conditions {
+			if readinessProvider.Status.Conditions[i].Reason == corev1alpha2.ProgressDeadlineExceededReason {
+				condition.Reason = corev1alpha2.ProgressDeadlineExceededReason
+				break
+			}
+		}
+		eventType = corev1.EventTypeWarning
+	default:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = inProgressReason
+	}
+
+	readinessProvider.Status.StatusConditions = previous.StatusConditions
+	meta.SetStatusCondition(&readinessProvider.Status.StatusConditions, condition)
+
+	if readinessProvider.Status.State != previous.State {
+		r.Recorder.Eventf(readinessProvider, eventType, condition.Reason, "Provider state changed to %s: %s", readinessProvider.Status.State, readinessProvider.Status.Message)
+	}
+}
@@ -34,6 +34,7 @@ import (
 
 	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
 	capabilitiesdiscovery "github.com/vmware-tanzu/tanzu-framework/capabilities/client/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-framework/readiness/controller/pkg/conditions"
 	testutil "github.com/vmware-tanzu/tanzu-framework/util/test"
 	//+kubebuilder:scaffold:imports
 )
@@ -149,25 +150,14 @@ var _ = BeforeSuite(func() {
 	Expect(queryClient).ToNot(BeNil())
 
 	err = (&ReadinessProviderReconciler{
-		Client:    k8sManager.GetClient(),
-		Clientset: kubernetes.NewForConfigOrDie(k8sManager.GetConfig()),
-		Scheme:    k8sManager.GetScheme(),
-		Log:       setupLog,
-		ResourceExistenceCondition: func(context context.Context, client *capabilitiesdiscovery.ClusterQueryClient, rec *corev1alpha2.ResourceExistenceCondition, conditionName string) (corev1alpha2.ReadinessConditionState, string) {
-			if rec.Kind == "failurekind" {
-				return corev1alpha2.ConditionFailureState, "TestFailure"
-			}
-			if rec.Kind == "inprogresskind" {
-				return corev1alpha2.ConditionInProgressState, "TestInProgress"
-			}
-			if rec.Kind == "repeatkind" {
-				calls++
-			}
-
-			return corev1alpha2.ConditionSuccessState, "TestSuccess"
-		},
-		RestConfig:         k8sManager.GetConfig(),
-		DefaultQueryClient: queryClient,
+		Client:              k8sManager.GetClient(),
+		Clientset:           kubernetes.NewForConfigOrDie(k8sManager.GetConfig()),
+		Scheme:              k8sManager.GetScheme(),
+		Log:                 setupLog,
+		ConditionEvaluators: testConditionEvaluators(),
+		RestConfig:          k8sManager.GetConfig(),
+		DefaultQueryClient:  queryClient,
+		Recorder:            k8sManager.GetEventRecorderFor("readinessprovider-controller"),
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -190,6 +180,26 @@ var _ = BeforeSuite(func() {
 	Expect(err).ToNot(HaveOccurred())
 })
 
+func testConditionEvaluators() *conditions.Registry {
+	registry := conditions.NewRegistry()
+	registry.Register(corev1alpha2.ResourceExistenceConditionType, conditions.EvaluatorFunc(
+		func(context context.Context, clients *conditions.Clients, condition *corev1alpha2.ReadinessProviderCondition) (corev1alpha2.ReadinessConditionState, string) {
+			rec := condition.ResourceExistenceCondition
+			if rec.Name == "failure" {
+				return corev1alpha2.ConditionFailureState, "TestFailure"
+			}
+			if rec.Name == "inprogress" {
+				return corev1alpha2.ConditionInProgressState, "TestInProgress"
+			}
+			if rec.Name == "repeat" {
+				calls++
+			}
+
+			return corev1alpha2.ConditionSuccessState, "TestSuccess"
+		}))
+	return registry
+}
+
 var _ = AfterSuite(func() {
 	cancel()
 	By("tearing down the test environment")
@@ -220,11 +230,11 @@ var _ = Describe("Readiness Provider controller", func() {
 		readinessProvider := getTestReadinessProvider()
 		readinessProvider.Spec.Conditions = append(readinessProvider.Spec.Conditions, corev1alpha2.ReadinessProviderCondition{
 			Name:                       "cond1",
-			ResourceExistenceCondition: &corev1alpha2.ResourceExistenceCondition{},
+			ResourceExistenceCondition: getTestResourceExistenceCondition("success"),
 		})
 		readinessProvider.Spec.Conditions = append(readinessProvider.Spec.Conditions, corev1alpha2.ReadinessProviderCondition{
 			Name:                       "cond1",
-			ResourceExistenceCondition: &corev1alpha2.ResourceExistenceCondition{},
+			ResourceExistenceCondition: getTestResourceExistenceCondition("success"),
 		})
 		err := k8sClient.Create(ctx, readinessProvider)
 		Expect(err).To(BeNil())
@@ -243,13 +253,11 @@ var _ = Describe("Readiness Provider controller", func() {
 		readinessProvider := getTestReadinessProvider()
 		readinessProvider.Spec.Conditions = append(readinessProvider.Spec.Conditions, corev1alpha2.ReadinessProviderCondition{
 			Name:                       "cond1",
-			ResourceExistenceCondition: &corev1alpha2.ResourceExistenceCondition{},
+			ResourceExistenceCondition: getTestResourceExistenceCondition("success"),
 		})
 		readinessProvider.Spec.Conditions = append(readinessProvider.Spec.Conditions, corev1alpha2.ReadinessProviderCondition{
-			Name: "cond2",
-			ResourceExistenceCondition: &corev1alpha2.ResourceExistenceCondition{
-				Kind: "failurekind",
-			},
+			Name:                       "cond2",
+			ResourceExistenceCondition: getTestResourceExistenceCondition("failure"),
 		})
 		err := k8sClient.Create(ctx, readinessProvider)
 		Expect(err).To(BeNil())
@@ -268,13 +276,11 @@ var _ = Describe("Readiness Provider controller", func() {
 		readinessProvider := getTestReadinessProvider()
 		readinessProvider.Spec.Conditions = append(readinessProvider.Spec.Conditions, corev1alpha2.ReadinessProviderCondition{
 			Name:                       "cond1",
-			ResourceExistenceCondition: &corev1alpha2.ResourceExistenceCondition{},
+			ResourceExistenceCondition: getTestResourceExistenceCondition("success"),
 		})
 		readinessProvider.Spec.Conditions = append(readinessProvider.Spec.Conditions, corev1alpha2.ReadinessProviderCondition{
-			Name: "cond2",
-			ResourceExistenceCondition: &corev1alpha2.ResourceExistenceCondition{
-				Kind: "inprogresskind",
-			},
+			Name:                       "cond2",
+			ResourceExistenceCondition: getTestResourceExistenceCondition("inprogress"),
 		})
 		err := k8sClient.Create(ctx, readinessProvider)
 		Expect(err).To(BeNil())
@@ -292,16 +298,12 @@ var _ = Describe("Readiness Provider controller", func() {
 	It("should fail when one of the conditions does not satisfy and other is in progress", func() {
 		readinessProvider := getTestReadinessProvider()
 		readinessProvider.Spec.Conditions = append(readinessProvider.Spec.Conditions, corev1alpha2.ReadinessProviderCondition{
-			Name: "cond1",
-			ResourceExistenceCondition: &corev1alpha2.ResourceExistenceCondition{
-				Kind: "inprogresskind",
-			},
+			Name:                       "cond1",
+			ResourceExistenceCondition: getTestResourceExistenceCondition("inprogress"),
 		})
 		readinessProvider.Spec.Conditions = append(readinessProvider.Spec.Conditions, corev1alpha2.ReadinessProviderCondition{
-			Name: "cond2",
-			ResourceExistenceCondition: &corev1alpha2.ResourceExistenceCondition{
-				Kind: "failurekind",
-			},
+			Name:                       "cond2",
+			ResourceExistenceCondition: getTestResourceExistenceCondition("failure"),
 		})
 		err := k8sClient.Create(ctx, readinessProvider)
 		Expect(err).To(BeNil())
@@ -320,7 +322,7 @@ var _ = Describe("Readiness Provider controller", func() {
 		readinessProvider := getTestReadinessProvider()
 		readinessProvider.Spec.Conditions = append(readinessProvider.Spec.Conditions, corev1alpha2.ReadinessProviderCondition{
 			Name:                       "cond1",
-			ResourceExistenceCondition: &corev1alpha2.ResourceExistenceCondition{},
+			ResourceExistenceCondition: getTestResourceExistenceCondition("success"),
 		})
 		err := k8sClient.Create(ctx, readinessProvider)
 		Expect(err).To(BeNil())
@@ -334,10 +336,8 @@ var _ = Describe("Readiness Provider controller", func() {
 		}, timeout, interval).Should(BeTrue())
 
 		readinessProvider.Spec.Conditions = append(readinessProvider.Spec.Conditions, corev1alpha2.ReadinessProviderCondition{
-			Name: "cond1",
-			ResourceExistenceCondition: &corev1alpha2.ResourceExistenceCondition{
-				Kind: "failurekind",
-			},
+			Name:                       "cond1",
+			ResourceExistenceCondition: getTestResourceExistenceCondition("failure"),
 		})
 
 		err = k8sClient.Update(ctx, readinessProvider)
@@ -354,7 +354,7 @@ var _ = Describe("Readiness Provider controller", func() {
 		readinessProvider := getTestReadinessProvider()
 		readinessProvider.Spec.Conditions = append(readinessProvider.Spec.Conditions, corev1alpha2.ReadinessProviderCondition{
 			Name:                       "cond1",
-			ResourceExistenceCondition: &corev1alpha2.ResourceExistenceCondition{},
+			ResourceExistenceCondition: getTestResourceExistenceCondition("success"),
 		})
 		err := k8sClient.Create(ctx, readinessProvider)
 		Expect(err).To(BeNil())
@@ -379,7 +379,7 @@ var _ = Describe("Readiness Provider controller", func() {
 		readinessProvider := getTestReadinessProvider()
 		readinessProvider.Spec.Conditions = append(readinessProvider.Spec.Conditions, corev1alpha2.ReadinessProviderCondition{
 			Name:                       "cond1",
-			ResourceExistenceCondition: &corev1alpha2.ResourceExistenceCondition{},
+			ResourceExistenceCondition: getTestResourceExistenceCondition("success"),
 		})
 		err := k8sClient.Create(ctx, readinessProvider)
 		Expect(err).To(BeNil())
@@ -432,7 +432,7 @@ var _ = Describe("Readiness Provider controller", func() {
 		}
 		readinessProvider.Spec.Conditions = append(readinessProvider.Spec.Conditions, corev1alpha2.ReadinessProviderCondition{
 			Name:                       "cond1",
-			ResourceExistenceCondition: &corev1alpha2.ResourceExistenceCondition{},
+			ResourceExistenceCondition: getTestResourceExistenceCondition("success"),
 		})
 		err := k8sClient.Create(ctx, readinessProvider)
 		Expect(err).To(BeNil())
@@ -451,6 +451,51 @@ var _ = Describe("Readiness Provider controller", func() {
 		Expect(len(status.Conditions)).To(Equal(0))
 	})
 
+	It("should fail when the kind of a condition target does not resolve", func() {
+		readinessProvider := getTestReadinessProvider()
+		condition := getTestResourceExistenceCondition("success")
+		condition.Kind = "NonExistentKind"
+		readinessProvider.Spec.Conditions = append(readinessProvider.Spec.Conditions, corev1alpha2.ReadinessProviderCondition{
+			Name:                       "cond1",
+			ResourceExistenceCondition: condition,
+		})
+		err := k8sClient.Create(ctx, readinessProvider)
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should fail when the namespace of a condition target is empty", func() {
+		readinessProvider := getTestReadinessProvider()
+		condition := getTestResourceExistenceCondition("success")
+		emptyNamespace := ""
+		condition.Namespace = &emptyNamespace
+		readinessProvider.Spec.Conditions = append(readinessProvider.Spec.Conditions, corev1alpha2.ReadinessProviderCondition{
+			Name:                       "cond1",
+			ResourceExistenceCondition: condition,
+		})
+		err := k8sClient.Create(ctx, readinessProvider)
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should fail when the service account cannot get a condition target", func() {
+		readinessProvider := getTestReadinessProvider()
+		readinessProvider.Spec.ServiceAccountRef = &corev1alpha2.ServiceAccountRef{
+			Name:      "pod-sa",
+			Namespace: "default",
+		}
+		condition := getTestResourceExistenceCondition("success")
+		condition.Kind = "Secret"
+		readinessProvider.Spec.Conditions = append(readinessProvider.Spec.Conditions, corev1alpha2.ReadinessProviderCondition{
+			Name:                       "cond1",
+			ResourceExistenceCondition: condition,
+		})
+		err := k8sClient.Create(ctx, readinessProvider)
+		Expect(err).NotTo(BeNil())
+
+		condition.Kind = "Pod"
+		err = k8sClient.Create(ctx, readinessProvider)
+		Expect(err).To(BeNil())
+	})
+
 })
 
 func getTestReadinessProvider() *corev1alpha2.ReadinessProvider {
@@ -465,3 +510,15 @@ func getTestReadinessProvider() *corev1alpha2.ReadinessProvider {
 		},
 	}
 }
+
+// getTestResourceExistenceCondition returns a condition on a resource that resolves through the RESTMapper;
+// the name of the resource selects the result of the stub evaluator
+func getTestResourceExistenceCondition(name string) *corev1alpha2.ResourceExistenceCondition {
+	namespace := "default"
+	return &corev1alpha2.ResourceExistenceCondition{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Namespace:  &namespace,
+		Name:       name,
+	}
+}
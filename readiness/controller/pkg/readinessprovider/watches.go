@@ -0,0 +1,169 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readinessprovider
+
+import (
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// accessRecheckInterval is the interval after which the controller checks again whether it may watch a kind
+// that it was not allowed to watch, so that permissions granted later are picked up.
+const accessRecheckInterval = 10 * time.Minute
+
+// watchedObject identifies an object referenced by the conditions of a provider.
+// The version is left out so that events for any version of the object are matched.
+type watchedObject struct {
+	groupKind schema.GroupKind
+	namespace string
+	name      string
+}
+
+// watchIndex tracks the objects referenced by each provider and the kinds that are being watched
+type watchIndex struct {
+	lock sync.RWMutex
+
+	// providers maps each referenced object to the names of the providers that reference it
+	providers map[watchedObject]map[string]struct{}
+
+	// objects maps each provider name to the objects that it references
+	objects map[string][]watchedObject
+
+	// kindProviders maps each referenced kind to the names of the providers that reference it
+	kindProviders map[schema.GroupVersionKind]map[string]struct{}
+
+	// watchedKinds is the set of kinds for which a watch has been started
+	watchedKinds map[schema.GroupVersionKind]struct{}
+
+	// deniedKinds maps the kinds that the controller is not allowed to watch to the time of the access check
+	deniedKinds map[schema.GroupVersionKind]time.Time
+}
+
+func newWatchIndex() *watchIndex {
+	return &watchIndex{
+		providers:     make(map[watchedObject]map[string]struct{}),
+		objects:       make(map[string][]watchedObject),
+		kindProviders: make(map[schema.GroupVersionKind]map[string]struct{}),
+		watchedKinds:  make(map[schema.GroupVersionKind]struct{}),
+		deniedKinds:   make(map[schema.GroupVersionKind]time.Time),
+	}
+}
+
+// update replaces the objects referenced by the provider and returns the kinds that are not being watched yet.
+// Kinds that the controller was recently not allowed to watch are left out.
+func (w *watchIndex) update(providerName string, refs []corev1.ObjectReference) []schema.GroupVersionKind {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.removeLocked(providerName)
+
+	var unwatched []schema.GroupVersionKind
+	seen := make(map[schema.GroupVersionKind]struct{})
+
+	for i := range refs {
+		gvk := refs[i].GroupVersionKind()
+		obj := watchedObject{groupKind: gvk.GroupKind(), namespace: refs[i].Namespace, name: refs[i].Name}
+
+		if _, ok := w.providers[obj]; !ok {
+			w.providers[obj] = make(map[string]struct{})
+		}
+		w.providers[obj][providerName] = struct{}{}
+		w.objects[providerName] = append(w.objects[providerName], obj)
+
+		if _, ok := w.kindProviders[gvk]; !ok {
+			w.kindProviders[gvk] = make(map[string]struct{})
+		}
+		w.kindProviders[gvk][providerName] = struct{}{}
+
+		if _, ok := w.watchedKinds[gvk]; ok {
+			continue
+		}
+		if deniedAt, ok := w.deniedKinds[gvk]; ok && time.Since(deniedAt) < accessRecheckInterval {
+			continue
+		}
+		if _, ok := seen[gvk]; !ok {
+			seen[gvk] = struct{}{}
+			unwatched = append(unwatched, gvk)
+		}
+	}
+
+	return unwatched
+}
+
+// remove forgets the objects referenced by the provider
+func (w *watchIndex) remove(providerName string) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.removeLocked(providerName)
+}
+
+func (w *watchIndex) removeLocked(providerName string) {
+	for _, obj := range w.objects[providerName] {
+		delete(w.providers[obj], providerName)
+		if len(w.providers[obj]) == 0 {
+			delete(w.providers, obj)
+		}
+	}
+	delete(w.objects, providerName)
+
+	for gvk, providers := range w.kindProviders {
+		delete(providers, providerName)
+		if len(providers) == 0 {
+			delete(w.kindProviders, gvk)
+		}
+	}
+}
+
+// markWatched records that a watch has been started for the kind
+func (w *watchIndex) markWatched(gvk schema.GroupVersionKind) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.watchedKinds[gvk] = struct{}{}
+}
+
+// markDenied records that the controller is not allowed to watch the kind
+func (w *watchIndex) markDenied(gvk schema.GroupVersionKind) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.deniedKinds[gvk] = time.Now()
+}
+
+// unwatchStale forgets the watched kinds that no provider references any more, and returns them so that their
+// watches can be stopped
+func (w *watchIndex) unwatchStale() []schema.GroupVersionKind {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	var stale []schema.GroupVersionKind
+	for gvk := range w.watchedKinds {
+		if _, ok := w.kindProviders[gvk]; !ok {
+			delete(w.watchedKinds, gvk)
+			stale = append(stale, gvk)
+		}
+	}
+	return stale
+}
+
+// requestsFor returns the reconcile requests for the providers that reference the given object
+func (w *watchIndex) requestsFor(groupKind schema.GroupKind, obj metav1.Object) []reconcile.Request {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+
+	providers := w.providers[watchedObject{groupKind: groupKind, namespace: obj.GetNamespace(), name: obj.GetName()}]
+
+	requests := make([]reconcile.Request, 0, len(providers))
+	for name := range providers {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: name}})
+	}
+	return requests
+}
@@ -0,0 +1,140 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readinessprovider
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func requestNames(w *watchIndex, groupKind schema.GroupKind, namespace, name string) []string {
+	obj := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+
+	names := []string{}
+	for _, req := range w.requestsFor(groupKind, obj) {
+		names = append(names, req.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestWatchIndex(t *testing.T) {
+	w := newWatchIndex()
+	deployment := schema.GroupKind{Group: "apps", Kind: "Deployment"}
+
+	unwatched := w.update("provider1", []corev1.ObjectReference{
+		{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "foo"},
+		{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "bar"},
+		{APIVersion: "v1", Kind: "Service", Namespace: "default", Name: "foo"},
+	})
+	if len(unwatched) != 2 {
+		t.Fatalf("expected 2 unwatched kinds, got %v", unwatched)
+	}
+	w.markWatched(unwatched[0])
+	w.markWatched(unwatched[1])
+
+	unwatched = w.update("provider2", []corev1.ObjectReference{
+		{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "foo"},
+	})
+	if len(unwatched) != 0 {
+		t.Fatalf("expected no unwatched kinds, got %v", unwatched)
+	}
+
+	if got := requestNames(w, deployment, "default", "foo"); len(got) != 2 || got[0] != "provider1" || got[1] != "provider2" {
+		t.Errorf("expected both providers for default/foo, got %v", got)
+	}
+	if got := requestNames(w, deployment, "other", "foo"); len(got) != 0 {
+		t.Errorf("expected no providers for other/foo, got %v", got)
+	}
+
+	// provider1 no longer references default/foo
+	w.update("provider1", []corev1.ObjectReference{
+		{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "bar"},
+	})
+	if got := requestNames(w, deployment, "default", "foo"); len(got) != 1 || got[0] != "provider2" {
+		t.Errorf("expected provider2 for default/foo, got %v", got)
+	}
+
+	w.remove("provider2")
+	if got := requestNames(w, deployment, "default", "foo"); len(got) != 0 {
+		t.Errorf("expected no providers for default/foo, got %v", got)
+	}
+	if got := requestNames(w, deployment, "default", "bar"); len(got) != 1 || got[0] != "provider1" {
+		t.Errorf("expected provider1 for default/bar, got %v", got)
+	}
+}
+
+func TestWatchIndexStaleAndDeniedKinds(t *testing.T) {
+	w := newWatchIndex()
+	deployment := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	secret := schema.GroupVersionKind{Version: "v1", Kind: "Secret"}
+
+	unwatched := w.update("provider1", []corev1.ObjectReference{
+		{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "foo"},
+		{APIVersion: "v1", Kind: "Secret", Namespace: "default", Name: "foo"},
+	})
+	if len(unwatched) != 2 {
+		t.Fatalf("expected 2 unwatched kinds, got %v", unwatched)
+	}
+	w.markWatched(deployment)
+	w.markDenied(secret)
+	w.update("provider2", []corev1.ObjectReference{
+		{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "bar"},
+	})
+
+	// The access to a denied kind is not checked again until the recheck interval has passed
+	if unwatched := w.update("provider1", []corev1.ObjectReference{
+		{APIVersion: "v1", Kind: "Secret", Namespace: "default", Name: "foo"},
+	}); len(unwatched) != 0 {
+		t.Errorf("expected no unwatched kinds, got %v", unwatched)
+	}
+	w.deniedKinds[secret] = time.Now().Add(-accessRecheckInterval)
+	if unwatched := w.update("provider1", []corev1.ObjectReference{
+		{APIVersion: "v1", Kind: "Secret", Namespace: "default", Name: "foo"},
+	}); len(unwatched) != 1 || unwatched[0] != secret {
+		t.Errorf("expected Secret to be checked again, got %v", unwatched)
+	}
+
+	// Deployments are still referenced by provider2
+	if stale := w.unwatchStale(); len(stale) != 0 {
+		t.Errorf("expected no stale kinds, got %v", stale)
+	}
+
+	w.remove("provider2")
+	if stale := w.unwatchStale(); len(stale) != 1 || stale[0] != deployment {
+		t.Errorf("expected Deployment to be stale, got %v", stale)
+	}
+	if stale := w.unwatchStale(); len(stale) != 0 {
+		t.Errorf("expected no stale kinds once unwatched, got %v", stale)
+	}
+
+	// A kind that is referenced again is watched again
+	if unwatched := w.update("provider2", []corev1.ObjectReference{
+		{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "bar"},
+	}); len(unwatched) != 1 || unwatched[0] != deployment {
+		t.Errorf("expected Deployment to be unwatched, got %v", unwatched)
+	}
+}
+
+func TestFailureBackoff(t *testing.T) {
+	b := newFailureBackoff()
+	maxInterval := 30 * time.Second
+
+	want := []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 30 * time.Second, 30 * time.Second}
+	for i, w := range want {
+		if got := b.next("provider", maxInterval); got != w {
+			t.Errorf("attempt %d: got %s, want %s", i, got, w)
+		}
+	}
+
+	b.reset("provider")
+	if got := b.next("provider", maxInterval); got != initialFailureBackoff {
+		t.Errorf("got %s after reset, want %s", got, initialFailureBackoff)
+	}
+}